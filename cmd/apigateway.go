@@ -11,7 +11,7 @@ var apigatewayCmd = &cobra.Command{
 	Use:   "apigateway",
 	Short: "Manage APIGateway",
 	Run: func(cmd *cobra.Command, _ []string) {
-		config, err := cmd.Flags().GetString(flagConfig)
+		config, err := getConfig(cmd)
 		if err != nil {
 			printErrorAndExit(err)
 		}
@@ -31,8 +31,8 @@ var apigatewayCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(apigatewayCmd)
 
-	apigatewayCmd.Flags().StringP(flagConfig, "c", "",
-		"Path to the configuration file. For example: ./apigateway.config.yaml")
+	apigatewayCmd.Flags().StringArrayP(flagConfig, "c", nil,
+		"Path to the configuration file, repeat it to compose several files. For example: ./apigateway.config.yaml")
 	apigatewayCmd.Flags().StringP(flagOutput, "o", "",
 		"Path to the output folder. For example: ./output")
 
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/custom"
+)
+
+// customCmd represents the custom command.
+var customCmd = &cobra.Command{
+	Use:   "custom",
+	Short: "Manage the custom resources of the plugins",
+	Run: func(cmd *cobra.Command, _ []string) {
+		config, err := getConfig(cmd)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		output, err := cmd.Flags().GetString(flagOutput)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		err = custom.NewCustom(config, output).Build()
+		if err != nil {
+			printErrorAndExit(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(customCmd)
+
+	customCmd.Flags().StringArrayP(flagConfig, "c", nil,
+		"Path to the configuration file, repeat it to compose several files. For example: ./custom.config.yaml")
+	customCmd.Flags().StringP(flagOutput, "o", "", "Path to the output folder. For example: ./output")
+
+	_ = customCmd.MarkFlagRequired(flagConfig)
+	_ = customCmd.MarkFlagRequired(flagOutput)
+}
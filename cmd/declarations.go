@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/declarations"
+)
+
+// declarationsCmd represents the declarations command.
+var declarationsCmd = &cobra.Command{
+	Use:   "declarations",
+	Short: "Declare the variables and outputs of the generated module",
+	Run: func(cmd *cobra.Command, _ []string) {
+		output, err := cmd.Flags().GetString(flagOutput)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		err = declarations.NewDeclarations(output).Build()
+		if err != nil {
+			printErrorAndExit(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(declarationsCmd)
+
+	declarationsCmd.Flags().StringP(flagOutput, "o", "", "Path to the output folder. For example: ./output/mystack")
+
+	_ = declarationsCmd.MarkFlagRequired(flagOutput)
+}
@@ -13,10 +13,10 @@ var diagramCmd = &cobra.Command{
 	Short: "Manage Diagram",
 	Run: func(cmd *cobra.Command, _ []string) {
 		diagramFilename, _ := cmd.Flags().GetString(flagDiagram)
-		configFile, _ := cmd.Flags().GetString(flagConfig)
+		yamlParser, _ := getConfig(cmd)
 		output, _ := cmd.Flags().GetString(flagOutput)
 
-		if err := diagram.NewDiagram(diagramFilename, configFile, output).Build(); err != nil {
+		if err := diagram.NewDiagram(diagramFilename, yamlParser, output).Build(); err != nil {
 			printErrorAndExit(err)
 		}
 
@@ -28,8 +28,8 @@ func init() {
 	rootCmd.AddCommand(diagramCmd)
 
 	diagramCmd.Flags().StringP(flagDiagram, "d", "", "Path to the XML file. For example: ./diagram.xml")
-	diagramCmd.Flags().StringP(flagConfig, "c", "",
-		"Path to the YAML config file. For example: ./diagram.config.yaml")
+	diagramCmd.Flags().StringArrayP(flagConfig, "c", nil,
+		"Path to the YAML config file, repeat it to compose several files. For example: ./diagram.config.yaml")
 	diagramCmd.Flags().StringP(flagOutput, "o", "", "Path to the output file. For example: ./diagram.yaml")
 
 	_ = diagramCmd.MarkFlagRequired(flagDiagram)
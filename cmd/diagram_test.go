@@ -83,7 +83,7 @@ func TestDiagram_Run(t *testing.T) {
 			}
 
 			_ = diagramCmd.Flags().Set(flagDiagram, tc.args.diagram)
-			_ = diagramCmd.Flags().Set(flagConfig, tc.args.configFile)
+			setConfig(diagramCmd, tc.args.configFile)
 			_ = diagramCmd.Flags().Set(flagOutput, tc.args.output)
 
 			diagramCmd.Run(diagramCmd, []string{})
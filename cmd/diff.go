@@ -3,6 +3,7 @@ package cmd
 import (
 	"os"
 	"path"
+	"slices"
 
 	"github.com/spf13/cobra"
 
@@ -30,17 +31,31 @@ var diffCmd = &cobra.Command{
 			printErrorAndExit(err)
 		}
 
-		leftRc, err := yamltoresources.Parse(left)
+		opts, err := getConfigOptions(cmd)
 		if err != nil {
 			printErrorAndExit(err)
 		}
 
-		rightRc, err := yamltoresources.Parse(right)
+		leftRc, err := yamltoresources.Parse(left, opts...)
 		if err != nil {
 			printErrorAndExit(err)
 		}
 
-		resources.PrintDiff(leftRc, rightRc, awsresources.AvailableTypes)
+		// Parsing a configuration registers the resource types of its plugins only, so the types of both are kept.
+		types := awsresources.Types()
+
+		rightRc, err := yamltoresources.Parse(right, opts...)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		for _, resourceType := range awsresources.Types() {
+			if !slices.Contains(types, resourceType) {
+				types = append(types, resourceType)
+			}
+		}
+
+		resources.PrintDiff(leftRc, rightRc, types)
 
 		addedResourcesByType, removedResourcesByType, addedRelationships, removedRelationships :=
 			resources.FindDifferences(leftRc, rightRc)
@@ -21,7 +21,7 @@ var drawCmd = &cobra.Command{
 			printErrorAndExit(err)
 		}
 
-		configFilename, err := cmd.Flags().GetString(flagConfig)
+		yamlParser, err := getConfig(cmd)
 		if err != nil {
 			printErrorAndExit(err)
 		}
@@ -31,7 +31,7 @@ var drawCmd = &cobra.Command{
 			printErrorAndExit(err)
 		}
 
-		err = draw.NewDraw(workdirs, files, configFilename, output).Build()
+		err = draw.NewDraw(workdirs, files, yamlParser, output).Build()
 		if err != nil {
 			printErrorAndExit(err)
 		}
@@ -45,8 +45,8 @@ func init() {
 		"Path to the folder where the terraform files are. For example: ./workdir")
 	drawCmd.Flags().StringArrayP(flagFile, "", nil,
 		"Path to the specific terraform file. For example: ./workdir/sqs.tf")
-	drawCmd.Flags().StringP(flagConfig, "c", "",
-		"Path to the YAML config file. For example: ./draw.config.yaml")
+	drawCmd.Flags().StringArrayP(flagConfig, "c", nil,
+		"Path to the YAML config file, repeat it to compose several files. For example: ./draw.config.yaml")
 	drawCmd.Flags().StringP(flagOutput, "o", "", "Path to the output folder. For example: ./output")
 
 	_ = drawCmd.MarkFlagRequired(flagConfig)
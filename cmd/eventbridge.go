@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/eventbridge"
+)
+
+// eventBridgeCmd represents the eventbridge command.
+var eventBridgeCmd = &cobra.Command{
+	Use:   "eventbridge",
+	Short: "Manage EventBridge event buses, rules and targets",
+	Run: func(cmd *cobra.Command, _ []string) {
+		config, err := getConfig(cmd)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		output, err := cmd.Flags().GetString(flagOutput)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		err = eventbridge.NewEventBridge(config, output).Build()
+		if err != nil {
+			printErrorAndExit(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(eventBridgeCmd)
+
+	eventBridgeCmd.Flags().StringArrayP(flagConfig, "c", nil,
+		"Path to the configuration file, repeat it to compose several files. For example: ./eventbridge.config.yaml")
+	eventBridgeCmd.Flags().StringP(flagOutput, "o", "", "Path to the output folder. For example: ./output")
+
+	_ = eventBridgeCmd.MarkFlagRequired(flagConfig)
+	_ = eventBridgeCmd.MarkFlagRequired(flagOutput)
+}
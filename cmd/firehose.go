@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/firehose"
+)
+
+// firehoseCmd represents the firehose command.
+var firehoseCmd = &cobra.Command{
+	Use:   "firehose",
+	Short: "Manage Kinesis Data Firehose delivery streams",
+	Run: func(cmd *cobra.Command, _ []string) {
+		config, err := getConfig(cmd)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		output, err := cmd.Flags().GetString(flagOutput)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		err = firehose.NewFirehose(config, output).Build()
+		if err != nil {
+			printErrorAndExit(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(firehoseCmd)
+
+	firehoseCmd.Flags().StringArrayP(flagConfig, "c", nil,
+		"Path to the configuration file, repeat it to compose several files. For example: ./firehose.config.yaml")
+	firehoseCmd.Flags().StringP(flagOutput, "o", "", "Path to the output folder. For example: ./output")
+
+	_ = firehoseCmd.MarkFlagRequired(flagConfig)
+	_ = firehoseCmd.MarkFlagRequired(flagOutput)
+}
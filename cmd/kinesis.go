@@ -11,7 +11,7 @@ var kinesisCmd = &cobra.Command{
 	Use:   "kinesis",
 	Short: "Manage Kinesis streams",
 	Run: func(cmd *cobra.Command, _ []string) {
-		config, err := cmd.Flags().GetString(flagConfig)
+		config, err := getConfig(cmd)
 		if err != nil {
 			printErrorAndExit(err)
 		}
@@ -31,7 +31,8 @@ var kinesisCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(kinesisCmd)
 
-	kinesisCmd.Flags().StringP(flagConfig, "c", "", "Path to the configuration file. For example: ./kinesis.config.yaml")
+	kinesisCmd.Flags().StringArrayP(flagConfig, "c", nil,
+		"Path to the configuration file, repeat it to compose several files. For example: ./kinesis.config.yaml")
 	kinesisCmd.Flags().StringP(flagOutput, "o", "", "Path to the output folder. For example: ./output")
 
 	_ = kinesisCmd.MarkFlagRequired(flagConfig)
@@ -11,7 +11,7 @@ var lambdaCmd = &cobra.Command{
 	Use:   "lambda",
 	Short: "Manage Lambda",
 	Run: func(cmd *cobra.Command, _ []string) {
-		config, err := cmd.Flags().GetString(flagConfig)
+		config, err := getConfig(cmd)
 		if err != nil {
 			printErrorAndExit(err)
 		}
@@ -31,8 +31,8 @@ var lambdaCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(lambdaCmd)
 
-	lambdaCmd.Flags().StringP(flagConfig, "c", "",
-		"Path to the configuration file. For example: ./lambda.config.yaml")
+	lambdaCmd.Flags().StringArrayP(flagConfig, "c", nil,
+		"Path to the configuration file, repeat it to compose several files. For example: ./lambda.config.yaml")
 	lambdaCmd.Flags().StringP(flagOutput, "o", "",
 		"Path to the output folder. For example: ./output")
 
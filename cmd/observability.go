@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/observability"
+)
+
+// observabilityCmd represents the secrets command.
+var observabilityCmd = &cobra.Command{
+	Use:   "observability",
+	Short: "Manage CloudWatch alarms and dashboard",
+	Run: func(cmd *cobra.Command, _ []string) {
+		config, err := getConfig(cmd)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		output, err := cmd.Flags().GetString(flagOutput)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		err = observability.NewObservability(config, output).Build()
+		if err != nil {
+			printErrorAndExit(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(observabilityCmd)
+
+	observabilityCmd.Flags().StringArrayP(flagConfig, "c", nil,
+		"Path to the configuration file, repeat it to compose several files. For example: ./observability.config.yaml")
+	observabilityCmd.Flags().StringP(flagOutput, "o", "", "Path to the output folder. For example: ./output")
+
+	_ = observabilityCmd.MarkFlagRequired(flagConfig)
+	_ = observabilityCmd.MarkFlagRequired(flagOutput)
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/rds"
+)
+
+// rdsCmd represents the secrets command.
+var rdsCmd = &cobra.Command{
+	Use:   "rds",
+	Short: "Manage RDS databases and Aurora clusters",
+	Run: func(cmd *cobra.Command, _ []string) {
+		config, err := getConfig(cmd)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		output, err := cmd.Flags().GetString(flagOutput)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		err = rds.NewRDS(config, output).Build()
+		if err != nil {
+			printErrorAndExit(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rdsCmd)
+
+	rdsCmd.Flags().StringArrayP(flagConfig, "c", nil,
+		"Path to the configuration file, repeat it to compose several files. For example: ./rds.config.yaml")
+	rdsCmd.Flags().StringP(flagOutput, "o", "", "Path to the output folder. For example: ./output")
+
+	_ = rdsCmd.MarkFlagRequired(flagConfig)
+	_ = rdsCmd.MarkFlagRequired(flagOutput)
+}
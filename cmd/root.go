@@ -11,18 +11,22 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 	"github.com/joselitofilho/aws-terraform-generator/internal/guides"
 	surveyasker "github.com/joselitofilho/aws-terraform-generator/internal/survey"
 )
 
 const (
-	flagConfig  = "config"
-	flagDiagram = "diagram"
-	flagFile    = "file"
-	flagLeft    = "left"
-	flagOutput  = "output"
-	flagRight   = "right"
-	flagWorkdir = "workdir"
+	flagConfig    = "config"
+	flagDiagram   = "diagram"
+	flagFile      = "file"
+	flagForce     = "force"
+	flagLeft      = "left"
+	flagOutput    = "output"
+	flagRight     = "right"
+	flagTemplates = "templates"
+	flagVar       = "var"
+	flagWorkdir   = "workdir"
 )
 
 const (
@@ -111,7 +115,7 @@ var rootCmd = &cobra.Command{
 				}
 
 				_ = diagramCmd.Flags().Set(flagDiagram, answers.Diagram)
-				_ = diagramCmd.Flags().Set(flagConfig, answers.Config)
+				setConfig(diagramCmd, answers.Config)
 				_ = diagramCmd.Flags().Set(flagOutput, answers.Output)
 				diagramCmd.Run(diagramCmd, []string{})
 			case optionGuideInitialStructure:
@@ -120,7 +124,7 @@ var rootCmd = &cobra.Command{
 					printErrorAndExit(err)
 				}
 
-				_ = structureCmd.Flags().Set(flagConfig, answers.Config)
+				setConfig(structureCmd, answers.Config)
 				_ = structureCmd.Flags().Set(flagOutput, answers.Output)
 				structureCmd.Run(structureCmd, []string{})
 			case optionGuideCode:
@@ -132,39 +136,80 @@ var rootCmd = &cobra.Command{
 				stackOutput := fmt.Sprintf("%s/%s", answers.Output, answers.StackName)
 
 				fmtcolor.White.Println("→ Generating API Gateway code...")
-				_ = apigatewayCmd.Flags().Set(flagConfig, answers.Config)
+				setConfig(apigatewayCmd, answers.Config)
 				_ = apigatewayCmd.Flags().Set(flagOutput, answers.Output)
 				apigatewayCmd.Run(apigatewayCmd, []string{})
 				fmt.Println()
 
 				fmtcolor.White.Println("→ Generating Kinesis code...")
-				_ = kinesisCmd.Flags().Set(flagConfig, answers.Config)
+				setConfig(kinesisCmd, answers.Config)
 				_ = kinesisCmd.Flags().Set(flagOutput, stackOutput)
 				kinesisCmd.Run(kinesisCmd, []string{})
 				fmt.Println()
 
+				fmtcolor.White.Println("→ Generating Firehose code...")
+				setConfig(firehoseCmd, answers.Config)
+				_ = firehoseCmd.Flags().Set(flagOutput, stackOutput)
+				firehoseCmd.Run(firehoseCmd, []string{})
+				fmt.Println()
+
+				fmtcolor.White.Println("→ Generating EventBridge code...")
+				setConfig(eventBridgeCmd, answers.Config)
+				_ = eventBridgeCmd.Flags().Set(flagOutput, stackOutput)
+				eventBridgeCmd.Run(eventBridgeCmd, []string{})
+				fmt.Println()
+
+				fmtcolor.White.Println("→ Generating Step Functions code...")
+				setConfig(stepFunctionsCmd, answers.Config)
+				_ = stepFunctionsCmd.Flags().Set(flagOutput, stackOutput)
+				stepFunctionsCmd.Run(stepFunctionsCmd, []string{})
+				fmt.Println()
+
+				fmtcolor.White.Println("→ Generating Secrets code...")
+				setConfig(secretsCmd, answers.Config)
+				_ = secretsCmd.Flags().Set(flagOutput, stackOutput)
+				secretsCmd.Run(secretsCmd, []string{})
+				fmt.Println()
+
+				fmtcolor.White.Println("→ Generating RDS code...")
+				setConfig(rdsCmd, answers.Config)
+				_ = rdsCmd.Flags().Set(flagOutput, stackOutput)
+				rdsCmd.Run(rdsCmd, []string{})
+				fmt.Println()
+
 				fmtcolor.White.Println("→ Generating Lambda code...")
-				_ = lambdaCmd.Flags().Set(flagConfig, answers.Config)
+				setConfig(lambdaCmd, answers.Config)
 				_ = lambdaCmd.Flags().Set(flagOutput, stackOutput)
 				lambdaCmd.Run(lambdaCmd, []string{})
 				fmt.Println()
 
 				fmtcolor.White.Println("→ Generating S3 code...")
-				_ = s3Cmd.Flags().Set(flagConfig, answers.Config)
+				setConfig(s3Cmd, answers.Config)
 				_ = s3Cmd.Flags().Set(flagOutput, stackOutput)
 				s3Cmd.Run(s3Cmd, []string{})
 				fmt.Println()
 
 				fmtcolor.White.Println("→ Generating SNS code...")
-				_ = snsCmd.Flags().Set(flagConfig, answers.Config)
+				setConfig(snsCmd, answers.Config)
 				_ = snsCmd.Flags().Set(flagOutput, stackOutput)
 				snsCmd.Run(snsCmd, []string{})
 				fmt.Println()
 
 				fmtcolor.White.Println("→ Generating SQS code...")
-				_ = sqsCmd.Flags().Set(flagConfig, answers.Config)
+				setConfig(sqsCmd, answers.Config)
 				_ = sqsCmd.Flags().Set(flagOutput, stackOutput)
 				sqsCmd.Run(sqsCmd, []string{})
+				fmt.Println()
+
+				fmtcolor.White.Println("→ Generating Observability code...")
+				setConfig(observabilityCmd, answers.Config)
+				_ = observabilityCmd.Flags().Set(flagOutput, stackOutput)
+				observabilityCmd.Run(observabilityCmd, []string{})
+				fmt.Println()
+
+				fmtcolor.White.Println("→ Declaring variables and outputs...")
+				_ = declarationsCmd.Flags().Set(flagOutput, stackOutput)
+				declarationsCmd.Run(declarationsCmd, []string{})
 			default:
 				shouldContinue = false
 			}
@@ -188,10 +233,64 @@ func Execute() {
 }
 
 func init() {
+	rootCmd.PersistentFlags().StringArray(flagVar, nil,
+		"Value of a var of the configuration, overriding the environment and the config. For example: domain=example.com")
+	rootCmd.PersistentFlags().String(flagTemplates, "",
+		"Path to the folder or archive of the template pack, overriding the one of the config. For example: ./my-pack")
 	rootCmd.Flags().StringP(flagWorkdir, "", ".",
 		"Path to the directory where diagrams and configuration files are stored for the project. For example: ./example")
 }
 
+// getConfig returns the parser of the configuration files of the --config flags, which may be repeated to compose
+// several files.
+func getConfig(cmd *cobra.Command) (*config.YAML, error) {
+	fileNames, err := cmd.Flags().GetStringArray(flagConfig)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	opts, err := getConfigOptions(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return config.NewYAMLFiles(fileNames, opts...), nil
+}
+
+// getConfigOptions returns the options of the configuration parser given by the persistent flags, such as the
+// overrides of the vars of the --var flags and the template pack of the --templates flag.
+func getConfigOptions(cmd *cobra.Command) ([]config.YAMLOption, error) {
+	var (
+		values       []string
+		templatePack string
+	)
+
+	// The persistent flags are looked up through Flag, since the guide runs the commands without parsing their flags.
+	if flag := cmd.Flag(flagVar); flag != nil {
+		if value, ok := flag.Value.(interface{ GetSlice() []string }); ok {
+			values = value.GetSlice()
+		}
+	}
+
+	if flag := cmd.Flag(flagTemplates); flag != nil {
+		templatePack = flag.Value.String()
+	}
+
+	overrides, err := config.ParseVarOverrides(values)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return []config.YAMLOption{config.WithVarOverrides(overrides), config.WithTemplatePack(templatePack)}, nil
+}
+
+// setConfig replaces the configuration files of the --config flag, which Set would append to.
+func setConfig(cmd *cobra.Command, fileName string) {
+	if value, ok := cmd.Flags().Lookup(flagConfig).Value.(interface{ Replace([]string) error }); ok {
+		_ = value.Replace([]string{fileName})
+	}
+}
+
 func printErrorAndExit(err error) {
 	fmtcolor.Red.Printf("🚨 %s\n", err)
 	osExit(1)
@@ -11,7 +11,7 @@ var s3Cmd = &cobra.Command{
 	Use:   "s3",
 	Short: "Manage S3",
 	Run: func(cmd *cobra.Command, _ []string) {
-		config, err := cmd.Flags().GetString(flagConfig)
+		config, err := getConfig(cmd)
 		if err != nil {
 			printErrorAndExit(err)
 		}
@@ -31,7 +31,8 @@ var s3Cmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(s3Cmd)
 
-	s3Cmd.Flags().StringP(flagConfig, "c", "", "Path to the configuration file. For example: ./s3.config.yaml")
+	s3Cmd.Flags().StringArrayP(flagConfig, "c", nil,
+		"Path to the configuration file, repeat it to compose several files. For example: ./s3.config.yaml")
 	s3Cmd.Flags().StringP(flagOutput, "o", "", "Path to the output folder. For example: ./output")
 
 	_ = s3Cmd.MarkFlagRequired(flagConfig)
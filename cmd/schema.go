@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+)
+
+// schemaCmd represents the schema command.
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of the configuration file",
+	Run: func(cmd *cobra.Command, _ []string) {
+		output, err := cmd.Flags().GetString(flagOutput)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		fileNames, err := cmd.Flags().GetStringArray(flagConfig)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		schema := config.Schema()
+
+		if len(fileNames) > 0 {
+			yamlParser, err := getConfig(cmd)
+			if err != nil {
+				printErrorAndExit(err)
+			}
+
+			// Parsing the configuration registers the resource types of its plugins.
+			if _, err := yamlParser.Parse(); err != nil {
+				printErrorAndExit(err)
+			}
+
+			if schema, err = config.SchemaWithPlugins(); err != nil {
+				printErrorAndExit(err)
+			}
+		}
+
+		if output == "" {
+			_, _ = os.Stdout.Write(schema)
+			return
+		}
+
+		if err := os.WriteFile(output, schema, os.ModePerm); err != nil {
+			printErrorAndExit(err)
+		}
+
+		fmtcolor.White.Printf("Schema file '%s' has been generated successfully\n", output)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+
+	schemaCmd.Flags().StringP(flagOutput, "o", "",
+		"Path to the output file, the schema is printed when it is not set. For example: ./config.schema.json")
+	schemaCmd.Flags().StringArrayP(flagConfig, "c", nil,
+		"Path to the configuration file, whose plugins add the schemas of their custom resources. "+
+			"For example: ./config.yaml")
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/secrets"
+)
+
+// secretsCmd represents the secrets command.
+var secretsCmd = &cobra.Command{
+	Use:   "secrets",
+	Short: "Manage Secrets Manager secrets and SSM parameters",
+	Run: func(cmd *cobra.Command, _ []string) {
+		config, err := getConfig(cmd)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		output, err := cmd.Flags().GetString(flagOutput)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		err = secrets.NewSecrets(config, output).Build()
+		if err != nil {
+			printErrorAndExit(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(secretsCmd)
+
+	secretsCmd.Flags().StringArrayP(flagConfig, "c", nil,
+		"Path to the configuration file, repeat it to compose several files. For example: ./secrets.config.yaml")
+	secretsCmd.Flags().StringP(flagOutput, "o", "", "Path to the output folder. For example: ./output")
+
+	_ = secretsCmd.MarkFlagRequired(flagConfig)
+	_ = secretsCmd.MarkFlagRequired(flagOutput)
+}
@@ -11,7 +11,7 @@ var snsCmd = &cobra.Command{
 	Use:   "sns",
 	Short: "Manage SNS",
 	Run: func(cmd *cobra.Command, _ []string) {
-		config, err := cmd.Flags().GetString(flagConfig)
+		config, err := getConfig(cmd)
 		if err != nil {
 			printErrorAndExit(err)
 		}
@@ -31,7 +31,8 @@ var snsCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(snsCmd)
 
-	snsCmd.Flags().StringP(flagConfig, "c", "", "Path to the configuration file. For example: ./sns.config.yaml")
+	snsCmd.Flags().StringArrayP(flagConfig, "c", nil,
+		"Path to the configuration file, repeat it to compose several files. For example: ./sns.config.yaml")
 	snsCmd.Flags().StringP(flagOutput, "o", "", "Path to the output folder. For example: ./output")
 
 	_ = snsCmd.MarkFlagRequired(flagConfig)
@@ -11,7 +11,7 @@ var sqsCmd = &cobra.Command{
 	Use:   "sqs",
 	Short: "Manage SQS",
 	Run: func(cmd *cobra.Command, _ []string) {
-		config, err := cmd.Flags().GetString(flagConfig)
+		config, err := getConfig(cmd)
 		if err != nil {
 			printErrorAndExit(err)
 		}
@@ -31,7 +31,8 @@ var sqsCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(sqsCmd)
 
-	sqsCmd.Flags().StringP(flagConfig, "c", "", "Path to the configuration file. For example: ./sqs.config.yaml")
+	sqsCmd.Flags().StringArrayP(flagConfig, "c", nil,
+		"Path to the configuration file, repeat it to compose several files. For example: ./sqs.config.yaml")
 	sqsCmd.Flags().StringP(flagOutput, "o", "", "Path to the output folder. For example: ./output")
 
 	_ = sqsCmd.MarkFlagRequired(flagConfig)
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/stepfunctions"
+)
+
+// stepFunctionsCmd represents the stepfunctions command.
+var stepFunctionsCmd = &cobra.Command{
+	Use:   "stepfunctions",
+	Short: "Manage Step Functions state machines",
+	Run: func(cmd *cobra.Command, _ []string) {
+		config, err := getConfig(cmd)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		output, err := cmd.Flags().GetString(flagOutput)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		err = stepfunctions.NewStepFunctions(config, output).Build()
+		if err != nil {
+			printErrorAndExit(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(stepFunctionsCmd)
+
+	stepFunctionsCmd.Flags().StringArrayP(flagConfig, "c", nil,
+		"Path to the configuration file, repeat it to compose several files. For example: ./stepfunctions.config.yaml")
+	stepFunctionsCmd.Flags().StringP(flagOutput, "o", "", "Path to the output folder. For example: ./output")
+
+	_ = stepFunctionsCmd.MarkFlagRequired(flagConfig)
+	_ = stepFunctionsCmd.MarkFlagRequired(flagOutput)
+}
@@ -11,7 +11,7 @@ var structureCmd = &cobra.Command{
 	Use:   "structure",
 	Short: "Manage Structure",
 	Run: func(cmd *cobra.Command, _ []string) {
-		config, err := cmd.Flags().GetString(flagConfig)
+		config, err := getConfig(cmd)
 		if err != nil {
 			printErrorAndExit(err)
 		}
@@ -31,8 +31,8 @@ var structureCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(structureCmd)
 
-	structureCmd.Flags().StringP(flagConfig, "c", "",
-		"Path to the configuration file. For example: ./structure.config.yaml")
+	structureCmd.Flags().StringArrayP(flagConfig, "c", nil,
+		"Path to the configuration file, repeat it to compose several files. For example: ./structure.config.yaml")
 	structureCmd.Flags().StringP(flagOutput, "o", "",
 		"Path to the output folder. For example: ./output")
 
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/templates"
+)
+
+// templatesCmd represents the templates command.
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "Manage Templates",
+}
+
+// templatesExportCmd represents the templates export command.
+var templatesExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the built-in templates as a template pack",
+	Run: func(cmd *cobra.Command, _ []string) {
+		output, err := cmd.Flags().GetString(flagOutput)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		force, err := cmd.Flags().GetBool(flagForce)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		err = templates.NewTemplates(output, force).Export()
+		if err != nil {
+			printErrorAndExit(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(templatesCmd)
+	templatesCmd.AddCommand(templatesExportCmd)
+
+	templatesExportCmd.Flags().StringP(flagOutput, "o", "", "Path to the output folder. For example: ./my-pack")
+	templatesExportCmd.Flags().Bool(flagForce, false, "Overwrite the templates that already exist in the output folder")
+
+	_ = templatesExportCmd.MarkFlagRequired(flagOutput)
+}
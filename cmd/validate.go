@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
+)
+
+// validateCmd represents the validate command.
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the configuration without generating anything, such as the required tags of every resource",
+	Run: func(cmd *cobra.Command, _ []string) {
+		yamlParser, err := getConfig(cmd)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		yamlConfig, err := yamlParser.Parse()
+		if err != nil {
+			printErrorAndExit(err)
+		}
+
+		if err := generators.ValidateTags(yamlConfig); err != nil {
+			printErrorAndExit(err)
+		}
+
+		fmtcolor.White.Println("The configuration is valid")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+
+	validateCmd.Flags().StringArrayP(flagConfig, "c", nil,
+		"Path to the configuration file, repeat it to compose several files. For example: ./diagram.config.yaml")
+
+	_ = validateCmd.MarkFlagRequired(flagConfig)
+}
@@ -7,117 +7,384 @@ import (
 	"path"
 	"strings"
 
+	"github.com/ettle/strcase"
+
 	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 	generatorerrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
 	"github.com/joselitofilho/aws-terraform-generator/internal/utils"
 )
 
 type APIGateway struct {
-	configFileName string
-	output         string
+	yamlParser *config.YAML
+	output     string
 }
 
-func NewAPIGateway(configFileName, output string) *APIGateway {
-	return &APIGateway{configFileName: configFileName, output: output}
+func NewAPIGateway(yamlParser *config.YAML, output string) *APIGateway {
+	return &APIGateway{yamlParser: yamlParser, output: output}
 }
 
 func (a *APIGateway) Build() error {
-	yamlParser := config.NewYAML(a.configFileName)
-
-	yamlConfig, err := yamlParser.Parse()
+	yamlConfig, err := a.yamlParser.Parse()
 	if err != nil {
 		return fmt.Errorf("%w: %w", generatorerrs.ErrYAMLParser, err)
 	}
 
-	apigTfTemplate := utils.MergeStringMap(map[string]string{filenameTfAPIG: string(tmplAPIGtf)},
-		generators.FilterTemplatesMap(filenameTfAPIG,
-			generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.APIGateway)),
-	)[filenameTfAPIG]
+	overrideTemplates := generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.APIGateway)
 
-	lambdaTfTemplate := utils.MergeStringMap(map[string]string{filenameTfLambda: string(tmplLambdaTf)},
-		generators.FilterTemplatesMap(
-			filenameTfLambda, generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.APIGateway)),
-	)[filenameTfLambda]
+	apigTfTemplate := mergeTemplate(filenameTfAPIG, string(tmplAPIGtf), overrideTemplates)
+	webSocketTfTemplate := mergeTemplate(filenameTfWebSocket, string(tmplWebSocketTf), overrideTemplates)
+	lambdaTfTemplate := mergeTemplate(filenameTfLambda, string(tmplLambdaTf), overrideTemplates)
+	webSocketLambdaTfTemplate := mergeTemplate(
+		filenameTfWebSocketLambda, string(tmplWebSocketLambdaTf), overrideTemplates)
 
-	goTemplates := utils.MergeStringMap(defaultGoTemplateFiles, generators.FilterTemplatesMap(".go",
-		generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.APIGateway)))
+	goOverrideTemplates := generators.ExcludeTemplate(generators.FilterTemplatesMap(".go", overrideTemplates),
+		filenameGoWebSocketLambda)
+	goTemplates := utils.MergeStringMap(defaultGoTemplateFiles, goOverrideTemplates)
+	webSocketGoTemplates := utils.MergeStringMap(defaultWebSocketGoTemplateFiles, goOverrideTemplates)
+
+	// The Go file of the WebSocket lambdas is overridden by lambda.go, as the one of the HTTP lambdas, or by
+	// websocket_lambda.go, as its template in the tmpls folder.
+	if tmpl, ok := overrideTemplates[filenameGoWebSocketLambda]; ok {
+		webSocketGoTemplates[filenameGoLambda] = tmpl
+	}
 
 	apigHasAlreadyGeneratedByStack := map[string]struct{}{}
 
-	tg := generators.NewGenerator()
+	tg := generators.NewGenerator(yamlConfig)
+
+	environments, err := generators.NewEnvironmentVariables(yamlConfig)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	envVarsByStack := map[string]*generators.EnvironmentVariables{}
+	stackNames := []string{}
 
 	for i := range yamlConfig.APIGateways {
 		apiConf := yamlConfig.APIGateways[i]
 		stackName := apiConf.StackName
+		isWebSocket := apiConf.IsWebSocket()
 
 		outputMod := path.Join(a.output, stackName, "mod")
 		_ = os.MkdirAll(outputMod, os.ModePerm)
 
-		if _, ok := apigHasAlreadyGeneratedByStack[stackName]; !ok && apiConf.APIG {
-			apigHasAlreadyGeneratedByStack[stackName] = struct{}{}
+		envVars, ok := envVarsByStack[stackName]
+		if !ok {
+			envVars = &generators.EnvironmentVariables{Environments: environments.Environments}
+			envVarsByStack[stackName] = envVars
+			stackNames = append(stackNames, stackName)
+		}
+
+		filename, outputFilename, tfTemplate := filenameTfAPIG, filenameTfAPIG, apigTfTemplate
+		lambdaFilesTemplates := lambdaTemplates{tf: lambdaTfTemplate, goFiles: goTemplates}
+
+		if isWebSocket {
+			filename, outputFilename, tfTemplate = filenameTfWebSocket, webSocketFilename(&apiConf), webSocketTfTemplate
+			lambdaFilesTemplates = lambdaTemplates{tf: webSocketLambdaTfTemplate, goFiles: webSocketGoTemplates}
+		}
+
+		// The HTTP APIs of a stack share one file, while every WebSocket API has its own.
+		generatedKey := fmt.Sprintf("%s/%s", stackName, outputFilename)
 
-			outputFile := path.Join(outputMod, filenameTfAPIG)
+		if _, ok := apigHasAlreadyGeneratedByStack[generatedKey]; !ok && apiConf.APIG {
+			apigHasAlreadyGeneratedByStack[generatedKey] = struct{}{}
+
+			outputFile := path.Join(outputMod, outputFilename)
+
+			tags, err := generators.BuildTags(yamlConfig, stackName, apiConf.Tags)
+			if err != nil {
+				return fmt.Errorf("%w", err)
+			}
 
 			data := Data{
-				StackName: stackName,
-				APIDomain: apiConf.APIDomain,
+				StackName:                stackName,
+				APIName:                  webSocketAPIName(&apiConf),
+				APILabel:                 webSocketAPILabel(&apiConf),
+				APIDomain:                apiConf.APIDomain,
+				AccessLogFormat:          buildAccessLogFormat(apiConf.AccessLogFormat),
+				RouteSelectionExpression: buildRouteSelectionExpression(&apiConf),
+				ConnectionsTable:         apiConf.ConnectionsTable,
+				CORS:                     buildCORS(apiConf.CORS),
+				Stages:                   buildStages(&apiConf),
+				Routes:                   buildRoutes(yamlConfig.APIGateways, &apiConf),
+				Tags:                     tags,
 			}
 
-			generators.MustGenerateFile(tg, nil, filenameTfAPIG, apigTfTemplate, outputFile, data)
+			generators.MustGenerateFile(tg, nil, filename, tfTemplate, outputFile, data)
 
-			fmtcolor.White.Printf("Terraform '%s' has been generated successfully\n", filenameTfAPIG)
+			fmtcolor.White.Printf("Terraform '%s' has been generated successfully\n", outputFilename)
 		}
 
 		for j := range apiConf.Lambdas {
-			buildLambdaFiles(&apiConf.Lambdas[j], apiConf.StackName, lambdaTfTemplate, outputMod, a.output,
-				goTemplates)
+			lambdaConf := &apiConf.Lambdas[j]
+
+			secrets, err := generators.BuildLambdaSecrets(lambdaConf.Name, lambdaConf.Secrets, lambdaConf.RDS,
+				yamlConfig)
+			if err != nil {
+				return fmt.Errorf("%w", err)
+			}
+
+			tags, err := generators.BuildTags(yamlConfig, lambdaConf.Name, apiConf.Tags, lambdaConf.Tags)
+			if err != nil {
+				return fmt.Errorf("%w", err)
+			}
+
+			buildLambdaFiles(tg, yamlConfig, &apiConf, lambdaConf, secrets, tags, envVars, lambdaFilesTemplates,
+				outputMod, a.output)
+		}
+	}
+
+	for _, stackName := range stackNames {
+		if err := envVarsByStack[stackName].Write(tg, path.Join(a.output, stackName), "apigateway"); err != nil {
+			return fmt.Errorf("%w", err)
 		}
 	}
 
 	return nil
 }
 
-func buildLambdaFiles(lambdaConf *config.APIGatewayLambda, stackName, lambdaTfTemplate, outputMod, output string,
-	goTemplates map[string]string,
+type lambdaTemplates struct {
+	tf      string
+	goFiles map[string]string
+}
+
+func mergeTemplate(filename, defaultTemplate string, overrideTemplates map[string]string) string {
+	return utils.MergeStringMap(map[string]string{filename: defaultTemplate},
+		generators.FilterTemplatesMap(filename, overrideTemplates))[filename]
+}
+
+// webSocketAPIName returns the name of the WebSocket API, made of its stack and its name.
+func webSocketAPIName(apiConf *config.APIGateway) string {
+	if apiConf.Name == "" {
+		return fmt.Sprintf("%s-websocket", apiConf.StackName)
+	}
+
+	return fmt.Sprintf("%s-%s-websocket", apiConf.StackName, strcase.ToKebab(apiConf.Name))
+}
+
+// webSocketAPILabel returns the Terraform label of the WebSocket API. The unnamed API of a stack keeps the label it had
+// before the APIs could be named.
+func webSocketAPILabel(apiConf *config.APIGateway) string {
+	if apiConf.Name == "" {
+		return fmt.Sprintf("%s_websocket_api", apiConf.StackName)
+	}
+
+	return fmt.Sprintf("%s_%s_websocket_api", apiConf.StackName, strcase.ToSnake(apiConf.Name))
+}
+
+// webSocketFilename returns the name of the Terraform file of the WebSocket API.
+func webSocketFilename(apiConf *config.APIGateway) string {
+	if apiConf.Name == "" {
+		return filenameTfWebSocket
+	}
+
+	return fmt.Sprintf("websocket_%s.tf", strcase.ToSnake(apiConf.Name))
+}
+
+func buildAccessLogFormat(format string) string {
+	if format == "" {
+		return ""
+	}
+
+	return generators.HCLString(format)
+}
+
+func buildCORS(conf *config.CORS) *CORSData {
+	if conf == nil {
+		return nil
+	}
+
+	return &CORSData{
+		AllowCredentials: conf.AllowCredentials,
+		AllowHeaders:     generators.HCLStringList(conf.AllowHeaders),
+		AllowMethods:     generators.HCLStringList(conf.AllowMethods),
+		AllowOrigins:     generators.HCLStringList(conf.AllowOrigins),
+		ExposeHeaders:    generators.HCLStringList(conf.ExposeHeaders),
+		MaxAge:           conf.MaxAge,
+	}
+}
+
+func buildThrottling(conf *config.Throttling) *ThrottlingData {
+	if conf == nil {
+		return nil
+	}
+
+	return &ThrottlingData{BurstLimit: conf.BurstLimit, RateLimit: conf.RateLimit}
+}
+
+func buildRouteSelectionExpression(apiConf *config.APIGateway) string {
+	if apiConf.RouteSelectionExpression == "" {
+		return defaultRouteSelectionExpression
+	}
+
+	return apiConf.RouteSelectionExpression
+}
+
+// buildStages returns the stages of the API. When no stage is configured, the default stage of the protocol is used,
+// keeping the same Terraform label as before stages were configurable.
+func buildStages(apiConf *config.APIGateway) []StageData {
+	defaultName, baseLabel := defaultStageName, fmt.Sprintf("%s_api", apiConf.StackName)
+	if apiConf.IsWebSocket() {
+		defaultName, baseLabel = defaultWebSocketStageName, webSocketAPILabel(apiConf)
+	}
+
+	stagesConf := apiConf.Stages
+	if len(stagesConf) == 0 {
+		stagesConf = []config.APIGatewayStage{{Name: defaultName}}
+	}
+
+	stages := make([]StageData, 0, len(stagesConf))
+
+	for i := range stagesConf {
+		stage := stagesConf[i]
+
+		label := baseLabel
+		if stage.Name != defaultName {
+			label = fmt.Sprintf("%s_%s", label, strcase.ToSnake(stage.Name))
+		}
+
+		throttling := stage.Throttling
+		if throttling == nil {
+			throttling = apiConf.Throttling
+		}
+
+		stages = append(stages, StageData{
+			Label:      label,
+			Name:       stage.Name,
+			Variables:  stage.Variables,
+			Throttling: buildThrottling(throttling),
+		})
+	}
+
+	return stages
+}
+
+// buildRoutes returns the routes with throttling limits from all API Gateway configurations of the same API: the HTTP
+// APIs of the stack, or the WebSocket APIs of the stack with the same name.
+func buildRoutes(apiConfs []config.APIGateway, apiConf *config.APIGateway) []RouteData {
+	var routes []RouteData
+
+	isWebSocket := apiConf.IsWebSocket()
+
+	for i := range apiConfs {
+		if apiConfs[i].StackName != apiConf.StackName || apiConfs[i].IsWebSocket() != isWebSocket ||
+			(isWebSocket && apiConfs[i].Name != apiConf.Name) {
+			continue
+		}
+
+		for j := range apiConfs[i].Lambdas {
+			lambdaConf := &apiConfs[i].Lambdas[j]
+			if lambdaConf.Throttling == nil {
+				continue
+			}
+
+			routes = append(routes, RouteData{
+				RouteKey:   routeKey(lambdaConf, isWebSocket),
+				Throttling: buildThrottling(lambdaConf.Throttling),
+			})
+		}
+	}
+
+	return routes
+}
+
+func routeKey(lambdaConf *config.APIGatewayLambda, isWebSocket bool) string {
+	if isWebSocket {
+		return lambdaConf.RouteKey
+	}
+
+	return fmt.Sprintf("%s %s", lambdaConf.Verb, lambdaConf.Path)
+}
+
+func buildLambdaFiles(tg *generators.TemplateGenerator, yamlConfig *config.Config, apiConf *config.APIGateway,
+	lambdaConf *config.APIGatewayLambda, secrets generators.LambdaSecrets, tags string,
+	envVars *generators.EnvironmentVariables, templates lambdaTemplates, outputMod, output string,
 ) {
-	tg := generators.NewGenerator()
+	defaults := &yamlConfig.LambdaDefaults
 
 	filesConf := generators.CreateFilesMap(lambdaConf.Files)
 
 	asModule := strings.Contains(lambdaConf.Source, "git@")
 
-	roleName := lambdaConf.RoleName
-	if roleName == "" {
-		roleName = "iam_for_lambda"
+	roleName := utils.FirstNonEmpty(lambdaConf.RoleName, defaults.RoleName, defaultRoleName)
+	settings := lambdaConf.LambdaSettings.WithDefaults(defaults.LambdaSettings)
+
+	envars := secrets.MergeEnvars(lambdaConf.Envars)
+	if apiConf.IsWebSocket() && apiConf.ConnectionsTable != "" {
+		envars = utils.MergeStringMap(map[string]string{
+			envarConnectionsTable: fmt.Sprintf("aws_dynamodb_table.%s_connections.name",
+				strcase.ToSnake(apiConf.ConnectionsTable)),
+		}, envars)
 	}
 
 	lambdaData := LambdaData{
-		Name:        lambdaConf.Name,
-		AsModule:    asModule,
-		Source:      lambdaConf.Source,
-		RoleName:    roleName,
-		Runtime:     lambdaConf.Runtime,
-		StackName:   stackName,
-		Description: lambdaConf.Description,
-		Envars:      lambdaConf.Envars,
-		Verb:        lambdaConf.Verb,
-		Path:        lambdaConf.Path,
-		Files:       filesConf,
+		LambdaSettings: generators.BuildLambdaSettings(&settings),
+		LambdaSecrets:  secrets,
+		Name:           lambdaConf.Name,
+		AsModule:       asModule,
+		Source:         lambdaConf.Source,
+		RoleName:       roleName,
+		Runtime:        utils.FirstNonEmpty(lambdaConf.Runtime, defaults.Runtime),
+		StackName:      apiConf.StackName,
+		APILabel:       webSocketAPILabel(apiConf),
+		Description:    lambdaConf.Description,
+		Tags:           tags,
+		Envars:         envars,
+		Verb:           lambdaConf.Verb,
+		Path:           lambdaConf.Path,
+		RouteKey:       lambdaConf.RouteKey,
+		Files:          filesConf,
 	}
 
+	generators.SetEnvironmentVariables(envVars, &lambdaData.LambdaSettings,
+		yamlConfig.Naming.Label(awsresources.LambdaType, lambdaConf.Name),
+		func(envConfig *config.Config) (generators.LambdaSettings, bool) {
+			envLambdaConf := findLambda(envConfig.APIGateways, apiConf.StackName, lambdaConf.Name)
+			if envLambdaConf == nil {
+				return generators.LambdaSettings{}, false
+			}
+
+			settings := envLambdaConf.LambdaSettings.WithDefaults(envConfig.LambdaDefaults.LambdaSettings)
+
+			return generators.BuildLambdaSettings(&settings), true
+		}, generators.LambdaEnvironmentSettings)
+
 	fileName := fmt.Sprintf("%s.tf", lambdaConf.Name)
 	outputLambdaTfFile := path.Join(outputMod, fileName)
 
-	generators.MustGenerateFile(tg, nil, fileName, lambdaTfTemplate, outputLambdaTfFile, lambdaData)
+	generators.MustGenerateFile(tg, nil, fileName, templates.tf, outputLambdaTfFile, lambdaData)
 
 	fmtcolor.White.Printf("Terraform '%s.tf' has been generated successfully\n", fileName)
 
-	outputLambda := path.Join(output, stackName, "lambda", lambdaConf.Name)
+	outputLambda := path.Join(output, apiConf.StackName, "lambda", lambdaConf.Name)
 	_ = os.MkdirAll(outputLambda, os.ModePerm)
 
+	goTemplates := templates.goFiles
+	if len(secrets.Secrets) == 0 {
+		goTemplates = generators.ExcludeTemplate(goTemplates, filenameGoConfig)
+	}
+
 	generators.MustGenerateFiles(tg, goTemplates, filesConf, lambdaData, outputLambda)
 
 	fmtcolor.White.Printf("Lambda '%s' has been generated successfully\n", lambdaData.Name)
 }
+
+// findLambda returns the lambda of the API Gateways of the stack with the given name.
+func findLambda(apiConfs []config.APIGateway, stackName, name string) *config.APIGatewayLambda {
+	for i := range apiConfs {
+		if apiConfs[i].StackName != stackName {
+			continue
+		}
+
+		for j := range apiConfs[i].Lambdas {
+			if apiConfs[i].Lambdas[j].Name == name {
+				return &apiConfs[i].Lambdas[j]
+			}
+		}
+	}
+
+	return nil
+}
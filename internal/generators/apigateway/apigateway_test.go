@@ -4,8 +4,10 @@ import (
 	_ "embed"
 	"os"
 	"path"
+	"strings"
 	"testing"
 
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
 
 	"github.com/stretchr/testify/require"
@@ -92,6 +94,199 @@ func TestAPIGateway_Build(t *testing.T) {
 				require.Equal(t, string(mainGoData), "package main\n")
 			},
 		},
+		{
+			name: "cors, throttling and stages",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "apigateway.config.stages.yaml"),
+				output:         path.Join(testOutput, "stages"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				apigTf := path.Join(output, "teststack", "mod", "apig.tf")
+				require.FileExists(tb, apigTf)
+
+				apigTfData, err := os.ReadFile(apigTf)
+				require.NoError(tb, err)
+
+				content := string(apigTfData)
+				require.Contains(tb, content, `gateway_format = "{\"requestId\":\"$context.requestId\"}"`)
+				require.Contains(tb, content, "cors_configuration {")
+				require.Contains(tb, content, `allow_methods     = ["GET", "POST"]`)
+				require.Contains(tb, content, `resource "aws_apigatewayv2_stage" "teststack_api" {`)
+				require.Contains(tb, content, `resource "aws_apigatewayv2_stage" "teststack_api_v1" {`)
+				require.Contains(tb, content, `lambdaAlias = "live"`)
+				require.Contains(tb, content, "throttling_burst_limit = 200")
+				require.Contains(tb, content, `route_key              = "POST /v1/examples"`)
+				require.Contains(tb, content, `api_mapping_key = "v1"`)
+				require.Contains(tb, content, `prefix = "/$${proxy}"`)
+				require.Contains(tb, content, "route_key              = \"GET /v1/examples\"\n    throttling_burst_limit = 20\n  }")
+			},
+		},
+		{
+			name: "lambda runtime settings",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "apigateway.config.settings.yaml"),
+				output:         path.Join(testOutput, "settings"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				lambdaTf := path.Join(output, "teststack", "mod", "exampleAPIReceiver.tf")
+				require.FileExists(tb, lambdaTf)
+
+				lambdaTfData, err := os.ReadFile(lambdaTf)
+				require.NoError(tb, err)
+
+				content := string(lambdaTfData)
+				require.Contains(tb, content, `runtime = "provided.al2023"`)
+				require.Contains(tb, content, "memory_size = 256")
+				require.Contains(tb, content, "timeout     = 15")
+				require.Contains(tb, content, `architectures = ["arm64"]`)
+				require.Contains(tb, content, "reserved_concurrent_executions = 10")
+				require.Contains(tb, content, "subnet_ids         = [var.private_subnet_a]")
+			},
+		},
+		{
+			name: "websocket api",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "apigateway.config.websocket.yaml"),
+				output:         path.Join(testOutput, "websocket"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				modPath := path.Join(output, "teststack", "mod")
+				require.NoFileExists(tb, path.Join(modPath, "apig.tf"))
+
+				websocketTfData, err := os.ReadFile(path.Join(modPath, "websocket.tf"))
+				require.NoError(tb, err)
+
+				content := string(websocketTfData)
+				require.Contains(tb, content, `protocol_type              = "WEBSOCKET"`)
+				require.Contains(tb, content, `route_selection_expression = "$request.body.type"`)
+				require.Contains(tb, content, `resource "aws_apigatewayv2_stage" "teststack_websocket_api" {`)
+				require.Contains(tb, content, `route_key              = "sendMessage"`)
+				require.Contains(tb, content, `resource "aws_dynamodb_table" "teststack_connections_connections" {`)
+
+				lambdaTfData, err := os.ReadFile(path.Join(modPath, "connectHandler.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(lambdaTfData), `route_key = "$connect"`)
+				require.Contains(tb, string(lambdaTfData),
+					"CONNECTIONS_TABLE = aws_dynamodb_table.teststack_connections_connections.name")
+
+				lambdaGoData, err := os.ReadFile(path.Join(output, "teststack", "lambda", "messageHandler", "lambda.go"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(lambdaGoData), "events.APIGatewayWebsocketProxyRequest")
+				require.Contains(tb, string(lambdaGoData), "conf, err := loadConfig(context.Background())")
+				require.FileExists(tb, path.Join(output, "teststack", "lambda", "messageHandler", "config.go"))
+				require.NoFileExists(tb, path.Join(output, "teststack", "lambda", "connectHandler", "config.go"))
+
+				lambdaTfData, err = os.ReadFile(path.Join(modPath, "messageHandler.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(lambdaTfData),
+					"PUSH_API_KEY_PARAMETER_NAME = aws_ssm_parameter.push_api_key_parameter.name")
+				require.Contains(tb, string(lambdaTfData), `Action   = ["ssm:GetParameter"]`)
+				require.Contains(tb, string(lambdaTfData), "role = aws_iam_role.execute_lambda.id")
+			},
+		},
+		{
+			name: "websocket apis of the same stack",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "apigateway.config.websocket.multiple.yaml"),
+				output:         path.Join(testOutput, "websocketmultiple"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				modPath := path.Join(output, "teststack", "mod")
+				require.NoFileExists(tb, path.Join(modPath, "websocket.tf"))
+
+				chatTfData, err := os.ReadFile(path.Join(modPath, "websocket_chat.tf"))
+				require.NoError(tb, err)
+
+				content := string(chatTfData)
+				require.Contains(tb, content, `resource "aws_apigatewayv2_api" "teststack_chat_websocket_api" {`)
+				require.Contains(tb, content, `teststack_chat_websocket_api_name       = "teststack-chat-websocket"`)
+				require.NotContains(tb, content, "route_settings")
+
+				gameTfData, err := os.ReadFile(path.Join(modPath, "websocket_game.tf"))
+				require.NoError(tb, err)
+
+				content = string(gameTfData)
+				require.Contains(tb, content, `resource "aws_apigatewayv2_api" "teststack_game_websocket_api" {`)
+				require.Contains(tb, content, `resource "aws_apigatewayv2_stage" "teststack_game_websocket_api" {`)
+				require.Contains(tb, content, `route_key              = "$connect"`)
+
+				lambdaTfData, err := os.ReadFile(path.Join(modPath, "gameConnectHandler.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(lambdaTfData), "api_id    = aws_apigatewayv2_api.teststack_game_websocket_api.id")
+			},
+		},
+		{
+			name: "override the go file of the websocket lambdas",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "apigateway.config.websocket.override.yaml"),
+				output:         path.Join(testOutput, "websocketoverride"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				webSocketLambdaPath := path.Join(output, "wsstack", "lambda", "messageHandler")
+
+				lambdaGoData, err := os.ReadFile(path.Join(webSocketLambdaPath, "lambda.go"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(lambdaGoData), "// messageHandler handles the WebSocket messages.")
+				require.NoFileExists(tb, path.Join(webSocketLambdaPath, "websocket_lambda.go"))
+
+				restLambdaPath := path.Join(output, "httpstack", "lambda", "restHandler")
+
+				lambdaGoData, err = os.ReadFile(path.Join(restLambdaPath, "lambda.go"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(lambdaGoData), "type restHandlerLambda struct")
+				require.NoFileExists(tb, path.Join(restLambdaPath, "websocket_lambda.go"))
+			},
+		},
+		{
+			name: "tags merged with the tags of the configuration",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "apigateway.config.tags.yaml"),
+				output:         path.Join(testOutput, "tags"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				apigTfData, err := os.ReadFile(path.Join(output, "teststack", "mod", "apig.tf"))
+				require.NoError(tb, err)
+
+				apigTags := "  tags = {\n    Service = \"orders\"\n    Team    = \"payments\"\n  }"
+				require.Equal(tb, 7, strings.Count(string(apigTfData), apigTags))
+
+				lambdaTfData, err := os.ReadFile(path.Join(output, "teststack", "mod", "exampleAPIReceiver.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(lambdaTfData), `Service = "orders-api"`)
+			},
+		},
+		{
+			name: "when a lambda reads an unknown secret should return an error",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "secrets.config.unknown.apigateway.yaml"),
+				output:         path.Join(testOutput, "unknownsecret"),
+			},
+			targetErr: generatorserrs.ErrUnknownSecret,
+		},
 		{
 			name: "when yaml parser fails should return an error",
 			fields: fields{
@@ -110,7 +305,7 @@ func TestAPIGateway_Build(t *testing.T) {
 		tc := tests[i]
 
 		t.Run(tc.name, func(t *testing.T) {
-			err := NewAPIGateway(tc.fields.configFileName, tc.fields.output).Build()
+			err := NewAPIGateway(config.NewYAML(tc.fields.configFileName), tc.fields.output).Build()
 
 			require.ErrorIs(t, err, tc.targetErr)
 
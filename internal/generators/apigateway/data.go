@@ -2,21 +2,62 @@ package apigateway
 
 import "github.com/joselitofilho/aws-terraform-generator/internal/generators"
 
+type CORSData struct {
+	AllowCredentials bool
+	AllowHeaders     string
+	AllowMethods     string
+	AllowOrigins     string
+	ExposeHeaders    string
+	MaxAge           int
+}
+
+type ThrottlingData struct {
+	BurstLimit int
+	RateLimit  float64
+}
+
+type StageData struct {
+	Label      string
+	Name       string
+	Variables  map[string]string
+	Throttling *ThrottlingData
+}
+
+type RouteData struct {
+	RouteKey   string
+	Throttling *ThrottlingData
+}
+
 type Data struct {
-	StackName string
-	APIDomain string
+	StackName                string
+	APIName                  string
+	APILabel                 string
+	APIDomain                string
+	AccessLogFormat          string
+	RouteSelectionExpression string
+	ConnectionsTable         string
+	CORS                     *CORSData
+	Stages                   []StageData
+	Routes                   []RouteData
+	Tags                     string
 }
 
 type LambdaData struct {
+	generators.LambdaSettings
+	generators.LambdaSecrets
+
 	Name        string
 	AsModule    bool
 	Source      string
 	RoleName    string
 	Runtime     string
 	StackName   string
+	APILabel    string
 	Description string
+	Tags        string
 	Envars      map[string]string
 	Verb        string
 	Path        string
+	RouteKey    string
 	Files       map[string]generators.File
 }
@@ -2,19 +2,37 @@ package apigateway
 
 import (
 	_ "embed"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/utils"
 )
 
 const (
-	filenameTfAPIG   = "apig.tf"
-	filenameTfLambda = "lambda.tf"
-	filenameGoLambda = "lambda.go"
-	filenameGoMain   = "main.go"
+	filenameTfAPIG            = "apig.tf"
+	filenameTfLambda          = "lambda.tf"
+	filenameTfWebSocket       = "websocket.tf"
+	filenameTfWebSocketLambda = "websocket_lambda.tf"
+	filenameGoLambda          = "lambda.go"
+	filenameGoWebSocketLambda = "websocket_lambda.go"
+	filenameGoMain            = "main.go"
+	filenameGoConfig          = "config.go"
+)
+
+const (
+	defaultStageName                = "$default"
+	defaultWebSocketStageName       = "default"
+	defaultRouteSelectionExpression = "$request.body.action"
+	defaultRoleName                 = "iam_for_lambda"
+
+	envarConnectionsTable = "CONNECTIONS_TABLE"
 )
 
 var (
 	//go:embed tmpls/apig.tf.tmpl
 	tmplAPIGtf []byte
 
+	//go:embed tmpls/config.go.tmpl
+	tmplConfigGo []byte
+
 	//go:embed tmpls/lambda.go.tmpl
 	tmplLambdaGo []byte
 
@@ -23,9 +41,39 @@ var (
 
 	//go:embed tmpls/main.go.tmpl
 	tmplMainGo []byte
+
+	//go:embed tmpls/websocket.tf.tmpl
+	tmplWebSocketTf []byte
+
+	//go:embed tmpls/websocket_lambda.go.tmpl
+	tmplWebSocketLambdaGo []byte
+
+	//go:embed tmpls/websocket_lambda.tf.tmpl
+	tmplWebSocketLambdaTf []byte
+)
+
+var (
+	defaultGoTemplateFiles = map[string]string{
+		filenameGoLambda: string(tmplLambdaGo),
+		filenameGoMain:   string(tmplMainGo),
+		filenameGoConfig: string(tmplConfigGo),
+	}
+
+	defaultWebSocketGoTemplateFiles = map[string]string{
+		filenameGoLambda: string(tmplWebSocketLambdaGo),
+		filenameGoMain:   string(tmplMainGo),
+		filenameGoConfig: string(tmplConfigGo),
+	}
 )
 
-var defaultGoTemplateFiles = map[string]string{
-	filenameGoLambda: string(tmplLambdaGo),
-	filenameGoMain:   string(tmplMainGo),
+// DefaultTemplates returns the built-in templates of the generator by file name. The Go file of the WebSocket lambdas
+// is websocket_lambda.go, as in the tmpls folder, and is generated as lambda.go.
+func DefaultTemplates() map[string]string {
+	return utils.MergeStringMap(defaultGoTemplateFiles, map[string]string{
+		filenameGoWebSocketLambda: string(tmplWebSocketLambdaGo),
+		filenameTfAPIG:            string(tmplAPIGtf),
+		filenameTfLambda:          string(tmplLambdaTf),
+		filenameTfWebSocket:       string(tmplWebSocketTf),
+		filenameTfWebSocketLambda: string(tmplWebSocketLambdaTf),
+	})
 }
@@ -4,3 +4,69 @@ type File struct {
 	Tmpl    string
 	Imports []string
 }
+
+// LambdaSettings represents the runtime settings of a Lambda function as they are rendered by the templates.
+type LambdaSettings struct {
+	MemorySize             int
+	MemorySizeVar          string
+	Timeout                int
+	TimeoutVar             string
+	Architectures          string
+	EphemeralStorage       int
+	Layers                 string
+	ReservedConcurrency    *int
+	ProvisionedConcurrency int
+	VPC                    *LambdaVPC
+}
+
+type LambdaVPC struct {
+	SubnetIDs        string
+	SecurityGroupIDs string
+}
+
+// LambdaSecret represents a configured secret read by a Lambda function at cold start.
+type LambdaSecret struct {
+	Name string
+	// FieldName is the field of the generated Go config that holds the value.
+	FieldName string
+	// Envar is the environment variable that tells the function where the secret is.
+	Envar string
+	// Value is the Terraform expression of the environment variable.
+	Value string
+	SSM   bool
+}
+
+// LambdaSecrets represents the secrets of a Lambda function and what its role needs to read them. The master
+// credentials of the databases the function connects to are read like any other secret.
+type LambdaSecrets struct {
+	Secrets       []LambdaSecret
+	SecretARNs    string
+	ParameterARNs string
+	KMSKeyIDs     string
+
+	// databaseEnvars holds the endpoints of the databases the function connects to.
+	databaseEnvars map[string]string
+}
+
+// MergeEnvars returns the given environment variables together with the ones of the secrets and databases. The given
+// map is not changed.
+func (s LambdaSecrets) MergeEnvars(envars map[string]string) map[string]string {
+	if len(s.Secrets) == 0 && len(s.databaseEnvars) == 0 {
+		return envars
+	}
+
+	merged := make(map[string]string, len(envars)+len(s.Secrets)+len(s.databaseEnvars))
+	for key, value := range envars {
+		merged[key] = value
+	}
+
+	for key, value := range s.databaseEnvars {
+		merged[key] = value
+	}
+
+	for i := range s.Secrets {
+		merged[s.Secrets[i].Envar] = s.Secrets[i].Value
+	}
+
+	return merged
+}
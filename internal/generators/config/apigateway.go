@@ -1,22 +1,78 @@
 package config
 
+import "strings"
+
+// API Gateway protocols.
+const (
+	ProtocolHTTP      = "http"
+	ProtocolWebSocket = "websocket"
+)
+
 type APIGatewayLambda struct {
+	LambdaSettings `yaml:",inline"`
+
 	Name        string            `yaml:"name"`
 	Source      string            `yaml:"source"`
 	RoleName    string            `yaml:"role_name,omitempty"`
 	Runtime     string            `yaml:"runtime,omitempty"`
 	Description string            `yaml:"description"`
 	Envars      map[string]string `yaml:"envars,omitempty"`
-	Verb        string            `yaml:"verb"`
-	Path        string            `yaml:"path"`
-	Files       []File            `yaml:"files,omitempty"`
+	// Secrets lists the names of the configured secrets the lambda reads.
+	Secrets []string `yaml:"secrets,omitempty"`
+	// RDS lists the names of the configured databases the lambda connects to.
+	RDS []string `yaml:"rds,omitempty"`
+	// Alarms overrides the thresholds of the observability section.
+	Alarms     *LambdaAlarms `yaml:"alarms,omitempty"`
+	Verb       string        `yaml:"verb,omitempty"`
+	Path       string        `yaml:"path,omitempty"`
+	RouteKey   string        `yaml:"route_key,omitempty"`
+	Throttling *Throttling   `yaml:"throttling,omitempty"`
+	Files      []File        `yaml:"files,omitempty"`
+	// Tags are merged over the tags of the configuration.
+	Tags map[string]string `yaml:"tags,omitempty"`
 }
 
 func (r *APIGatewayLambda) GetName() string { return r.Name }
 
+// CORS represents the cross-origin resource sharing configuration of an HTTP API.
+type CORS struct {
+	AllowCredentials bool     `yaml:"allow_credentials,omitempty"`
+	AllowHeaders     []string `yaml:"allow_headers,omitempty"`
+	AllowMethods     []string `yaml:"allow_methods,omitempty"`
+	AllowOrigins     []string `yaml:"allow_origins,omitempty"`
+	ExposeHeaders    []string `yaml:"expose_headers,omitempty"`
+	MaxAge           int      `yaml:"max_age,omitempty"`
+}
+
+// Throttling represents the throttling limits of an API stage or route.
+type Throttling struct {
+	BurstLimit int     `yaml:"burst_limit,omitempty"`
+	RateLimit  float64 `yaml:"rate_limit,omitempty"`
+}
+
+// APIGatewayStage represents a named deployment stage of an API.
+type APIGatewayStage struct {
+	Name       string            `yaml:"name"`
+	Variables  map[string]string `yaml:"variables,omitempty"`
+	Throttling *Throttling       `yaml:"throttling,omitempty"`
+}
+
 type APIGateway struct {
-	StackName string             `yaml:"stack_name"`
-	APIDomain string             `yaml:"api_domain"`
-	APIG      bool               `yaml:"apig"`
-	Lambdas   []APIGatewayLambda `yaml:"lambdas"`
+	StackName                string             `yaml:"stack_name"`
+	Name                     string             `yaml:"name,omitempty"`
+	APIDomain                string             `yaml:"api_domain"`
+	APIG                     bool               `yaml:"apig"`
+	Protocol                 string             `yaml:"protocol,omitempty"`
+	RouteSelectionExpression string             `yaml:"route_selection_expression,omitempty"`
+	ConnectionsTable         string             `yaml:"connections_table,omitempty"`
+	CORS                     *CORS              `yaml:"cors,omitempty"`
+	Throttling               *Throttling        `yaml:"throttling,omitempty"`
+	Stages                   []APIGatewayStage  `yaml:"stages,omitempty"`
+	AccessLogFormat          string             `yaml:"access_log_format,omitempty"`
+	Lambdas                  []APIGatewayLambda `yaml:"lambdas"`
+	// Tags are merged over the tags of the configuration.
+	Tags map[string]string `yaml:"tags,omitempty"`
 }
+
+// IsWebSocket reports whether the API Gateway uses the WebSocket protocol.
+func (r *APIGateway) IsWebSocket() bool { return strings.EqualFold(r.Protocol, ProtocolWebSocket) }
@@ -0,0 +1,70 @@
+package config
+
+import "reflect"
+
+// Clone returns a deep copy of the configuration, which shares none of its maps, slices and pointers.
+func (c *Config) Clone() *Config {
+	clone, _ := deepCopy(reflect.ValueOf(c)).Interface().(*Config)
+
+	return clone
+}
+
+// deepCopy returns a copy of the value with copies of the values its pointers, interfaces, slices and maps refer to.
+// The unexported fields of the structs are copied as they are.
+func deepCopy(value reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return value
+		}
+
+		copied := reflect.New(value.Type().Elem())
+		copied.Elem().Set(deepCopy(value.Elem()))
+
+		return copied
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(deepCopy(value.Elem()))
+
+		return copied
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+
+		copied := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(deepCopy(value.Index(i)))
+		}
+
+		return copied
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+
+		copied := reflect.MakeMapWithSize(value.Type(), value.Len())
+		for iter := value.MapRange(); iter.Next(); {
+			copied.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+
+		return copied
+	case reflect.Struct:
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(value)
+
+		for i := 0; i < value.NumField(); i++ {
+			if copied.Field(i).CanSet() {
+				copied.Field(i).Set(deepCopy(value.Field(i)))
+			}
+		}
+
+		return copied
+	default:
+		return value
+	}
+}
@@ -1,5 +1,12 @@
 package config
 
+import (
+	"errors"
+	"fmt"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/resources"
+)
+
 type Resource interface {
 	GetName() string
 }
@@ -10,11 +17,98 @@ type Config struct {
 	OverrideDefaultTemplates OverrideDefaultTemplates `yaml:"override_default_templates,omitempty"`
 	Diagram                  Diagram                  `yaml:"diagram,omitempty"`
 	Structure                Structure                `yaml:"structure,omitempty"`
+	LambdaDefaults           LambdaDefaults           `yaml:"lambda_defaults,omitempty"`
 	APIGateways              []APIGateway             `yaml:"apigateways,omitempty"`
 	Kinesis                  []Kinesis                `yaml:"kinesis,omitempty"`
+	Firehoses                []Firehose               `yaml:"firehose,omitempty"`
+	EventBuses               []EventBus               `yaml:"eventbridge,omitempty"`
+	StepFunctions            []StepFunction           `yaml:"stepfunctions,omitempty"`
+	Secrets                  []Secret                 `yaml:"secrets,omitempty"`
+	RDS                      []RDS                    `yaml:"rds,omitempty"`
+	Observability            *Observability           `yaml:"observability,omitempty"`
 	Lambdas                  []Lambda                 `yaml:"lambdas,omitempty"`
 	Buckets                  []S3                     `yaml:"buckets,omitempty"`
 	SNSs                     []SNS                    `yaml:"sns,omitempty"`
 	SQSs                     []SQS                    `yaml:"sqs,omitempty"`
 	RestfulAPIs              []RestfulAPI             `yaml:"restfulapis,omitempty"`
+	// Tags are the default tags of the team, merged into every taggable resource.
+	Tags map[string]string `yaml:"tags,omitempty"`
+	// RequiredTags are the tag keys every taggable resource must have. The generators fail when one is missing.
+	RequiredTags []string `yaml:"required_tags,omitempty"`
+	// Naming overrides the naming conventions of the resource types.
+	Naming Naming `yaml:"naming,omitempty"`
+	// Environments are the overlays of the environments, either inline or as the path of an overlay file.
+	Environments map[string]any `yaml:"environments,omitempty"`
+	// TemplatePack is the folder or archive of the templates that override the built-in ones, relative to the
+	// configuration file. The inline templates take precedence over it.
+	TemplatePack string `yaml:"template_pack,omitempty"`
+	// Partials are the named templates every template can use with {{template "<name>" .}}.
+	Partials map[string]string `yaml:"partials,omitempty"`
+	// Vars are the values referred to as ${vars.<name>} in the strings of the configuration.
+	Vars map[string]string `yaml:"vars,omitempty"`
+	// Plugins are the files of the plugins declaring resource types beyond the built-in ones, relative to the
+	// configuration file.
+	Plugins []string `yaml:"plugins,omitempty"`
+	// CustomResources are the resources of the types of the plugins, by type.
+	CustomResources map[string][]CustomResource `yaml:"custom_resources,omitempty"`
+}
+
+var ErrResourceNotFound = errors.New("resource not found")
+
+// LookupResource returns the configuration of the resource of the type with the name. The lambdas include the ones of
+// the API Gateways.
+func (c *Config) LookupResource(resourceType resources.ResourceType, name string) (Resource, error) {
+	var candidates []Resource
+
+	switch resourceType {
+	case resources.DatabaseType:
+		candidates = resourcesOf(c.RDS)
+	case resources.EventBusType:
+		candidates = resourcesOf(c.EventBuses)
+	case resources.FirehoseType:
+		candidates = resourcesOf(c.Firehoses)
+	case resources.KinesisType:
+		candidates = resourcesOf(c.Kinesis)
+	case resources.LambdaType:
+		candidates = resourcesOf(c.Lambdas)
+		for i := range c.APIGateways {
+			candidates = append(candidates, resourcesOf(c.APIGateways[i].Lambdas)...)
+		}
+	case resources.RestfulAPIType:
+		candidates = resourcesOf(c.RestfulAPIs)
+	case resources.S3Type:
+		candidates = resourcesOf(c.Buckets)
+	case resources.SNSType:
+		candidates = resourcesOf(c.SNSs)
+	case resources.SQSType:
+		candidates = resourcesOf(c.SQSs)
+	case resources.StepFunctionType:
+		candidates = resourcesOf(c.StepFunctions)
+	default:
+		candidates = resourcesOf(c.CustomResources[string(resourceType)])
+	}
+
+	for _, candidate := range candidates {
+		if candidate.GetName() == name {
+			return candidate, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s '%s'", ErrResourceNotFound, resourceType, name)
+}
+
+// resourcePointer is the pointer to a resource configuration, which implements Resource.
+type resourcePointer[T any] interface {
+	*T
+	Resource
+}
+
+// resourcesOf returns the pointers to the items.
+func resourcesOf[T any, P resourcePointer[T]](items []T) []Resource {
+	result := make([]Resource, 0, len(items))
+	for i := range items {
+		result = append(result, P(&items[i]))
+	}
+
+	return result
 }
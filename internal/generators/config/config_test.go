@@ -0,0 +1,87 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/resources"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_LookupResource(t *testing.T) {
+	config := &Config{
+		SQSs:    []SQS{{Name: "orders"}, {Name: "payments"}},
+		Lambdas: []Lambda{{Name: "worker"}},
+		APIGateways: []APIGateway{{
+			StackName: "teststack", Lambdas: []APIGatewayLambda{{Name: "receiver"}},
+		}},
+		RDS: []RDS{{Name: "ledger"}},
+	}
+
+	tests := []struct {
+		name         string
+		resourceType resources.ResourceType
+		resourceName string
+		want         Resource
+		targetErr    error
+	}{
+		{name: "sqs", resourceType: resources.SQSType, resourceName: "payments", want: &config.SQSs[1]},
+		{name: "lambda", resourceType: resources.LambdaType, resourceName: "worker", want: &config.Lambdas[0]},
+		{
+			name:         "lambda of an API Gateway",
+			resourceType: resources.LambdaType,
+			resourceName: "receiver",
+			want:         &config.APIGateways[0].Lambdas[0],
+		},
+		{name: "database", resourceType: resources.DatabaseType, resourceName: "ledger", want: &config.RDS[0]},
+		{
+			name:         "unknown resource",
+			resourceType: resources.SQSType,
+			resourceName: "refunds",
+			targetErr:    ErrResourceNotFound,
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := config.LookupResource(tc.resourceType, tc.resourceName)
+
+			require.ErrorIs(t, err, tc.targetErr)
+
+			if tc.targetErr != nil {
+				require.Nil(t, got)
+				return
+			}
+
+			require.Same(t, tc.want, got)
+		})
+	}
+}
+
+func TestConfig_Clone(t *testing.T) {
+	newConfig := func() *Config {
+		return &Config{
+			SQSs:          []SQS{{Name: "orders", Encryption: &SQSEncryption{Type: "kms"}}},
+			Lambdas:       []Lambda{{Name: "worker", Envars: map[string]string{"TABLE": "orders"}}},
+			Observability: &Observability{AlarmActions: []string{"var.alerting_sns_topic_arn"}},
+			Tags:          map[string]string{"Team": "payments"},
+			Environments:  map[string]any{"prd": map[string]any{"sqs": []any{map[string]any{"name": "orders"}}}},
+		}
+	}
+
+	original := newConfig()
+	clone := original.Clone()
+
+	require.Equal(t, original, clone)
+
+	clone.SQSs[0].Name = "payments"
+	clone.SQSs[0].Encryption.Type = "sqs"
+	clone.Lambdas[0].Envars["TABLE"] = "payments"
+	clone.Observability.AlarmActions[0] = "var.other_topic_arn"
+	clone.Tags["Team"] = "orders"
+	clone.Environments["prd"].(map[string]any)["sqs"].([]any)[0].(map[string]any)["name"] = "payments"
+
+	require.Equal(t, newConfig(), original)
+}
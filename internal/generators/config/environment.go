@@ -0,0 +1,251 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ErrInvalidEnvironment = errors.New("invalid environment")
+
+// identityKeys are the fields that identify the items of a list, so the overlays change the items rather than
+// replace the whole list.
+var identityKeys = []string{"name", "stack_name"}
+
+// lambdaOverlayFields are the settings of the Lambda functions that may differ between the environments.
+var lambdaOverlayFields = map[string]any{"name": nil, "memory_size": nil, "timeout": nil}
+
+// overlayFields are the fields an overlay may change. The generators turn these settings into variables of the
+// module, so the changes of any other field would not reach the generated code. A nil value is a setting and a map
+// holds the fields of a section or of the items of a list.
+var overlayFields = map[string]any{
+	"lambda_defaults": map[string]any{"memory_size": nil, "timeout": nil},
+	"lambdas":         lambdaOverlayFields,
+	"apigateways":     map[string]any{"stack_name": nil, "lambdas": lambdaOverlayFields},
+	"kinesis":         map[string]any{"name": nil, "shard_count": nil, "retention_period": nil},
+	"sqs": map[string]any{
+		"name": nil, "max_receive_count": nil, "message_retention_seconds": nil, "delay_seconds": nil,
+		"visibility_timeout_seconds": nil, "receive_wait_time_seconds": nil,
+	},
+}
+
+// EnvironmentNames returns the names of the environments in alphabetical order.
+func (c *Config) EnvironmentNames() []string {
+	names := make([]string, 0, len(c.Environments))
+	for name := range c.Environments {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// Environment returns the configuration of the environment, which is the base configuration deep-merged with the
+// overlay of the environment. Maps are merged key by key, list items are matched by name and any other value is
+// replaced. Overlays that change fields other than the settings of the environments, or that add list items, are
+// rejected.
+func (c *Config) Environment(name string) (*Config, error) {
+	overlay, ok := c.Environments[name].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: '%s' has no overlay", ErrInvalidEnvironment, name)
+	}
+
+	base := *c
+	base.Environments = nil
+
+	data, err := yaml.Marshal(&base)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	var merged map[string]any
+	if err := yaml.Unmarshal(data, &merged); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	if err := validateOverlay("", merged, overlay, overlayFields); err != nil {
+		return nil, fmt.Errorf("%w: '%s': %w", ErrInvalidEnvironment, name, err)
+	}
+
+	data, err = yaml.Marshal(mergeOverlay(merged, overlay))
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("%w: '%s': %w", ErrInvalidEnvironment, name, err)
+	}
+
+	return &config, nil
+}
+
+// loadEnvironmentFiles replaces the environments given as a file path with the overlay read from the file. The paths
+// were made relative to the folder of the configuration file when the files were composed. The references to the vars
+// in the overlay files are replaced too.
+func (c *Config) loadEnvironmentFiles(resolver *varResolver) error {
+	for name, value := range c.Environments {
+		switch overlay := value.(type) {
+		case map[string]any:
+			// Inline overlay.
+		case nil:
+			c.Environments[name] = map[string]any{}
+		case string:
+			data, err := osReadFile(overlay)
+			if err != nil {
+				return fmt.Errorf("%w: '%s': %w", ErrInvalidEnvironment, name, err)
+			}
+
+			var node yaml.Node
+			if err := yamlUnmarshal(data, &node); err != nil {
+				return fmt.Errorf("%w: '%s': %w", ErrInvalidEnvironment, name, err)
+			}
+
+			doc := &document{sources: []source{{line: 1, fileName: overlay}}}
+			if err := doc.interpolateNode(resolver, &node, map[*yaml.Node]bool{}); err != nil {
+				return fmt.Errorf("%w: '%s': %w", ErrInvalidEnvironment, name, err)
+			}
+
+			loaded := map[string]any{}
+			if err := node.Decode(&loaded); err != nil {
+				return fmt.Errorf("%w: '%s': %w", ErrInvalidEnvironment, name, err)
+			}
+
+			c.Environments[name] = loaded
+		default:
+			return fmt.Errorf("%w: '%s' must be an overlay or the path of an overlay file", ErrInvalidEnvironment,
+				name)
+		}
+	}
+
+	return nil
+}
+
+// validateOverlay checks that the overlay only changes the given fields of the base configuration and that its list
+// items match the base items.
+func validateOverlay(fieldPath string, base, overlay any, fields map[string]any) error {
+	overlayMap, ok := overlay.(map[string]any)
+	if !ok {
+		return fmt.Errorf("%w: '%s' must be a map", ErrInvalidEnvironment, fieldPath)
+	}
+
+	baseMap, _ := base.(map[string]any)
+
+	for key, value := range overlayMap {
+		keyPath := strings.TrimPrefix(fieldPath+"."+key, ".")
+
+		field, ok := fields[key]
+		if !ok {
+			return fmt.Errorf("%w: '%s' cannot differ between the environments", ErrInvalidEnvironment, keyPath)
+		}
+
+		itemFields, ok := field.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		items, isList := value.([]any)
+		if !isList {
+			if err := validateOverlay(keyPath, baseMap[key], value, itemFields); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		baseItems, _ := baseMap[key].([]any)
+
+		for i, item := range items {
+			itemPath := fmt.Sprintf("%s[%d]", keyPath, i)
+
+			idKey, id, ok := itemIdentity(item)
+			if !ok {
+				return fmt.Errorf("%w: '%s' has no name", ErrInvalidEnvironment, itemPath)
+			}
+
+			index := slices.IndexFunc(baseItems, func(baseItem any) bool {
+				baseKey, baseID, ok := itemIdentity(baseItem)
+				return ok && baseKey == idKey && baseID == id
+			})
+			if index < 0 {
+				return fmt.Errorf("%w: '%s' refers to '%v', which is not in the base configuration",
+					ErrInvalidEnvironment, itemPath, id)
+			}
+
+			if err := validateOverlay(itemPath, baseItems[index], item, itemFields); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func mergeOverlay(base, overlay any) any {
+	switch overlayValue := overlay.(type) {
+	case map[string]any:
+		baseMap, ok := base.(map[string]any)
+		if !ok {
+			return overlay
+		}
+
+		for key, value := range overlayValue {
+			baseMap[key] = mergeOverlay(baseMap[key], value)
+		}
+
+		return baseMap
+	case []any:
+		baseList, ok := base.([]any)
+		if !ok {
+			return overlay
+		}
+
+		return mergeOverlayList(baseList, overlayValue)
+	default:
+		return overlay
+	}
+}
+
+// mergeOverlayList merges the items of the overlay into the base items with the same identity and appends the new
+// ones. Lists whose items have no identity are replaced.
+func mergeOverlayList(base, overlay []any) []any {
+	for _, item := range overlay {
+		key, id, ok := itemIdentity(item)
+		if !ok {
+			return overlay
+		}
+
+		index := slices.IndexFunc(base, func(baseItem any) bool {
+			baseKey, baseID, ok := itemIdentity(baseItem)
+			return ok && baseKey == key && baseID == id
+		})
+
+		if index < 0 {
+			base = append(base, item)
+			continue
+		}
+
+		base[index] = mergeOverlay(base[index], item)
+	}
+
+	return base
+}
+
+func itemIdentity(item any) (key string, id any, ok bool) {
+	itemMap, isMap := item.(map[string]any)
+	if !isMap {
+		return "", nil, false
+	}
+
+	for _, key := range identityKeys {
+		if id, ok := itemMap[key]; ok {
+			return key, id, true
+		}
+	}
+
+	return "", nil, false
+}
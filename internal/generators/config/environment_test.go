@@ -0,0 +1,102 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_EnvironmentNames(t *testing.T) {
+	config := &Config{Environments: map[string]any{"uat": nil, "prd": nil, "dev": nil}}
+
+	require.Equal(t, []string{"dev", "prd", "uat"}, config.EnvironmentNames())
+}
+
+func TestConfig_Environment(t *testing.T) {
+	base := &Config{
+		LambdaDefaults: LambdaDefaults{LambdaSettings: LambdaSettings{MemorySize: 128, Timeout: 30}},
+		SQSs: []SQS{
+			{Name: "orders", MaxReceiveCount: 10},
+			{Name: "payments", MaxReceiveCount: 5},
+		},
+		Tags:         map[string]string{"Team": "payments"},
+		RequiredTags: []string{"Team"},
+	}
+
+	tests := []struct {
+		name      string
+		overlay   any
+		want      *Config
+		targetErr error
+	}{
+		{
+			name:    "empty overlay",
+			overlay: map[string]any{},
+			want: &Config{
+				LambdaDefaults: LambdaDefaults{LambdaSettings: LambdaSettings{MemorySize: 128, Timeout: 30}},
+				SQSs: []SQS{
+					{Name: "orders", MaxReceiveCount: 10},
+					{Name: "payments", MaxReceiveCount: 5},
+				},
+				Tags:         map[string]string{"Team": "payments"},
+				RequiredTags: []string{"Team"},
+			},
+		},
+		{
+			name: "maps are merged, items are matched by name and other values are replaced",
+			overlay: map[string]any{
+				"lambda_defaults": map[string]any{"memory_size": 1024},
+				"sqs": []any{
+					map[string]any{"name": "payments", "max_receive_count": 3, "message_retention_seconds": 1209600},
+				},
+			},
+			want: &Config{
+				LambdaDefaults: LambdaDefaults{LambdaSettings: LambdaSettings{MemorySize: 1024, Timeout: 30}},
+				SQSs: []SQS{
+					{Name: "orders", MaxReceiveCount: 10},
+					{Name: "payments", MaxReceiveCount: 3, MessageRetentionSeconds: 1209600},
+				},
+				Tags:         map[string]string{"Team": "payments"},
+				RequiredTags: []string{"Team"},
+			},
+		},
+		{
+			name:      "when the overlay changes a field that is not a setting of the environments should return an error",
+			overlay:   map[string]any{"tags": map[string]any{"Environment": "prd"}},
+			targetErr: ErrInvalidEnvironment,
+		},
+		{
+			name: "when the overlay changes a setting of an item that is not a setting of the environments should " +
+				"return an error",
+			overlay:   map[string]any{"sqs": []any{map[string]any{"name": "orders", "fifo": true}}},
+			targetErr: ErrInvalidEnvironment,
+		},
+		{
+			name:      "when the overlay adds an item should return an error",
+			overlay:   map[string]any{"sqs": []any{map[string]any{"name": "refunds"}}},
+			targetErr: ErrInvalidEnvironment,
+		},
+		{
+			name:      "when the environment has no overlay should return an error",
+			overlay:   "sqs.prd.yaml",
+			targetErr: ErrInvalidEnvironment,
+		},
+		{
+			name:      "when the overlay does not fit the configuration should return an error",
+			overlay:   map[string]any{"sqs": map[string]any{"name": "orders"}},
+			targetErr: ErrInvalidEnvironment,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := *base
+			config.Environments = map[string]any{"prd": tt.overlay}
+
+			got, err := config.Environment("prd")
+
+			require.ErrorIs(t, err, tt.targetErr)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
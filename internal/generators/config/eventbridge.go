@@ -0,0 +1,49 @@
+package config
+
+// DefaultEventBusName is the name of the event bus that exists in every account.
+const DefaultEventBusName = "default"
+
+// EventInputTransformer represents how the matched event is reshaped before being sent to a target.
+type EventInputTransformer struct {
+	// InputPaths maps a variable name to the JSON path of the event it is extracted from.
+	InputPaths    map[string]string `yaml:"input_paths,omitempty"`
+	InputTemplate string            `yaml:"input_template"`
+}
+
+// EventTarget represents a resource invoked by an EventBridge rule.
+type EventTarget struct {
+	Name             string                 `yaml:"name"`
+	InputTransformer *EventInputTransformer `yaml:"input_transformer,omitempty"`
+	// DeadLetterQueue is the name of the SQS queue that receives the events that could not be delivered.
+	DeadLetterQueue        string `yaml:"dead_letter_queue,omitempty"`
+	MaximumRetryAttempts   *int   `yaml:"maximum_retry_attempts,omitempty"`
+	MaximumEventAgeSeconds int    `yaml:"maximum_event_age_seconds,omitempty"`
+}
+
+// EventRule represents an EventBridge rule that matches events with a pattern.
+type EventRule struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	// EventPattern is the JSON pattern the events must match.
+	EventPattern  string        `yaml:"event_pattern"`
+	Enabled       *bool         `yaml:"enabled,omitempty"`
+	Lambdas       []EventTarget `yaml:"lambdas,omitempty"`
+	SQSs          []EventTarget `yaml:"sqs,omitempty"`
+	Kinesis       []EventTarget `yaml:"kinesis,omitempty"`
+	StepFunctions []EventTarget `yaml:"step_functions,omitempty"`
+}
+
+// IsEnabled reports whether the rule is enabled. It defaults to true.
+func (r *EventRule) IsEnabled() bool { return r.Enabled == nil || *r.Enabled }
+
+// EventBus represents an EventBridge event bus and its rules. The bus named "default" is not created.
+type EventBus struct {
+	Name  string      `yaml:"name"`
+	Rules []EventRule `yaml:"rules,omitempty"`
+	Files []File      `yaml:"files,omitempty"`
+}
+
+func (r *EventBus) GetName() string { return r.Name }
+
+// IsDefault reports whether the bus is the default event bus of the account.
+func (r *EventBus) IsDefault() bool { return r.Name == DefaultEventBusName }
@@ -0,0 +1,31 @@
+package config
+
+// FirehoseBuffering represents how much data a delivery stream buffers before writing to its destination.
+type FirehoseBuffering struct {
+	SizeMB          int `yaml:"size_mb,omitempty"`
+	IntervalSeconds int `yaml:"interval_seconds,omitempty"`
+}
+
+// FirehoseDynamicPartitioning represents the dynamic partitioning of the records delivered to S3.
+type FirehoseDynamicPartitioning struct {
+	// PartitionKeys maps a partition key name to the JQ query that extracts it from each record.
+	PartitionKeys        map[string]string `yaml:"partition_keys"`
+	RetryDurationSeconds int               `yaml:"retry_duration_seconds,omitempty"`
+}
+
+type Firehose struct {
+	Name string `yaml:"name"`
+	// KinesisSource is the name of the Kinesis stream the delivery stream reads from. Empty means direct PUT.
+	KinesisSource string `yaml:"kinesis_source,omitempty"`
+	// Bucket is the name of the S3 bucket the records are delivered to.
+	Bucket               string                       `yaml:"bucket"`
+	Prefix               string                       `yaml:"prefix,omitempty"`
+	ErrorOutputPrefix    string                       `yaml:"error_output_prefix,omitempty"`
+	Buffering            *FirehoseBuffering           `yaml:"buffering,omitempty"`
+	Compression          string                       `yaml:"compression,omitempty"`
+	DynamicPartitioning  *FirehoseDynamicPartitioning `yaml:"dynamic_partitioning,omitempty"`
+	TransformationLambda string                       `yaml:"transformation_lambda,omitempty"`
+	Files                []File                       `yaml:"files,omitempty"`
+}
+
+func (r *Firehose) GetName() string { return r.Name }
@@ -0,0 +1,589 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrInvalidInclude      = errors.New("invalid include")
+	ErrConflictingResource = errors.New("conflicting resource")
+	ErrInvalidDocument     = errors.New("invalid document")
+)
+
+const (
+	includeKey = "include"
+	// fragmentsKey holds the YAML anchors shared by the files, which are not part of the configuration.
+	fragmentsKey = "fragments"
+	// includedAnchorsKey is the key under which the anchored values of the files parsed before a file are declared
+	// ahead of its content.
+	includedAnchorsKey = "__included_anchors"
+)
+
+// reErrorLine matches the line of the errors of the YAML parser.
+var reErrorLine = regexp.MustCompile(`line (\d+)`)
+
+// source is a file of the composed configuration, whose lines start at the line of the configuration.
+type source struct {
+	line     int
+	fileName string
+}
+
+// document is the composition of a configuration file and the files it includes. Every file is parsed on its own into
+// its YAML documents, which are composed in order: the included files first and the including file last. The lines of
+// the nodes are numbered across the files, so that every node is located in its file.
+type document struct {
+	roots   []*yaml.Node
+	sources []source
+	lines   int
+	// anchors are the anchored nodes of the files parsed so far, in order, which the files parsed later may refer to.
+	anchors []*yaml.Node
+}
+
+// source returns the file and the line in the file of the line of the document.
+func (d *document) source(line int) (fileName string, fileLine int) {
+	for i := len(d.sources) - 1; i >= 0; i-- {
+		if d.sources[i].line <= line {
+			return d.sources[i].fileName, line - d.sources[i].line + 1
+		}
+	}
+
+	return "", line
+}
+
+func (d *document) location(line int) string {
+	fileName, fileLine := d.source(line)
+
+	return fmt.Sprintf("%s:%d", fileName, fileLine)
+}
+
+// loadDocuments composes the configuration files in order, as if they were included by a single file.
+func loadDocuments(fileNames []string) (*document, error) {
+	doc := &document{}
+	loaded := map[string]bool{}
+
+	for _, fileName := range fileNames {
+		if err := doc.load(fileName, nil, loaded); err != nil {
+			return nil, err
+		}
+	}
+
+	return doc, nil
+}
+
+// load reads the configuration file and the files it includes. The included paths are relative to the folder of the
+// including file. A file is composed only the first time it is included, so two files may include the same one
+// without defining its resources twice.
+func (d *document) load(fileName string, including []string, loaded map[string]bool) error {
+	cleanFileName := filepath.Clean(fileName)
+
+	if slices.Contains(including, cleanFileName) {
+		return fmt.Errorf("%w: '%s' includes itself", ErrInvalidInclude, fileName)
+	}
+
+	if loaded[cleanFileName] {
+		return nil
+	}
+
+	loaded[cleanFileName] = true
+
+	data, err := osReadFile(fileName)
+	if err != nil {
+		return fmt.Errorf("read YAML file error: %w", err)
+	}
+
+	includes, err := readIncludes(data)
+	if err != nil {
+		return fmt.Errorf("%w: '%s': %w", ErrInvalidInclude, fileName, err)
+	}
+
+	for _, include := range includes {
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(filepath.Dir(fileName), include)
+		}
+
+		if err := d.load(include, append(including, cleanFileName), loaded); err != nil {
+			return err
+		}
+	}
+
+	if err := d.parse(fileName, data); err != nil {
+		return fmt.Errorf("unmarshal YAML file error: '%s': %w", fileName, err)
+	}
+
+	return nil
+}
+
+// parse appends the YAML documents of the file. A file referring to the anchors of the files before it is parsed again
+// with their anchored values declared ahead of its content, on a line of their own.
+func (d *document) parse(fileName string, data []byte) error {
+	roots, err := yamlDecodeDocuments(data)
+
+	offset := 0
+
+	if err != nil && len(d.anchors) > 0 && strings.Contains(err.Error(), "unknown anchor") {
+		prelude, preludeErr := d.anchorsPrelude(data)
+		if preludeErr != nil {
+			return preludeErr
+		}
+
+		offset = bytes.Count(prelude, []byte("\n"))
+
+		if roots, err = yamlDecodeDocuments(append(prelude, data...)); err == nil {
+			roots = d.removeAnchorsPrelude(roots)
+		}
+	}
+
+	if err != nil {
+		return shiftErrorLines(err, offset)
+	}
+
+	visited := map[*yaml.Node]bool{}
+	for _, root := range roots {
+		d.renumber(root, d.lines-offset, visited)
+	}
+
+	for _, root := range roots {
+		d.collectAnchors(root, map[*yaml.Node]bool{})
+	}
+
+	d.sources = append(d.sources, source{line: d.lines + 1, fileName: fileName})
+	d.roots = append(d.roots, roots...)
+	d.lines += bytes.Count(data, []byte("\n"))
+
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		d.lines++
+	}
+
+	return nil
+}
+
+// decodeDocuments returns the roots of the YAML documents of the data.
+func decodeDocuments(data []byte) ([]*yaml.Node, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+
+	var roots []*yaml.Node
+
+	for {
+		var node yaml.Node
+
+		err := decoder.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			return roots, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w", err)
+		}
+
+		if len(node.Content) > 0 {
+			roots = append(roots, node.Content[0])
+		}
+	}
+}
+
+// anchorsPrelude returns the anchored values of the files parsed so far as a one-line flow mapping, which declares
+// them ahead of the content of the file. The prelude is a document of its own when the file starts with directives.
+func (d *document) anchorsPrelude(data []byte) ([]byte, error) {
+	anchors := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+	for _, anchor := range d.anchors {
+		anchors.Content = append(anchors.Content, flowCopy(anchor))
+	}
+
+	prelude, err := yaml.Marshal(&yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+		{Kind: yaml.ScalarNode, Value: includedAnchorsKey}, anchors,
+	}})
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	if startsWithDirective(data) {
+		prelude = append(prelude, "...\n"...)
+	}
+
+	return prelude, nil
+}
+
+// removeAnchorsPrelude removes the prelude from the documents of the file. The aliases of the file refer to the
+// anchored nodes of the files before it rather than to their copies of the prelude.
+func (d *document) removeAnchorsPrelude(roots []*yaml.Node) []*yaml.Node {
+	if len(roots) == 0 || roots[0].Kind != yaml.MappingNode {
+		return roots
+	}
+
+	index := mappingIndex(roots[0], includedAnchorsKey)
+	if index < 0 {
+		return roots
+	}
+
+	originals := make(map[*yaml.Node]*yaml.Node, len(d.anchors))
+	for i, anchor := range roots[0].Content[index+1].Content {
+		originals[anchor] = d.anchors[i]
+	}
+
+	roots[0].Content = slices.Delete(roots[0].Content, index, index+2)
+
+	// The prelude is a document of its own when the file starts with a document marker or with directives.
+	if len(roots[0].Content) == 0 {
+		roots = roots[1:]
+	}
+
+	visited := map[*yaml.Node]bool{}
+	for _, root := range roots {
+		replaceAliases(root, originals, visited)
+	}
+
+	return roots
+}
+
+// renumber numbers the lines of the nodes of a file across the files.
+func (d *document) renumber(node *yaml.Node, offset int, visited map[*yaml.Node]bool) {
+	if visited[node] || node.Line == 0 {
+		return
+	}
+
+	visited[node] = true
+	node.Line += offset
+
+	if node.Kind == yaml.AliasNode {
+		return
+	}
+
+	for _, child := range node.Content {
+		d.renumber(child, offset, visited)
+	}
+}
+
+// collectAnchors appends the anchored nodes of a file to the anchors the files parsed later may refer to.
+func (d *document) collectAnchors(node *yaml.Node, visited map[*yaml.Node]bool) {
+	if visited[node] || node.Kind == yaml.AliasNode {
+		return
+	}
+
+	visited[node] = true
+
+	if node.Anchor != "" {
+		d.anchors = append(d.anchors, node)
+	}
+
+	for _, child := range node.Content {
+		d.collectAnchors(child, visited)
+	}
+}
+
+// replaceAliases makes the aliases to the copies of the prelude refer to the original anchored nodes.
+func replaceAliases(node *yaml.Node, originals map[*yaml.Node]*yaml.Node, visited map[*yaml.Node]bool) {
+	if visited[node] {
+		return
+	}
+
+	visited[node] = true
+
+	if node.Kind == yaml.AliasNode {
+		if original, ok := originals[node.Alias]; ok {
+			node.Alias = original
+		}
+
+		return
+	}
+
+	for _, child := range node.Content {
+		replaceAliases(child, originals, visited)
+	}
+}
+
+// flowCopy returns a deep copy of the node in flow style and without comments, so it fits on one line. The aliases it
+// holds are kept by name.
+func flowCopy(node *yaml.Node) *yaml.Node {
+	copied := *node
+	copied.HeadComment, copied.LineComment, copied.FootComment = "", "", ""
+
+	switch node.Kind {
+	case yaml.MappingNode, yaml.SequenceNode:
+		copied.Style = yaml.FlowStyle
+	case yaml.ScalarNode:
+		if node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+			copied.Style = yaml.DoubleQuotedStyle
+		}
+	}
+
+	if node.Kind == yaml.AliasNode {
+		copied.Alias = nil
+		return &copied
+	}
+
+	copied.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		copied.Content[i] = flowCopy(child)
+	}
+
+	return &copied
+}
+
+// startsWithDirective reports whether the first line of the data that is not blank or a comment is a directive, such
+// as %YAML or %TAG.
+func startsWithDirective(data []byte) bool {
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			return strings.HasPrefix(line, "%")
+		}
+	}
+
+	return false
+}
+
+// shiftErrorLines makes the lines of the error of the YAML parser relative to the file rather than to the file
+// preceded by its prelude.
+func shiftErrorLines(err error, offset int) error {
+	if offset == 0 {
+		return err
+	}
+
+	return errors.New(reErrorLine.ReplaceAllStringFunc(err.Error(), func(match string) string {
+		line, _ := strconv.Atoi(reErrorLine.FindStringSubmatch(match)[1])
+		return fmt.Sprintf("line %d", max(line-offset, 1))
+	}))
+}
+
+// isDocumentMarker reports whether the line starts or ends a YAML document.
+func isDocumentMarker(line string) bool {
+	for _, marker := range []string{"---", "..."} {
+		if line == marker || strings.HasPrefix(line, marker+" ") || strings.HasPrefix(line, marker+"\t") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readIncludes reads the include list of the first document of the file. It is read on its own because the file may
+// refer to anchors of the included files, which make the file invalid until they are parsed.
+func readIncludes(data []byte) ([]string, error) {
+	var block []string
+
+	started := false
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		if isDocumentMarker(line) {
+			if started {
+				break
+			}
+
+			continue
+		}
+
+		if !started && strings.HasPrefix(line, "%") {
+			continue
+		}
+
+		if len(block) > 0 && line != "" && !strings.ContainsAny(line[:1], " -#") {
+			break
+		}
+
+		if len(block) > 0 || strings.HasPrefix(line, includeKey+":") {
+			block = append(block, line)
+		}
+
+		if trimmed := strings.TrimSpace(line); trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			started = true
+		}
+	}
+
+	var file struct {
+		Include []string `yaml:"include"`
+	}
+
+	if err := yaml.Unmarshal([]byte(strings.Join(block, "\n")), &file); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return file.Include, nil
+}
+
+// compose merges the documents of the included files and the documents of the file itself, in this order. Maps are
+// merged key by key, lists are concatenated and any other value is replaced by the one that comes later. The same
+// resource defined by two files is a conflict.
+func (d *document) compose() (*yaml.Node, error) {
+	composed := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+	for _, root := range d.roots {
+		if root.Tag == "!!null" {
+			continue
+		}
+
+		if root.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("%w: %s: the document must be a map", ErrInvalidDocument, d.location(root.Line))
+		}
+
+		own := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			key, value := root.Content[i], root.Content[i+1]
+
+			switch key.Value {
+			case includeKey, fragmentsKey:
+			case "environments":
+				d.resolveEnvironmentFiles(value)
+
+				own.Content = append(own.Content, key, value)
+			case templatePackKey:
+				d.resolvePath(value)
+
+				own.Content = append(own.Content, key, value)
+			case pluginsKey:
+				for _, item := range value.Content {
+					d.resolvePath(item)
+				}
+
+				own.Content = append(own.Content, key, value)
+			default:
+				own.Content = append(own.Content, key, value)
+			}
+		}
+
+		if err := d.merge(composed, own); err != nil {
+			return nil, err
+		}
+	}
+
+	return composed, nil
+}
+
+// resolveEnvironmentFiles makes the paths of the overlay files relative to the folder of the file that defines them.
+func (d *document) resolveEnvironmentFiles(node *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 1; i < len(node.Content); i += 2 {
+		d.resolvePath(node.Content[i])
+	}
+}
+
+// resolvePath makes the path relative to the folder of the file that defines it.
+func (d *document) resolvePath(node *yaml.Node) {
+	if node.Kind != yaml.ScalarNode || node.Tag != "!!str" || filepath.IsAbs(node.Value) {
+		return
+	}
+
+	fileName, _ := d.source(node.Line)
+	node.Value = filepath.Join(filepath.Dir(fileName), node.Value)
+}
+
+func (d *document) merge(base, overlay *yaml.Node) error {
+	for i := 0; i+1 < len(overlay.Content); i += 2 {
+		key, value := overlay.Content[i], overlay.Content[i+1]
+
+		index := mappingIndex(base, key.Value)
+		if index < 0 {
+			base.Content = append(base.Content, key, value)
+			continue
+		}
+
+		// The anchored nodes are copied before they are merged, so the overlay does not change their aliases.
+		baseValue := base.Content[index+1]
+		if baseValue.Kind == yaml.AliasNode || baseValue.Anchor != "" {
+			baseValue = copyNode(resolveAlias(baseValue))
+			base.Content[index+1] = baseValue
+		}
+
+		switch {
+		case baseValue.Kind == yaml.MappingNode && resolveAlias(value).Kind == yaml.MappingNode:
+			if err := d.merge(baseValue, resolveAlias(value)); err != nil {
+				return err
+			}
+		case baseValue.Kind == yaml.SequenceNode && resolveAlias(value).Kind == yaml.SequenceNode:
+			if err := d.mergeList(key.Value, baseValue, resolveAlias(value)); err != nil {
+				return err
+			}
+		default:
+			base.Content[index+1] = value
+		}
+	}
+
+	return nil
+}
+
+func (d *document) mergeList(name string, base, overlay *yaml.Node) error {
+	for _, item := range overlay.Content {
+		if id, ok := nodeIdentity(item); ok {
+			index := slices.IndexFunc(base.Content, func(baseItem *yaml.Node) bool {
+				baseID, ok := nodeIdentity(baseItem)
+				return ok && baseID == id
+			})
+
+			if index >= 0 {
+				return fmt.Errorf("%w: %s '%s' is defined at %s and %s", ErrConflictingResource, name, id,
+					d.location(base.Content[index].Line), d.location(item.Line))
+			}
+		}
+
+		base.Content = append(base.Content, item)
+	}
+
+	return nil
+}
+
+// mappingIndex returns the index of the key in the content of the mapping node, or -1 when it has no such key.
+func mappingIndex(node *yaml.Node, key string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// nodeIdentity returns the name of the resource of the list item. The items without a name, such as the API Gateways
+// of a stack, are never conflicting.
+func nodeIdentity(node *yaml.Node) (string, bool) {
+	node = resolveAlias(node)
+	if node.Kind != yaml.MappingNode {
+		return "", false
+	}
+
+	if i := mappingIndex(node, "name"); i >= 0 && node.Content[i+1].Kind == yaml.ScalarNode {
+		return node.Content[i+1].Value, true
+	}
+
+	return "", false
+}
+
+// copyNode returns a deep copy of the node without its anchor. The aliases it holds still refer to the anchored
+// nodes, which are copied in turn when they are merged.
+func copyNode(node *yaml.Node) *yaml.Node {
+	copied := *node
+	copied.Anchor = ""
+
+	if node.Kind == yaml.AliasNode {
+		return &copied
+	}
+
+	copied.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		copied.Content[i] = copyNode(child)
+	}
+
+	return &copied
+}
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	return node
+}
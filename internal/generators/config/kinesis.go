@@ -1,10 +1,24 @@
 package config
 
+// Kinesis stream modes.
+const (
+	KinesisStreamModeOnDemand    = "ON_DEMAND"
+	KinesisStreamModeProvisioned = "PROVISIONED"
+)
+
 type Kinesis struct {
-	Name            string `yaml:"name"`
-	RetentionPeriod string `yaml:"retention_period,omitempty"`
-	KMSKeyID        string `yaml:"kms_key_id,omitempty"`
-	Files           []File `yaml:"files,omitempty"`
+	Name              string   `yaml:"name"`
+	RetentionPeriod   string   `yaml:"retention_period,omitempty"`
+	KMSKeyID          string   `yaml:"kms_key_id,omitempty"`
+	StreamMode        string   `yaml:"stream_mode,omitempty"`
+	ShardCount        int      `yaml:"shard_count,omitempty"`
+	ShardLevelMetrics []string `yaml:"shard_level_metrics,omitempty"`
+	Consumers         []string `yaml:"consumers,omitempty"`
+	// Alarms overrides the thresholds of the observability section.
+	Alarms *KinesisAlarms `yaml:"alarms,omitempty"`
+	// Tags are merged over the tags of the configuration.
+	Tags  map[string]string `yaml:"tags,omitempty"`
+	Files []File            `yaml:"files,omitempty"`
 }
 
 func (r *Kinesis) GetName() string { return r.Name }
@@ -1,29 +1,144 @@
 package config
 
+// LambdaVPC represents the network configuration of a Lambda function. The values are Terraform expressions.
+type LambdaVPC struct {
+	SubnetIDs        []string `yaml:"subnet_ids"`
+	SecurityGroupIDs []string `yaml:"security_group_ids"`
+}
+
+// LambdaSettings represents the runtime settings of a Lambda function.
+type LambdaSettings struct {
+	MemorySize int `yaml:"memory_size,omitempty"`
+	// Timeout is in seconds.
+	Timeout      int    `yaml:"timeout,omitempty"`
+	Architecture string `yaml:"architecture,omitempty"`
+	// EphemeralStorage is the size of the /tmp directory in MB.
+	EphemeralStorage int `yaml:"ephemeral_storage,omitempty"`
+	// Layers are Terraform expressions of the layer version ARNs.
+	Layers []string `yaml:"layers,omitempty"`
+	// ReservedConcurrency is a pointer because zero is valid and stops the function from being invoked.
+	ReservedConcurrency    *int       `yaml:"reserved_concurrency,omitempty"`
+	ProvisionedConcurrency int        `yaml:"provisioned_concurrency,omitempty"`
+	VPC                    *LambdaVPC `yaml:"vpc,omitempty"`
+}
+
+// WithDefaults returns the settings with every unset field taken from the defaults.
+func (s LambdaSettings) WithDefaults(defaults LambdaSettings) LambdaSettings {
+	if s.MemorySize == 0 {
+		s.MemorySize = defaults.MemorySize
+	}
+
+	if s.Timeout == 0 {
+		s.Timeout = defaults.Timeout
+	}
+
+	if s.Architecture == "" {
+		s.Architecture = defaults.Architecture
+	}
+
+	if s.EphemeralStorage == 0 {
+		s.EphemeralStorage = defaults.EphemeralStorage
+	}
+
+	if len(s.Layers) == 0 {
+		s.Layers = defaults.Layers
+	}
+
+	if s.ReservedConcurrency == nil {
+		s.ReservedConcurrency = defaults.ReservedConcurrency
+	}
+
+	if s.ProvisionedConcurrency == 0 {
+		s.ProvisionedConcurrency = defaults.ProvisionedConcurrency
+	}
+
+	if s.VPC == nil {
+		s.VPC = defaults.VPC
+	}
+
+	return s
+}
+
+// LambdaDefaults represents the settings inherited by every Lambda function that does not set them.
+type LambdaDefaults struct {
+	LambdaSettings `yaml:",inline"`
+
+	RoleName string `yaml:"role_name,omitempty"`
+	Runtime  string `yaml:"runtime,omitempty"`
+}
+
 type Lambda struct {
-	Name            string            `yaml:"name"`
-	Source          string            `yaml:"source"`
-	RoleName        string            `yaml:"role_name,omitempty"`
-	Runtime         string            `yaml:"runtime,omitempty"`
-	Description     string            `yaml:"description"`
-	Envars          map[string]string `yaml:"envars,omitempty"`
-	KinesisTriggers []KinesisTrigger  `yaml:"kinesis-triggers,omitempty"`
-	SQSTriggers     []SQSTrigger      `yaml:"sqs-triggers,omitempty"`
-	Crons           []Cron            `yaml:"crons,omitempty"`
-	Files           []File            `yaml:"files,omitempty"`
+	LambdaSettings `yaml:",inline"`
+
+	Name        string            `yaml:"name"`
+	Source      string            `yaml:"source"`
+	RoleName    string            `yaml:"role_name,omitempty"`
+	Runtime     string            `yaml:"runtime,omitempty"`
+	Description string            `yaml:"description"`
+	Envars      map[string]string `yaml:"envars,omitempty"`
+	// Secrets lists the names of the configured secrets the lambda reads.
+	Secrets []string `yaml:"secrets,omitempty"`
+	// RDS lists the names of the configured databases the lambda connects to.
+	RDS []string `yaml:"rds,omitempty"`
+	// Alarms overrides the thresholds of the observability section.
+	Alarms          *LambdaAlarms    `yaml:"alarms,omitempty"`
+	KinesisTriggers []KinesisTrigger `yaml:"kinesis-triggers,omitempty"`
+	SQSTriggers     []SQSTrigger     `yaml:"sqs-triggers,omitempty"`
+	Crons           []Cron           `yaml:"crons,omitempty"`
+	Files           []File           `yaml:"files,omitempty"`
+	// Tags are merged over the tags of the configuration.
+	Tags map[string]string `yaml:"tags,omitempty"`
 }
 
 func (r *Lambda) GetName() string { return r.Name }
 
 type SQSTrigger struct {
 	SourceARN string `yaml:"source_arn"`
+	// Enabled defaults to true.
+	Enabled                      *bool `yaml:"enabled,omitempty"`
+	BatchSize                    int   `yaml:"batch_size,omitempty"`
+	MaximumBatchingWindowSeconds int   `yaml:"maximum_batching_window_seconds,omitempty"`
+	MaximumConcurrency           int   `yaml:"maximum_concurrency,omitempty"`
+	// FilterCriteria is a list of JSON event filtering patterns.
+	FilterCriteria          []string `yaml:"filter_criteria,omitempty"`
+	ReportBatchItemFailures bool     `yaml:"report_batch_item_failures,omitempty"`
 }
 
 type Cron struct {
+	// Name distinguishes the schedules of the same lambda.
+	Name               string `yaml:"name,omitempty"`
 	ScheduleExpression string `yaml:"schedule_expression"`
 	IsEnabled          string `yaml:"is_enabled"`
+	// Input is the JSON payload sent to the lambda on every invocation.
+	Input string `yaml:"input,omitempty"`
+	// Scheduler creates an EventBridge Scheduler schedule instead of an EventBridge rule.
+	Scheduler bool   `yaml:"scheduler,omitempty"`
+	TimeZone  string `yaml:"time_zone,omitempty"`
 }
 
 type KinesisTrigger struct {
 	SourceARN string `yaml:"source_arn"`
+	// Consumer is the name of an enhanced fan-out consumer declared in the kinesis configuration.
+	Consumer                   string `yaml:"consumer,omitempty"`
+	BatchSize                  int    `yaml:"batch_size,omitempty"`
+	StartingPosition           string `yaml:"starting_position,omitempty"`
+	ParallelizationFactor      int    `yaml:"parallelization_factor,omitempty"`
+	BisectBatchOnFunctionError bool   `yaml:"bisect_batch_on_function_error,omitempty"`
+	OnFailureDestinationARN    string `yaml:"on_failure_destination_arn,omitempty"`
+	// Enabled defaults to true.
+	Enabled                      *bool `yaml:"enabled,omitempty"`
+	MaximumBatchingWindowSeconds int   `yaml:"maximum_batching_window_seconds,omitempty"`
+	// FilterCriteria is a list of JSON event filtering patterns.
+	FilterCriteria          []string `yaml:"filter_criteria,omitempty"`
+	ReportBatchItemFailures bool     `yaml:"report_batch_item_failures,omitempty"`
 }
+
+// IsEnabled reports whether the event source mapping is enabled. It defaults to true.
+func (r *SQSTrigger) IsEnabled() bool { return r.Enabled == nil || *r.Enabled }
+
+// IsEnabled reports whether the event source mapping is enabled. It defaults to true.
+func (r *KinesisTrigger) IsEnabled() bool { return r.Enabled == nil || *r.Enabled }
+
+// UsesScheduler reports whether the cron is created with the EventBridge Scheduler. Time zones are only supported by
+// the scheduler, so setting one implies it.
+func (c *Cron) UsesScheduler() bool { return c.Scheduler || c.TimeZone != "" }
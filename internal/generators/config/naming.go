@@ -0,0 +1,184 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ettle/strcase"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/resources"
+)
+
+// NamePlaceholder is replaced by the name of the resource in the patterns and labels of the naming conventions.
+const NamePlaceholder = "{name}"
+
+// Casings supported by the naming conventions.
+const (
+	CaseCamel          = "camel"
+	CasePascal         = "pascal"
+	CaseKebab          = "kebab"
+	CaseSnake          = "snake"
+	CaseScreamingSnake = "screaming_snake"
+)
+
+var (
+	ErrInvalidNaming = errors.New("invalid naming convention")
+	ErrNameTooLong   = errors.New("name too long")
+)
+
+var caseFuncs = map[string]func(string) string{
+	CaseCamel:          strcase.ToCamel,
+	CasePascal:         strcase.ToPascal,
+	CaseKebab:          strcase.ToKebab,
+	CaseSnake:          strcase.ToSnake,
+	CaseScreamingSnake: strcase.ToSNAKE,
+}
+
+// reInterpolation matches the Terraform interpolations of a pattern, which are not counted in the name length.
+var reInterpolation = regexp.MustCompile(`\$\{[^}]*\}`)
+
+const prefixClientEnvironment = "${var.client}-${var.environment}-" + NamePlaceholder
+
+var defaultNamingConventions = map[resources.ResourceType]NamingConvention{
+	// The name of the crons is the name of their lambda.
+	resources.CronType: {
+		Pattern: NamePlaceholder, Label: NamePlaceholder + "_cron",
+	},
+	resources.DatabaseType: {
+		Case: CaseKebab, Pattern: NamePlaceholder, Label: NamePlaceholder + "_rds", MaxLength: 63,
+	},
+	resources.GoogleBQType: {
+		Case: CaseKebab, Pattern: NamePlaceholder, Label: NamePlaceholder,
+	},
+	resources.KinesisType: {
+		Case: CasePascal, Pattern: NamePlaceholder, Label: NamePlaceholder + "_kinesis", MaxLength: 128,
+	},
+	resources.LambdaType: {
+		Case: CaseCamel, Pattern: NamePlaceholder, Label: NamePlaceholder + "_lambda", MaxLength: 64,
+	},
+	resources.RestfulAPIType: {
+		Case: CasePascal, Pattern: NamePlaceholder, Label: NamePlaceholder,
+	},
+	resources.S3Type: {
+		Case: CaseKebab, Pattern: prefixClientEnvironment, Label: NamePlaceholder + "_bucket", MaxLength: 63,
+	},
+	resources.SNSType: {
+		Case: CaseKebab, Pattern: NamePlaceholder, Label: NamePlaceholder, MaxLength: 256,
+	},
+	resources.SQSType: {
+		Case: CaseKebab, Pattern: prefixClientEnvironment, Label: NamePlaceholder + "_sqs", MaxLength: 80,
+	},
+}
+
+// NamingConvention represents how the resources of a type are named.
+type NamingConvention struct {
+	// Case is the casing of the names derived from diagrams, Terraform files and environment variables: camel, pascal,
+	// kebab, snake or screaming_snake.
+	Case string `yaml:"case,omitempty"`
+	// Pattern is the name of the resource in AWS. The {name} placeholder is replaced by the name of the resource.
+	Pattern string `yaml:"pattern,omitempty"`
+	// Label is the Terraform label of the resource. The {name} placeholder is replaced by the name of the resource in
+	// snake case.
+	Label string `yaml:"label,omitempty"`
+	// MaxLength is the maximum length of the name in AWS. The Terraform interpolations of the pattern are not counted.
+	MaxLength int `yaml:"max_length,omitempty"`
+}
+
+// WithDefaults returns the convention with every unset field taken from the defaults.
+func (c NamingConvention) WithDefaults(defaults NamingConvention) NamingConvention {
+	if c.Case == "" {
+		c.Case = defaults.Case
+	}
+
+	if c.Pattern == "" {
+		c.Pattern = defaults.Pattern
+	}
+
+	if c.Label == "" {
+		c.Label = defaults.Label
+	}
+
+	if c.MaxLength == 0 {
+		c.MaxLength = defaults.MaxLength
+	}
+
+	return c
+}
+
+// Naming maps the resource types to their naming conventions. The unset conventions and fields fall back to the
+// defaults of the generators.
+type Naming map[resources.ResourceType]NamingConvention
+
+// Convention returns the naming convention of the resource type merged with its defaults.
+func (n Naming) Convention(resourceType resources.ResourceType) NamingConvention {
+	return n[resourceType].WithDefaults(defaultNamingConventions[resourceType])
+}
+
+// Case returns the name in the casing of the resource type. Types without casing return the name unchanged.
+func (n Naming) Case(resourceType resources.ResourceType, name string) string {
+	if toCase, ok := caseFuncs[n.Convention(resourceType).Case]; ok {
+		return toCase(name)
+	}
+
+	return name
+}
+
+// Name returns the name of the resource in AWS. It fails when the name is longer than the maximum length of the
+// resource type.
+func (n Naming) Name(resourceType resources.ResourceType, name string) (string, error) {
+	conv := n.Convention(resourceType)
+
+	value := name
+	if conv.Pattern != "" {
+		value = strings.ReplaceAll(conv.Pattern, NamePlaceholder, name)
+	}
+
+	if length := len(reInterpolation.ReplaceAllString(value, "")); conv.MaxLength > 0 && length > conv.MaxLength {
+		return "", fmt.Errorf("%w: %s '%s' has %d characters, the limit is %d", ErrNameTooLong, resourceType, value,
+			length, conv.MaxLength)
+	}
+
+	return value, nil
+}
+
+// Label returns the Terraform label of the resource.
+func (n Naming) Label(resourceType resources.ResourceType, name string) string {
+	label := n.Convention(resourceType).Label
+	if label == "" {
+		label = NamePlaceholder
+	}
+
+	return strings.ReplaceAll(label, NamePlaceholder, strcase.ToSnake(name))
+}
+
+// MatchLabel reports whether the Terraform label follows the label pattern of the resource type, regardless of the
+// casing.
+func (n Naming) MatchLabel(resourceType resources.ResourceType, label string) bool {
+	prefix, suffix, _ := strings.Cut(strings.ToLower(n.Convention(resourceType).Label), NamePlaceholder)
+	label = strings.ToLower(label)
+
+	return len(label) > len(prefix)+len(suffix) && strings.HasPrefix(label, prefix) && strings.HasSuffix(label, suffix)
+}
+
+// Validate reports the conventions with an unknown casing or without the name placeholder.
+func (n Naming) Validate() error {
+	for resourceType, conv := range n {
+		if conv.Case != "" {
+			if _, ok := caseFuncs[conv.Case]; !ok {
+				return fmt.Errorf("%w: unknown case '%s' of %s", ErrInvalidNaming, conv.Case, resourceType)
+			}
+		}
+
+		if conv.Pattern != "" && !strings.Contains(conv.Pattern, NamePlaceholder) {
+			return fmt.Errorf("%w: pattern of %s misses %s", ErrInvalidNaming, resourceType, NamePlaceholder)
+		}
+
+		if conv.Label != "" && !strings.Contains(conv.Label, NamePlaceholder) {
+			return fmt.Errorf("%w: label of %s misses %s", ErrInvalidNaming, resourceType, NamePlaceholder)
+		}
+	}
+
+	return nil
+}
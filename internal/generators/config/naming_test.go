@@ -0,0 +1,185 @@
+package config
+
+import (
+	"testing"
+
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNaming_Case(t *testing.T) {
+	tests := []struct {
+		name         string
+		n            Naming
+		resourceType awsresources.ResourceType
+		value        string
+		want         string
+	}{
+		{
+			name:         "default casing",
+			resourceType: awsresources.LambdaType,
+			value:        "ORDERS_RECEIVER",
+			want:         "ordersReceiver",
+		},
+		{
+			name:         "custom casing",
+			n:            Naming{awsresources.LambdaType: {Case: CaseKebab}},
+			resourceType: awsresources.LambdaType,
+			value:        "ORDERS_RECEIVER",
+			want:         "orders-receiver",
+		},
+		{
+			name:         "type without casing",
+			resourceType: awsresources.CronType,
+			value:        "ORDERS_RECEIVER",
+			want:         "ORDERS_RECEIVER",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.n.Case(tt.resourceType, tt.value))
+		})
+	}
+}
+
+func TestNaming_Name(t *testing.T) {
+	tests := []struct {
+		name         string
+		n            Naming
+		resourceType awsresources.ResourceType
+		value        string
+		want         string
+		targetErr    error
+	}{
+		{
+			name:         "default pattern",
+			resourceType: awsresources.SQSType,
+			value:        "orders",
+			want:         "${var.client}-${var.environment}-orders",
+		},
+		{
+			name:         "custom pattern",
+			n:            Naming{awsresources.SQSType: {Pattern: "acme-{name}"}},
+			resourceType: awsresources.SQSType,
+			value:        "orders",
+			want:         "acme-orders",
+		},
+		{
+			name:         "interpolations are not counted in the length",
+			n:            Naming{awsresources.SQSType: {MaxLength: 8}},
+			resourceType: awsresources.SQSType,
+			value:        "orders",
+			want:         "${var.client}-${var.environment}-orders",
+		},
+		{
+			name:         "name too long",
+			n:            Naming{awsresources.LambdaType: {MaxLength: 5}},
+			resourceType: awsresources.LambdaType,
+			value:        "ordersReceiver",
+			targetErr:    ErrNameTooLong,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.n.Name(tt.resourceType, tt.value)
+
+			require.ErrorIs(t, err, tt.targetErr)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNaming_Label(t *testing.T) {
+	tests := []struct {
+		name         string
+		n            Naming
+		resourceType awsresources.ResourceType
+		value        string
+		want         string
+	}{
+		{
+			name:         "default label",
+			resourceType: awsresources.KinesisType,
+			value:        "OrdersStream",
+			want:         "orders_stream_kinesis",
+		},
+		{
+			name:         "custom label",
+			n:            Naming{awsresources.KinesisType: {Label: "stream_{name}"}},
+			resourceType: awsresources.KinesisType,
+			value:        "OrdersStream",
+			want:         "stream_orders_stream",
+		},
+		{
+			name:         "type without label",
+			resourceType: awsresources.EndpointType,
+			value:        "dailyReport",
+			want:         "daily_report",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.n.Label(tt.resourceType, tt.value))
+		})
+	}
+}
+
+func TestNaming_MatchLabel(t *testing.T) {
+	tests := []struct {
+		name  string
+		n     Naming
+		label string
+		want  bool
+	}{
+		{name: "default label", label: "orders_receiver_lambda", want: true},
+		{name: "default label in upper case", label: "ORDERS_RECEIVER_LAMBDA", want: true},
+		{name: "label of another convention", label: "orders_receiver", want: false},
+		{name: "only the suffix", label: "_lambda", want: false},
+		{name: "custom label", n: Naming{awsresources.LambdaType: {Label: "fn_{name}"}}, label: "fn_orders", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.n.MatchLabel(awsresources.LambdaType, tt.label))
+		})
+	}
+}
+
+func TestNaming_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		n         Naming
+		targetErr error
+	}{
+		{
+			name: "valid conventions",
+			n: Naming{
+				awsresources.LambdaType: {Case: CaseSnake, Pattern: "{name}-fn", Label: "{name}_fn", MaxLength: 64},
+			},
+		},
+		{
+			name:      "unknown case",
+			n:         Naming{awsresources.LambdaType: {Case: "train"}},
+			targetErr: ErrInvalidNaming,
+		},
+		{
+			name:      "pattern without the name placeholder",
+			n:         Naming{awsresources.SQSType: {Pattern: "orders"}},
+			targetErr: ErrInvalidNaming,
+		},
+		{
+			name:      "label without the name placeholder",
+			n:         Naming{awsresources.SQSType: {Label: "orders"}},
+			targetErr: ErrInvalidNaming,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, tt.n.Validate(), tt.targetErr)
+		})
+	}
+}
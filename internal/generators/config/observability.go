@@ -0,0 +1,86 @@
+package config
+
+// LambdaAlarms represents the thresholds of the alarms of a Lambda function.
+type LambdaAlarms struct {
+	Disabled  bool `yaml:"disabled,omitempty"`
+	Errors    int  `yaml:"errors,omitempty"`
+	Throttles int  `yaml:"throttles,omitempty"`
+	// Duration is in milliseconds. Defaults to 80% of the timeout of the function.
+	Duration int `yaml:"duration,omitempty"`
+}
+
+// WithDefaults returns the thresholds with every unset field taken from the defaults.
+func (a LambdaAlarms) WithDefaults(defaults LambdaAlarms) LambdaAlarms {
+	if a.Errors == 0 {
+		a.Errors = defaults.Errors
+	}
+
+	if a.Throttles == 0 {
+		a.Throttles = defaults.Throttles
+	}
+
+	if a.Duration == 0 {
+		a.Duration = defaults.Duration
+	}
+
+	return a
+}
+
+// SQSAlarms represents the thresholds of the alarms of an SQS queue.
+type SQSAlarms struct {
+	Disabled bool `yaml:"disabled,omitempty"`
+	// AgeOfOldestMessage is in seconds.
+	AgeOfOldestMessage int `yaml:"age_of_oldest_message,omitempty"`
+	// DLQDepth is the number of messages in the dead-letter queue.
+	DLQDepth int `yaml:"dlq_depth,omitempty"`
+}
+
+// WithDefaults returns the thresholds with every unset field taken from the defaults.
+func (a SQSAlarms) WithDefaults(defaults SQSAlarms) SQSAlarms {
+	if a.AgeOfOldestMessage == 0 {
+		a.AgeOfOldestMessage = defaults.AgeOfOldestMessage
+	}
+
+	if a.DLQDepth == 0 {
+		a.DLQDepth = defaults.DLQDepth
+	}
+
+	return a
+}
+
+// KinesisAlarms represents the thresholds of the alarms of a Kinesis stream.
+type KinesisAlarms struct {
+	Disabled bool `yaml:"disabled,omitempty"`
+	// IteratorAge is in milliseconds.
+	IteratorAge int `yaml:"iterator_age,omitempty"`
+}
+
+// WithDefaults returns the thresholds with every unset field taken from the defaults.
+func (a KinesisAlarms) WithDefaults(defaults KinesisAlarms) KinesisAlarms {
+	if a.IteratorAge == 0 {
+		a.IteratorAge = defaults.IteratorAge
+	}
+
+	return a
+}
+
+// Dashboard represents the CloudWatch dashboard of the stack.
+type Dashboard struct {
+	Disabled bool `yaml:"disabled,omitempty"`
+	// Name defaults to local.stack_name.
+	Name string `yaml:"name,omitempty"`
+}
+
+// Observability represents the CloudWatch alarms and dashboard generated for the resources of the stack. The
+// thresholds are the defaults of every resource, which can override them in their alarms field.
+type Observability struct {
+	// AlarmActions are Terraform expressions. Defaults to var.alerting_sns_topic_arn.
+	AlarmActions []string `yaml:"alarm_actions,omitempty"`
+	// Period is in seconds. Defaults to 300.
+	Period            int           `yaml:"period,omitempty"`
+	EvaluationPeriods int           `yaml:"evaluation_periods,omitempty"`
+	Lambda            LambdaAlarms  `yaml:"lambda,omitempty"`
+	SQS               SQSAlarms     `yaml:"sqs,omitempty"`
+	Kinesis           KinesisAlarms `yaml:"kinesis,omitempty"`
+	Dashboard         Dashboard     `yaml:"dashboard,omitempty"`
+}
@@ -1,10 +1,18 @@
 package config
 
 type OverrideDefaultTemplates struct {
-	APIGateway []FilenameTemplateMap `yaml:"apigateway,omitempty"`
-	Kinesis    []FilenameTemplateMap `yaml:"kinesis,omitempty"`
-	Lambda     []FilenameTemplateMap `yaml:"lambda,omitempty"`
-	S3Bucket   []FilenameTemplateMap `yaml:"bucket,omitempty"`
-	SNS        []FilenameTemplateMap `yaml:"sns,omitempty"`
-	SQS        []FilenameTemplateMap `yaml:"sqs,omitempty"`
+	APIGateway    []FilenameTemplateMap `yaml:"apigateway,omitempty"`
+	EventBridge   []FilenameTemplateMap `yaml:"eventbridge,omitempty"`
+	Firehose      []FilenameTemplateMap `yaml:"firehose,omitempty"`
+	Kinesis       []FilenameTemplateMap `yaml:"kinesis,omitempty"`
+	Lambda        []FilenameTemplateMap `yaml:"lambda,omitempty"`
+	Observability []FilenameTemplateMap `yaml:"observability,omitempty"`
+	RDS           []FilenameTemplateMap `yaml:"rds,omitempty"`
+	S3Bucket      []FilenameTemplateMap `yaml:"bucket,omitempty"`
+	Secrets       []FilenameTemplateMap `yaml:"secrets,omitempty"`
+	SNS           []FilenameTemplateMap `yaml:"sns,omitempty"`
+	SQS           []FilenameTemplateMap `yaml:"sqs,omitempty"`
+	StepFunctions []FilenameTemplateMap `yaml:"stepfunctions,omitempty"`
+	// Custom are the templates of the custom resources, by the type of their plugin.
+	Custom map[string][]FilenameTemplateMap `yaml:"custom,omitempty"`
 }
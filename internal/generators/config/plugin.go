@@ -0,0 +1,180 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/resources"
+)
+
+var ErrInvalidPlugin = errors.New("invalid plugin")
+
+const (
+	pluginsKey         = "plugins"
+	customResourcesKey = "custom_resources"
+)
+
+// Plugin is the file of a plugin, which declares a resource type beyond the built-in ones.
+type Plugin struct {
+	// Type is the resource type, which names the resources of the type in custom_resources.
+	Type string `yaml:"type"`
+	// DisplayName is the name of the type in the diagrams. It defaults to the type.
+	DisplayName string `yaml:"display_name,omitempty"`
+	// Style is the regular expression matching the draw.io styles of the resource.
+	Style string `yaml:"style,omitempty"`
+	// Labels are the Terraform resource types of the resource.
+	Labels []string `yaml:"labels,omitempty"`
+	// NameAttribute is the attribute of the Terraform resource holding the name of the resource. It defaults to name.
+	NameAttribute string `yaml:"name_attribute,omitempty"`
+	// EnvarSuffixes are the suffixes of the lambda environment variables referring to the resource.
+	EnvarSuffixes []string `yaml:"envar_suffixes,omitempty"`
+	// Image is the image of the resource in the drawn diagrams.
+	Image string `yaml:"image,omitempty"`
+	// Naming is the default naming convention of the resource type.
+	Naming NamingConvention `yaml:"naming,omitempty"`
+	// Schema is the JSON Schema of the configuration of each resource.
+	Schema map[string]any `yaml:"schema,omitempty"`
+	// Templates are the templates generating the files of the resources, by file name.
+	Templates FilenameTemplateMap `yaml:"templates,omitempty"`
+}
+
+// CustomResource is the configuration of a resource of a plugin type. Its fields are declared by the schema of the
+// plugin, and the name is required.
+type CustomResource map[string]any
+
+func (r CustomResource) GetName() string {
+	name, _ := r["name"].(string)
+	return name
+}
+
+// loadPlugins registers the resource types of the plugins, in place of the ones of the configurations parsed before,
+// and checks the custom resources against them. The registry of the resources package is global, so the types of the
+// plugins of a configuration are unregistered as soon as another configuration is parsed: its generators must run
+// before then.
+func (c *Config) loadPlugins() error {
+	resources.ResetResourceTypes()
+
+	for _, fileName := range c.Plugins {
+		if err := c.loadPlugin(fileName); err != nil {
+			return fmt.Errorf("%w: '%s': %w", ErrInvalidPlugin, fileName, err)
+		}
+	}
+
+	for resourceType, customResources := range c.CustomResources {
+		def, ok := resources.LookupResourceType(resources.ParseResourceType(resourceType))
+		if !ok {
+			return fmt.Errorf("%w: no plugin declares the type of the custom resources '%s'", ErrInvalidPlugin,
+				resourceType)
+		}
+
+		for i, resource := range customResources {
+			if resource.GetName() == "" {
+				return fmt.Errorf("%w: %s %d misses the name", ErrInvalidPlugin, resourceType, i)
+			}
+
+			if missing := missingRequired(def.Schema, resource); missing != "" {
+				return fmt.Errorf("%w: %s '%s' misses '%s'", ErrInvalidPlugin, resourceType, resource.GetName(),
+					missing)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) loadPlugin(fileName string) error {
+	data, err := osReadFile(fileName)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	var plugin Plugin
+	if err := yaml.Unmarshal(data, &plugin); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	err = resources.RegisterResourceType(resources.ResourceTypeDefinition{
+		Type:          resources.ResourceType(plugin.Type),
+		DisplayName:   plugin.DisplayName,
+		StylePattern:  plugin.Style,
+		Labels:        plugin.Labels,
+		NameAttribute: plugin.NameAttribute,
+		EnvarSuffixes: plugin.EnvarSuffixes,
+		Image:         plugin.Image,
+		Schema:        plugin.Schema,
+		Templates:     plugin.Templates,
+	})
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	if plugin.Naming != (NamingConvention{}) {
+		if c.Naming == nil {
+			c.Naming = Naming{}
+		}
+
+		resourceType := resources.ParseResourceType(plugin.Type)
+		c.Naming[resourceType] = c.Naming[resourceType].WithDefaults(plugin.Naming)
+	}
+
+	return nil
+}
+
+// missingRequired returns the first required property of the schema the resource misses. The editors check the
+// rest of the schema with the schema command.
+func missingRequired(schema map[string]any, resource CustomResource) string {
+	required, _ := schema["required"].([]any)
+
+	for _, property := range required {
+		name, _ := property.(string)
+
+		if _, ok := resource[name]; !ok && name != "" {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// SchemaWithPlugins returns the JSON Schema of the configuration file with the schemas of the custom resources of
+// the registered resource types.
+func SchemaWithPlugins() ([]byte, error) {
+	defs := resources.RegisteredResourceTypes()
+	if len(defs) == 0 {
+		return Schema(), nil
+	}
+
+	var root map[string]any
+	if err := json.Unmarshal(Schema(), &root); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	properties := map[string]any{}
+
+	for _, def := range defs {
+		items := def.Schema
+		if items == nil {
+			items = map[string]any{"type": "object"}
+		}
+
+		properties[string(def.Type)] = map[string]any{"type": "array", "items": items}
+	}
+
+	rootProperties, _ := root["properties"].(map[string]any)
+	rootProperties[customResourcesKey] = map[string]any{
+		"description":          "The resources of the types of the plugins, by type.",
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+
+	data, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/resources"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestYAML_ParsePlugins(t *testing.T) {
+	tests := []struct {
+		name      string
+		fileName  string
+		targetErr error
+	}{
+		{name: "plugin and its custom resources", fileName: testdataFolder + "/custom.config.yaml"},
+		{
+			name:      "custom resources of a type without plugin",
+			fileName:  testdataFolder + "/custom.config.unknown.type.yaml",
+			targetErr: ErrInvalidPlugin,
+		},
+		{
+			name:      "custom resource missing a required property",
+			fileName:  testdataFolder + "/custom.config.missing.required.yaml",
+			targetErr: ErrInvalidPlugin,
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewYAML(tc.fileName).Parse()
+
+			require.ErrorIs(t, err, tc.targetErr)
+
+			if tc.targetErr != nil {
+				return
+			}
+
+			require.Equal(t, []string{filepath.Join(testdataFolder, "plugins", "ledger.plugin.yaml")}, got.Plugins)
+			require.Equal(t, []CustomResource{
+				{"name": "payments", "currency": "EUR"}, {"name": "refunds", "currency": "USD"},
+			}, got.CustomResources["ledger"])
+
+			def, ok := resources.LookupResourceType("ledger")
+			require.True(t, ok)
+			require.Equal(t, []string{"acme_ledger"}, def.Labels)
+			require.Equal(t, "ledger_name", def.NameAttribute)
+			require.Contains(t, def.Templates, "ledger.tf")
+
+			require.Equal(t, "payments_ledger", got.Naming.Label("ledger", "payments"))
+
+			resource, err := got.LookupResource("ledger", "refunds")
+			require.NoError(t, err)
+			require.Equal(t, &CustomResource{"name": "refunds", "currency": "USD"}, resource)
+		})
+	}
+}
+
+func TestSchemaWithPlugins(t *testing.T) {
+	_, err := NewYAML(testdataFolder + "/custom.config.yaml").Parse()
+	require.NoError(t, err)
+
+	data, err := SchemaWithPlugins()
+	require.NoError(t, err)
+
+	var schema struct {
+		Properties map[string]struct {
+			Properties map[string]struct {
+				Items struct {
+					Required []string `json:"required"`
+				} `json:"items"`
+			} `json:"properties"`
+		} `json:"properties"`
+	}
+
+	require.NoError(t, json.Unmarshal(data, &schema))
+	require.Equal(t, []string{"name", "currency"},
+		schema.Properties[customResourcesKey].Properties["ledger"].Items.Required)
+}
@@ -0,0 +1,60 @@
+package config
+
+import "strings"
+
+// RDS engines.
+const (
+	RDSEngineAuroraPostgreSQL = "aurora-postgresql"
+	RDSEngineAuroraMySQL      = "aurora-mysql"
+	RDSEnginePostgreSQL       = "postgres"
+	RDSEngineMySQL            = "mysql"
+)
+
+// RDSProxy represents the RDS Proxy placed in front of a database.
+type RDSProxy struct {
+	// IdleClientTimeout is in seconds.
+	IdleClientTimeout     int  `yaml:"idle_client_timeout,omitempty"`
+	RequireTLS            bool `yaml:"require_tls,omitempty"`
+	MaxConnectionsPercent int  `yaml:"max_connections_percent,omitempty"`
+}
+
+// RDS represents an Aurora cluster or an RDS instance. Aurora clusters use Serverless v2 unless an instance class is
+// set.
+type RDS struct {
+	Name string `yaml:"name"`
+	// Engine is aurora-postgresql, aurora-mysql, postgres or mysql. Defaults to aurora-postgresql.
+	Engine        string `yaml:"engine,omitempty"`
+	EngineVersion string `yaml:"engine_version,omitempty"`
+	InstanceClass string `yaml:"instance_class,omitempty"`
+	// Instances is the number of instances of an Aurora cluster. Defaults to 1.
+	Instances int `yaml:"instances,omitempty"`
+	// MinCapacity and MaxCapacity are the Aurora capacity units of a Serverless v2 cluster.
+	MinCapacity float64 `yaml:"min_capacity,omitempty"`
+	MaxCapacity float64 `yaml:"max_capacity,omitempty"`
+	// AllocatedStorage is the storage of an RDS instance in GB.
+	AllocatedStorage int    `yaml:"allocated_storage,omitempty"`
+	DatabaseName     string `yaml:"database_name,omitempty"`
+	MasterUsername   string `yaml:"master_username,omitempty"`
+	// Port defaults to the port of the engine.
+	Port int `yaml:"port,omitempty"`
+	// VPCID and SubnetIDs are Terraform expressions. They default to var.vpc_id and var.subnet_ids.
+	VPCID              string    `yaml:"vpc_id,omitempty"`
+	SubnetIDs          []string  `yaml:"subnet_ids,omitempty"`
+	DeletionProtection bool      `yaml:"deletion_protection,omitempty"`
+	Proxy              *RDSProxy `yaml:"proxy,omitempty"`
+	Files              []File    `yaml:"files,omitempty"`
+}
+
+func (r *RDS) GetName() string { return r.Name }
+
+// GetEngine returns the engine of the database. It defaults to aurora-postgresql.
+func (r *RDS) GetEngine() string {
+	if r.Engine == "" {
+		return RDSEngineAuroraPostgreSQL
+	}
+
+	return r.Engine
+}
+
+// IsAurora reports whether the database is an Aurora cluster.
+func (r *RDS) IsAurora() bool { return strings.HasPrefix(r.GetEngine(), "aurora") }
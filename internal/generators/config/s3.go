@@ -1,9 +1,74 @@
 package config
 
+// S3 encryption types.
+const (
+	S3EncryptionS3  = "s3"
+	S3EncryptionKMS = "kms"
+)
+
+// S3Encryption represents the default server-side encryption of an S3 bucket.
+type S3Encryption struct {
+	// Type is either "s3" (SSE-S3) or "kms" (SSE-KMS).
+	Type             string `yaml:"type"`
+	KMSKeyID         string `yaml:"kms_key_id,omitempty"`
+	BucketKeyEnabled bool   `yaml:"bucket_key_enabled,omitempty"`
+}
+
+// S3CORSRule represents a cross-origin resource sharing rule of an S3 bucket.
+type S3CORSRule struct {
+	AllowedHeaders []string `yaml:"allowed_headers,omitempty"`
+	AllowedMethods []string `yaml:"allowed_methods"`
+	AllowedOrigins []string `yaml:"allowed_origins"`
+	ExposeHeaders  []string `yaml:"expose_headers,omitempty"`
+	MaxAgeSeconds  int      `yaml:"max_age_seconds,omitempty"`
+}
+
+// S3LifecycleTransition represents the transition of objects to another storage class.
+type S3LifecycleTransition struct {
+	Days         int    `yaml:"days"`
+	StorageClass string `yaml:"storage_class"`
+}
+
+// S3LifecycleRule represents a lifecycle rule of an S3 bucket.
+type S3LifecycleRule struct {
+	ID                              string                  `yaml:"id"`
+	Prefix                          string                  `yaml:"prefix,omitempty"`
+	ExpirationDays                  int                     `yaml:"expiration_days,omitempty"`
+	NoncurrentVersionExpirationDays int                     `yaml:"noncurrent_version_expiration_days,omitempty"`
+	Transitions                     []S3LifecycleTransition `yaml:"transitions,omitempty"`
+}
+
+// S3Replication represents the replication of an S3 bucket to a second bucket.
+type S3Replication struct {
+	// DestinationBucket is the name of the destination bucket.
+	DestinationBucket string `yaml:"destination_bucket"`
+	StorageClass      string `yaml:"storage_class,omitempty"`
+}
+
+// S3Logging represents the access logging of an S3 bucket.
+type S3Logging struct {
+	// TargetBucket is the name of the bucket receiving the access logs.
+	TargetBucket string `yaml:"target_bucket"`
+	TargetPrefix string `yaml:"target_prefix,omitempty"`
+}
+
 type S3 struct {
-	Name           string `yaml:"name"`
-	ExpirationDays int    `yaml:"expiration-days,omitempty"`
-	Files          []File `yaml:"files,omitempty"`
+	Name              string            `yaml:"name"`
+	ExpirationDays    int               `yaml:"expiration-days,omitempty"`
+	Versioning        bool              `yaml:"versioning,omitempty"`
+	Encryption        *S3Encryption     `yaml:"encryption,omitempty"`
+	BlockPublicAccess *bool             `yaml:"block_public_access,omitempty"`
+	ObjectOwnership   string            `yaml:"object_ownership,omitempty"`
+	CORS              []S3CORSRule      `yaml:"cors,omitempty"`
+	LifecycleRules    []S3LifecycleRule `yaml:"lifecycle_rules,omitempty"`
+	Replication       *S3Replication    `yaml:"replication,omitempty"`
+	Logging           *S3Logging        `yaml:"logging,omitempty"`
+	// Tags are merged over the tags of the configuration.
+	Tags  map[string]string `yaml:"tags,omitempty"`
+	Files []File            `yaml:"files,omitempty"`
 }
 
 func (r *S3) GetName() string { return r.Name }
+
+// HasPublicAccessBlock reports whether the public access of the bucket should be blocked. It defaults to true.
+func (r *S3) HasPublicAccessBlock() bool { return r.BlockPublicAccess == nil || *r.BlockPublicAccess }
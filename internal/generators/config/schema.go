@@ -0,0 +1,195 @@
+package config
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ErrUndocumentedSchema = errors.New("undocumented schema")
+
+const schemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// schema is the JSON Schema of the configuration. TestSchema keeps it in sync with the structs.
+//
+//go:embed config.schema.json
+var schema []byte
+
+// Schema returns the JSON Schema of the configuration file.
+func Schema() []byte {
+	return schema
+}
+
+// varReference matches the strings that refer to a var, which may set the fields of any type.
+const varReference = `\$\{vars\.[\w-]+\}`
+
+// schemaGenerator generates the JSON Schema of the configuration from the structs and the yaml tags of their fields.
+type schemaGenerator struct {
+	// descriptions map the types and their fields, as <Type>.<yaml name>, to their documentation.
+	descriptions map[string]string
+	definitions  map[string]any
+	// documented holds the keys of the descriptions in use and undocumented the keys without a description.
+	documented   map[string]bool
+	undocumented []string
+}
+
+// generateSchema generates the JSON Schema of the Config struct. Every type and every field must have a description,
+// and every description must belong to a type or a field.
+func generateSchema(descriptions map[string]string) ([]byte, error) {
+	g := &schemaGenerator{descriptions: descriptions, definitions: map[string]any{}, documented: map[string]bool{}}
+
+	root := g.object(reflect.TypeOf(Config{}))
+	root["$schema"] = schemaDraft
+	root["title"] = "AWS terraform generator configuration"
+	root["definitions"] = g.definitions
+
+	properties, _ := root["properties"].(map[string]any)
+	properties[includeKey] = g.describe("Config."+includeKey, map[string]any{
+		"type":  "array",
+		"items": map[string]any{"type": "string"},
+	})
+	properties[fragmentsKey] = g.describe("Config."+fragmentsKey, map[string]any{})
+
+	for key := range descriptions {
+		if !g.documented[key] {
+			return nil, fmt.Errorf("%w: '%s' is neither a type nor a field", ErrUndocumentedSchema, key)
+		}
+	}
+
+	if len(g.undocumented) > 0 {
+		slices.Sort(g.undocumented)
+		g.undocumented = slices.Compact(g.undocumented)
+
+		return nil, fmt.Errorf("%w: %s", ErrUndocumentedSchema, strings.Join(g.undocumented, ", "))
+	}
+
+	data, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return append(data, '\n'), nil
+}
+
+func (g *schemaGenerator) typeSchema(t reflect.Type) map[string]any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.typeSchema(t.Elem())
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+
+		if _, ok := g.definitions[t.Name()]; !ok {
+			// Set first, so recursive types refer to the definition being generated.
+			g.definitions[t.Name()] = nil
+			g.definitions[t.Name()] = g.object(t)
+		}
+
+		return map[string]any{"$ref": "#/definitions/" + t.Name()}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.typeSchema(t.Elem())}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return orVarReference("boolean")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return orVarReference("integer")
+	case reflect.Float32, reflect.Float64:
+		return orVarReference("number")
+	default:
+		return map[string]any{}
+	}
+}
+
+// object returns the schema of the struct, with its fields as properties named by their yaml tags.
+func (g *schemaGenerator) object(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	g.addProperties(t, properties)
+
+	object := map[string]any{"type": "object", "properties": properties, "additionalProperties": false}
+	if t.Name() == "" {
+		return object
+	}
+
+	return g.describe(t.Name(), object)
+}
+
+// describe sets the description of the key in the schema. The $ref schemas are wrapped, since draft-07 ignores the
+// siblings of $ref.
+func (g *schemaGenerator) describe(key string, schema map[string]any) map[string]any {
+	description, ok := g.descriptions[key]
+	if !ok {
+		g.undocumented = append(g.undocumented, key)
+		return schema
+	}
+
+	g.documented[key] = true
+
+	if _, isRef := schema["$ref"]; isRef {
+		schema = map[string]any{"allOf": []any{schema}}
+	}
+
+	schema["description"] = description
+
+	return schema
+}
+
+func (g *schemaGenerator) addProperties(t reflect.Type, properties map[string]any) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if strings.Contains(options, "inline") {
+			g.addProperties(field.Type, properties)
+			continue
+		}
+
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		properties[name] = g.describe(t.Name()+"."+name, g.typeSchema(field.Type))
+	}
+}
+
+// orVarReference returns the schema of the type, which also accepts a reference to a var.
+func orVarReference(typeName string) map[string]any {
+	return map[string]any{"anyOf": []any{
+		map[string]any{"type": typeName},
+		map[string]any{"type": "string", "pattern": varReference},
+	}}
+}
+
+// schemaDescriptions reads the descriptions of the types and their fields, written for the people writing the
+// configuration files.
+func schemaDescriptions(fileName string) (map[string]string, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	descriptions := map[string]string{}
+	if err := yaml.Unmarshal(data, &descriptions); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return descriptions, nil
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestSchema checks the JSON Schema is in sync with the structs. Run it with UPDATE_SCHEMA=1 to regenerate the schema.
+func TestSchema(t *testing.T) {
+	descriptions, err := schemaDescriptions("config.schema.descriptions.yaml")
+	require.NoError(t, err)
+
+	got, err := generateSchema(descriptions)
+	require.NoError(t, err)
+
+	if os.Getenv("UPDATE_SCHEMA") != "" {
+		require.NoError(t, os.WriteFile("config.schema.json", got, os.ModePerm))
+		return
+	}
+
+	require.Equal(t, string(Schema()), string(got), "the schema is out of date, run the test with UPDATE_SCHEMA=1")
+}
+
+func TestSchema_Properties(t *testing.T) {
+	var schema struct {
+		Properties  map[string]map[string]any `json:"properties"`
+		Definitions map[string]struct {
+			Properties map[string]map[string]any `json:"properties"`
+		} `json:"definitions"`
+	}
+
+	require.NoError(t, json.Unmarshal(Schema(), &schema))
+
+	tests := []struct {
+		name       string
+		properties map[string]map[string]any
+		property   string
+		want       map[string]any
+	}{
+		{
+			name:       "list of resources",
+			properties: schema.Properties,
+			property:   "sqs",
+			want: map[string]any{
+				"type":        "array",
+				"items":       map[string]any{"$ref": "#/definitions/SQS"},
+				"description": "SQS queues.",
+			},
+		},
+		{
+			name:       "documented field",
+			properties: schema.Properties,
+			property:   "required_tags",
+			want: map[string]any{
+				"type":        "array",
+				"items":       map[string]any{"type": "string"},
+				"description": "Tag keys every taggable resource must have. The generation fails when one is missing.",
+			},
+		},
+		{
+			name:       "number that can refer to a var",
+			properties: schema.Definitions["SQS"].Properties,
+			property:   "max_receive_count",
+			want: map[string]any{
+				"anyOf": []any{
+					map[string]any{"type": "integer"},
+					map[string]any{"type": "string", "pattern": varReference},
+				},
+				"description": "Number of times a message is received before it is moved to the dead-letter queue.",
+			},
+		},
+		{
+			name:       "inlined fields",
+			properties: schema.Definitions["LambdaDefaults"].Properties,
+			property:   "memory_size",
+			want: map[string]any{
+				"anyOf": []any{
+					map[string]any{"type": "integer"},
+					map[string]any{"type": "string", "pattern": varReference},
+				},
+				"description": "Memory in MB.",
+			},
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.properties[tc.property])
+		})
+	}
+}
+
+func TestSchema_Descriptions(t *testing.T) {
+	descriptions, err := schemaDescriptions("config.schema.descriptions.yaml")
+	require.NoError(t, err)
+
+	undocumented := maps.Clone(descriptions)
+	delete(undocumented, "SQS.max_receive_count")
+
+	unknown := maps.Clone(descriptions)
+	unknown["SQS.max_receive_counts"] = "Typo of a field."
+
+	tests := []struct {
+		name         string
+		descriptions map[string]string
+		expectedErr  string
+	}{
+		{
+			name:         "undocumented field",
+			descriptions: undocumented,
+			expectedErr:  "undocumented schema: SQS.max_receive_count",
+		},
+		{
+			name:         "description of an unknown field",
+			descriptions: unknown,
+			expectedErr:  "undocumented schema: 'SQS.max_receive_counts' is neither a type nor a field",
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := generateSchema(tc.descriptions)
+			require.ErrorIs(t, err, ErrUndocumentedSchema)
+			require.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
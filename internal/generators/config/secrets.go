@@ -0,0 +1,26 @@
+package config
+
+// Secret storages.
+const (
+	SecretTypeSecretsManager = "secretsmanager"
+	SecretTypeSSM            = "ssm"
+)
+
+// Secret represents a sensitive value read by the lambdas at cold start. The value itself is never part of the
+// configuration; it is set out of band once the secret exists.
+type Secret struct {
+	Name string `yaml:"name"`
+	// Type is secretsmanager or ssm. Defaults to secretsmanager.
+	Type        string `yaml:"type,omitempty"`
+	Description string `yaml:"description,omitempty"`
+	// KMSKeyID is a Terraform expression of the customer managed key ARN that encrypts the secret.
+	KMSKeyID string `yaml:"kms_key_id,omitempty"`
+	// RecoveryWindowInDays is a pointer because zero is valid and deletes the secret without recovery.
+	RecoveryWindowInDays *int   `yaml:"recovery_window_in_days,omitempty"`
+	Files                []File `yaml:"files,omitempty"`
+}
+
+func (r *Secret) GetName() string { return r.Name }
+
+// IsSSM reports whether the secret is stored as an SSM SecureString parameter.
+func (r *Secret) IsSSM() bool { return r.Type == SecretTypeSSM }
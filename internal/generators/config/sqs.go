@@ -1,9 +1,38 @@
 package config
 
+// SQS encryption types.
+const (
+	SQSEncryptionSQS = "sqs"
+	SQSEncryptionKMS = "kms"
+)
+
+// SQSEncryption represents the server-side encryption of an SQS queue.
+type SQSEncryption struct {
+	// Type is either "sqs" (SSE-SQS) or "kms" (SSE-KMS).
+	Type                         string `yaml:"type"`
+	KMSKeyID                     string `yaml:"kms_key_id,omitempty"`
+	KMSDataKeyReusePeriodSeconds int    `yaml:"kms_data_key_reuse_period_seconds,omitempty"`
+}
+
 type SQS struct {
-	Name            string `yaml:"name"`
-	MaxReceiveCount int32  `yaml:"max_receive_count"`
-	Files           []File `yaml:"files,omitempty"`
+	Name                      string         `yaml:"name"`
+	MaxReceiveCount           int32          `yaml:"max_receive_count"`
+	FIFO                      bool           `yaml:"fifo,omitempty"`
+	ContentBasedDeduplication bool           `yaml:"content_based_deduplication,omitempty"`
+	Encryption                *SQSEncryption `yaml:"encryption,omitempty"`
+	MessageRetentionSeconds   int            `yaml:"message_retention_seconds,omitempty"`
+	DelaySeconds              int            `yaml:"delay_seconds,omitempty"`
+	VisibilityTimeoutSeconds  int            `yaml:"visibility_timeout_seconds,omitempty"`
+	ReceiveWaitTimeSeconds    int            `yaml:"receive_wait_time_seconds,omitempty"`
+	DLQ                       *bool          `yaml:"dlq,omitempty"`
+	// Alarms overrides the thresholds of the observability section.
+	Alarms *SQSAlarms `yaml:"alarms,omitempty"`
+	// Tags are merged over the tags of the configuration.
+	Tags  map[string]string `yaml:"tags,omitempty"`
+	Files []File            `yaml:"files,omitempty"`
 }
 
 func (r *SQS) GetName() string { return r.Name }
+
+// HasDLQ reports whether a dead-letter queue should be created for the queue. It defaults to true.
+func (r *SQS) HasDLQ() bool { return r.DLQ == nil || *r.DLQ }
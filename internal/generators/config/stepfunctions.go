@@ -0,0 +1,52 @@
+package config
+
+// StepFunctionRetry represents how a task state is retried when it fails.
+type StepFunctionRetry struct {
+	// Errors lists the error names that are retried. Defaults to States.ALL.
+	Errors          []string `yaml:"errors,omitempty"`
+	IntervalSeconds int      `yaml:"interval_seconds,omitempty"`
+	MaxAttempts     int      `yaml:"max_attempts,omitempty"`
+	BackoffRate     float64  `yaml:"backoff_rate,omitempty"`
+}
+
+// StepFunctionState represents a state of a Step Functions state machine.
+type StepFunctionState struct {
+	Name string `yaml:"name"`
+	// Type is the Amazon States Language state type: Task, Pass, Wait, Succeed or Fail. Defaults to Task.
+	Type string `yaml:"type,omitempty"`
+	// Lambda is the name of the configured Lambda invoked by the task.
+	Lambda string `yaml:"lambda,omitempty"`
+	// SQS is the name of the configured queue the task sends the state input to.
+	SQS string `yaml:"sqs,omitempty"`
+	// Resource is the ARN of any other task integration. For example: arn:aws:states:::dynamodb:putItem
+	Resource string `yaml:"resource,omitempty"`
+	// Next is the name of the following state. The state ends the execution when it is empty.
+	Next    string             `yaml:"next,omitempty"`
+	Seconds int                `yaml:"seconds,omitempty"`
+	Error   string             `yaml:"error,omitempty"`
+	Cause   string             `yaml:"cause,omitempty"`
+	Retry   *StepFunctionRetry `yaml:"retry,omitempty"`
+}
+
+// StepFunctionLogging represents where and how much of the executions is logged.
+type StepFunctionLogging struct {
+	// Level is one of ALL, ERROR, FATAL or OFF. Defaults to ERROR.
+	Level                string `yaml:"level,omitempty"`
+	IncludeExecutionData bool   `yaml:"include_execution_data,omitempty"`
+	RetentionInDays      int    `yaml:"retention_in_days,omitempty"`
+}
+
+// StepFunction represents a Step Functions state machine.
+type StepFunction struct {
+	Name string `yaml:"name"`
+	// Type is STANDARD or EXPRESS. Defaults to STANDARD.
+	Type    string `yaml:"type,omitempty"`
+	Comment string `yaml:"comment,omitempty"`
+	// StartAt is the name of the first state. Defaults to the first state of the list.
+	StartAt string               `yaml:"start_at,omitempty"`
+	States  []StepFunctionState  `yaml:"states,omitempty"`
+	Logging *StepFunctionLogging `yaml:"logging,omitempty"`
+	Files   []File               `yaml:"files,omitempty"`
+}
+
+func (r *StepFunction) GetName() string { return r.Name }
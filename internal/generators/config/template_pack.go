@@ -0,0 +1,210 @@
+package config
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+)
+
+var ErrInvalidTemplatePack = errors.New("invalid template pack")
+
+const (
+	templatePackKey = "template_pack"
+	// partialsFolder is the folder of the partials in a template pack.
+	partialsFolder = "partials"
+	// TemplatesFolder is the folder of the templates of a generator, in the source tree and in a template pack.
+	TemplatesFolder = "tmpls"
+	// TemplateExtension is the extension of the files of a template pack, appended to the file name of the template.
+	TemplateExtension = ".tmpl"
+)
+
+// WithTemplatePack sets the template pack, such as the one of the --templates flag, which takes precedence over the
+// template pack of the configuration.
+func WithTemplatePack(packPath string) YAMLOption {
+	return func(y *YAML) {
+		y.templatePack = packPath
+	}
+}
+
+// templateGenerators returns the templates of the generators by the name of their folder in a template pack, which
+// is the name of their package.
+func (c *Config) templateGenerators() map[string]*[]FilenameTemplateMap {
+	o := &c.OverrideDefaultTemplates
+
+	return map[string]*[]FilenameTemplateMap{
+		"apigateway":    &o.APIGateway,
+		"eventbridge":   &o.EventBridge,
+		"firehose":      &o.Firehose,
+		"kinesis":       &o.Kinesis,
+		"lambda":        &o.Lambda,
+		"observability": &o.Observability,
+		"rds":           &o.RDS,
+		"s3":            &o.S3Bucket,
+		"secrets":       &o.Secrets,
+		"sns":           &o.SNS,
+		"sqs":           &o.SQS,
+		"stepfunctions": &o.StepFunctions,
+		"structure":     &c.Structure.DefaultTemplates,
+	}
+}
+
+// applyTemplatePack loads the templates of the template pack ahead of the inline templates of the configuration, so
+// the inline ones take precedence. The pack of packOverride replaces the one of the configuration.
+func (c *Config) applyTemplatePack(packOverride string) error {
+	packPath := packOverride
+	if packPath == "" {
+		packPath = c.TemplatePack
+	}
+
+	if packPath == "" {
+		return nil
+	}
+
+	files, err := readTemplatePack(packPath)
+	if err != nil {
+		return fmt.Errorf("%w: '%s': %w", ErrInvalidTemplatePack, packPath, err)
+	}
+
+	generators := c.templateGenerators()
+	templates := map[string]FilenameTemplateMap{}
+
+	for name, tmpl := range files {
+		folder := path.Dir(name)
+		filename := strings.TrimSuffix(path.Base(name), TemplateExtension)
+
+		if path.Base(folder) == partialsFolder {
+			c.addPackPartial(filename, tmpl)
+			continue
+		}
+
+		// The templates of a generator mirror its tmpls folder in the source tree, such as sqs/tmpls/sqs.tf.tmpl.
+		if path.Base(folder) != TemplatesFolder {
+			return fmt.Errorf("%w: '%s': '%s' is not in <generator>/%s", ErrInvalidTemplatePack, packPath, name,
+				TemplatesFolder)
+		}
+
+		generator := path.Base(path.Dir(folder))
+
+		if _, ok := generators[generator]; !ok {
+			return fmt.Errorf("%w: '%s': '%s' is not a generator", ErrInvalidTemplatePack, packPath, generator)
+		}
+
+		if templates[generator] == nil {
+			templates[generator] = FilenameTemplateMap{}
+		}
+
+		templates[generator][filename] = tmpl
+	}
+
+	for generator, tmpls := range templates {
+		list := generators[generator]
+		*list = append([]FilenameTemplateMap{tmpls}, *list...)
+	}
+
+	return nil
+}
+
+// addPackPartial adds the partial of the template pack, unless the configuration defines it.
+func (c *Config) addPackPartial(name, tmpl string) {
+	if c.Partials == nil {
+		c.Partials = map[string]string{}
+	}
+
+	if _, ok := c.Partials[name]; !ok {
+		c.Partials[name] = tmpl
+	}
+}
+
+// readTemplatePack reads the templates of the template pack, which is a folder, a zip archive or a gzipped tar
+// archive. It returns the templates by their path in the pack.
+func readTemplatePack(packPath string) (map[string]string, error) {
+	switch {
+	case strings.HasSuffix(packPath, ".zip"):
+		reader, err := zip.OpenReader(packPath)
+		if err != nil {
+			return nil, fmt.Errorf("%w", err)
+		}
+
+		defer reader.Close()
+
+		return readTemplateFS(reader)
+	case strings.HasSuffix(packPath, ".tar.gz"), strings.HasSuffix(packPath, ".tgz"):
+		return readTemplateTarGz(packPath)
+	default:
+		return readTemplateFS(os.DirFS(packPath))
+	}
+}
+
+func readTemplateFS(fsys fs.FS) (map[string]string, error) {
+	files := map[string]string{}
+
+	err := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() || !strings.HasSuffix(name, TemplateExtension) {
+			return nil
+		}
+
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+
+		files[name] = string(data)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return files, nil
+}
+
+func readTemplateTarGz(packPath string) (map[string]string, error) {
+	file, err := os.Open(packPath)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	files := map[string]string{}
+	reader := tar.NewReader(gzipReader)
+
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg || !strings.HasSuffix(header.Name, TemplateExtension) {
+			continue
+		}
+
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("%w", err)
+		}
+
+		files[path.Clean(header.Name)] = string(data)
+	}
+}
@@ -0,0 +1,176 @@
+package config
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	packSQSTemplate       = "resource \"aws_sqs_queue\" \"{{ToSnake $.Name}}_pack\" {}\n"
+	packStructureTemplate = "locals {\n  stack_name = \"{{$.StackName}}\"\n}\n"
+)
+
+func TestYAML_ParseTemplatePack(t *testing.T) {
+	inlineSQSTemplate := FilenameTemplateMap{"sqs.tf": `resource "aws_sqs_queue" "{{ToSnake $.Name}}_inline" {}`}
+	packPath := filepath.Join(testdataFolder, "templatepack")
+
+	tests := []struct {
+		name          string
+		fileName      string
+		override      string
+		want          OverrideDefaultTemplates
+		wantPartials  map[string]string
+		wantStructure []FilenameTemplateMap
+		targetErr     error
+	}{
+		{
+			name:          "pack of the config before the inline templates",
+			fileName:      testdataFolder + "/templatepack.config.yaml",
+			want:          OverrideDefaultTemplates{SQS: []FilenameTemplateMap{{"sqs.tf": packSQSTemplate}, inlineSQSTemplate}},
+			wantPartials:  map[string]string{"name": "{{ToSnake .}}_inline", "tags": "tags = {{$.Tags}}"},
+			wantStructure: []FilenameTemplateMap{{"main.tf": packStructureTemplate}},
+		},
+		{
+			name:         "pack of the flag",
+			fileName:     testdataFolder + "/sqs.config.yaml",
+			override:     packPath,
+			want:         OverrideDefaultTemplates{SQS: []FilenameTemplateMap{{"sqs.tf": packSQSTemplate}}},
+			wantPartials: map[string]string{"name": "{{ToSnake .}}_pack", "tags": "tags = {{$.Tags}}"},
+		},
+		{
+			name:     "zip archive",
+			fileName: testdataFolder + "/sqs.config.yaml",
+			override: writeZipPack(t, packPath),
+			want:     OverrideDefaultTemplates{SQS: []FilenameTemplateMap{{"sqs.tf": packSQSTemplate}}},
+		},
+		{
+			name:     "gzipped tar archive",
+			fileName: testdataFolder + "/sqs.config.yaml",
+			override: writeTarGzPack(t, packPath),
+			want:     OverrideDefaultTemplates{SQS: []FilenameTemplateMap{{"sqs.tf": packSQSTemplate}}},
+		},
+		{
+			name:      "unknown generator",
+			fileName:  testdataFolder + "/templatepack.config.invalid.yaml",
+			targetErr: ErrInvalidTemplatePack,
+		},
+		{
+			name:      "templates outside of the tmpls folder of the generator",
+			fileName:  testdataFolder + "/sqs.config.yaml",
+			override:  filepath.Join(testdataFolder, "templatepack.flat"),
+			targetErr: ErrInvalidTemplatePack,
+		},
+		{
+			name:      "missing pack",
+			fileName:  testdataFolder + "/sqs.config.yaml",
+			override:  filepath.Join(testdataFolder, "missing"),
+			targetErr: ErrInvalidTemplatePack,
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewYAML(tc.fileName, WithTemplatePack(tc.override)).Parse()
+
+			require.ErrorIs(t, err, tc.targetErr)
+
+			if tc.targetErr == nil {
+				require.Equal(t, tc.want, got.OverrideDefaultTemplates)
+
+				if tc.wantPartials != nil {
+					require.Equal(t, tc.wantPartials, got.Partials)
+				}
+
+				if tc.wantStructure != nil {
+					require.Equal(t, tc.wantStructure, got.Structure.DefaultTemplates)
+				}
+			}
+		})
+	}
+}
+
+// packFiles returns the files of the pack by their path in an archive, which usually has the pack in a root folder.
+func packFiles(tb testing.TB, packPath string) map[string][]byte {
+	tb.Helper()
+
+	files := map[string][]byte{}
+
+	err := filepath.WalkDir(packPath, func(name string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(packPath, name)
+		if err != nil {
+			return err
+		}
+
+		data, err := os.ReadFile(name)
+		files["pack/"+filepath.ToSlash(rel)] = data
+
+		return err
+	})
+	require.NoError(tb, err)
+
+	return files
+}
+
+func writeZipPack(tb testing.TB, packPath string) string {
+	tb.Helper()
+
+	archivePath := filepath.Join(tb.TempDir(), "pack.zip")
+
+	file, err := os.Create(archivePath)
+	require.NoError(tb, err)
+
+	defer file.Close()
+
+	writer := zip.NewWriter(file)
+
+	for name, data := range packFiles(tb, packPath) {
+		fileWriter, err := writer.Create(name)
+		require.NoError(tb, err)
+
+		_, err = fileWriter.Write(data)
+		require.NoError(tb, err)
+	}
+
+	require.NoError(tb, writer.Close())
+
+	return archivePath
+}
+
+func writeTarGzPack(tb testing.TB, packPath string) string {
+	tb.Helper()
+
+	archivePath := filepath.Join(tb.TempDir(), "pack.tar.gz")
+
+	file, err := os.Create(archivePath)
+	require.NoError(tb, err)
+
+	defer file.Close()
+
+	gzipWriter := gzip.NewWriter(file)
+	writer := tar.NewWriter(gzipWriter)
+
+	for name, data := range packFiles(tb, packPath) {
+		header := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), Typeflag: tar.TypeReg}
+		require.NoError(tb, writer.WriteHeader(header))
+
+		_, err = writer.Write(data)
+		require.NoError(tb, err)
+	}
+
+	require.NoError(tb, writer.Close())
+	require.NoError(tb, gzipWriter.Close())
+
+	return archivePath
+}
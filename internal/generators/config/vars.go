@@ -0,0 +1,196 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrInvalidVar   = errors.New("invalid var")
+	ErrUndefinedVar = errors.New("undefined var")
+)
+
+const (
+	varsKey = "vars"
+	// VarEnvPrefix is the prefix of the environment variables that override the vars.
+	VarEnvPrefix = "AWS_TERRAFORM_GENERATOR_VAR_"
+)
+
+// varPattern matches the references to the vars, such as ${vars.domain}. The references escaped as $${vars.domain}
+// are kept as ${vars.domain}.
+var varPattern = regexp.MustCompile(`\$?\$\{vars\.([\w-]+)\}`)
+
+var osLookupEnv = os.LookupEnv
+
+// WithVarOverrides sets the values that override the vars of the configuration, such as the ones of the --var flags.
+// They take precedence over the environment variables.
+func WithVarOverrides(overrides map[string]string) YAMLOption {
+	return func(y *YAML) {
+		y.varOverrides = overrides
+	}
+}
+
+// ParseVarOverrides parses the overrides given as key=value.
+func ParseVarOverrides(values []string) (map[string]string, error) {
+	overrides := make(map[string]string, len(values))
+
+	for _, value := range values {
+		key, val, ok := strings.Cut(value, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("%w: '%s' must be key=value", ErrInvalidVar, value)
+		}
+
+		overrides[key] = val
+	}
+
+	return overrides, nil
+}
+
+// varResolver resolves the values of the vars. The --var overrides come first, then the environment variables and
+// last the vars of the configuration. A var can refer to other vars.
+type varResolver struct {
+	overrides map[string]string
+	declared  map[string]string
+	resolved  map[string]string
+	resolving map[string]bool
+}
+
+func newVarResolver(overrides map[string]string) *varResolver {
+	return &varResolver{
+		overrides: overrides, declared: map[string]string{}, resolved: map[string]string{}, resolving: map[string]bool{},
+	}
+}
+
+func (r *varResolver) value(name string) (string, error) {
+	if value, ok := r.resolved[name]; ok {
+		return value, nil
+	}
+
+	if r.resolving[name] {
+		return "", fmt.Errorf("%w: '%s' refers to itself", ErrInvalidVar, name)
+	}
+
+	value, ok := r.overrides[name]
+	if !ok {
+		value, ok = osLookupEnv(VarEnvPrefix + name)
+	}
+
+	if !ok {
+		value, ok = r.declared[name]
+	}
+
+	if !ok {
+		return "", fmt.Errorf("%w: '%s'", ErrUndefinedVar, name)
+	}
+
+	r.resolving[name] = true
+	defer delete(r.resolving, name)
+
+	value, err := r.interpolate(value)
+	if err != nil {
+		return "", err
+	}
+
+	r.resolved[name] = value
+
+	return value, nil
+}
+
+// interpolate replaces the references to the vars in the text.
+func (r *varResolver) interpolate(text string) (string, error) {
+	var err error
+
+	result := varPattern.ReplaceAllStringFunc(text, func(match string) string {
+		if strings.HasPrefix(match, "$$") {
+			return match[1:]
+		}
+
+		value, valueErr := r.value(varPattern.FindStringSubmatch(match)[1])
+		if valueErr != nil && err == nil {
+			err = valueErr
+		}
+
+		return value
+	})
+
+	return result, err
+}
+
+// interpolateVars reads the vars of the configuration into the resolver and replaces the references to the vars in
+// every string of the configuration. The resolver is kept for the files read later.
+func (d *document) interpolateVars(resolver *varResolver, root *yaml.Node) error {
+	if index := mappingIndex(root, varsKey); index >= 0 {
+		if err := root.Content[index+1].Decode(&resolver.declared); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrInvalidVar, d.location(root.Content[index].Line), err)
+		}
+	}
+
+	return d.interpolateNode(resolver, root, map[*yaml.Node]bool{})
+}
+
+// vars returns the values of the vars of the configuration and of the overrides.
+func (r *varResolver) vars() (map[string]string, error) {
+	if len(r.declared) == 0 && len(r.overrides) == 0 {
+		return nil, nil
+	}
+
+	vars := make(map[string]string, len(r.declared)+len(r.overrides))
+
+	for _, names := range []map[string]string{r.declared, r.overrides} {
+		for name := range names {
+			value, err := r.value(name)
+			if err != nil {
+				return nil, err
+			}
+
+			vars[name] = value
+		}
+	}
+
+	return vars, nil
+}
+
+func (d *document) interpolateNode(resolver *varResolver, node *yaml.Node, visited map[*yaml.Node]bool) error {
+	if visited[node] {
+		return nil
+	}
+
+	visited[node] = true
+
+	if node.Kind == yaml.ScalarNode {
+		if !strings.Contains(node.Value, "${vars.") {
+			return nil
+		}
+
+		value, err := resolver.interpolate(node.Value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", d.location(node.Line), err)
+		}
+
+		node.Value = value
+
+		// Lets the plain scalars take the type of their new value, so vars can set numbers and booleans too.
+		if node.Style == 0 {
+			node.Tag = ""
+		}
+
+		return nil
+	}
+
+	for _, child := range node.Content {
+		if err := d.interpolateNode(resolver, child, visited); err != nil {
+			return err
+		}
+	}
+
+	if node.Alias != nil {
+		return d.interpolateNode(resolver, node.Alias, visited)
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVarOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		values    []string
+		want      map[string]string
+		targetErr error
+	}{
+		{
+			name:   "key=value",
+			values: []string{"domain=example.com", "filter=a=b", "empty="},
+			want:   map[string]string{"domain": "example.com", "filter": "a=b", "empty": ""},
+		},
+		{
+			name:      "missing value",
+			values:    []string{"domain"},
+			targetErr: ErrInvalidVar,
+		},
+		{
+			name:      "missing key",
+			values:    []string{"=example.com"},
+			targetErr: ErrInvalidVar,
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseVarOverrides(tc.values)
+
+			require.ErrorIs(t, err, tc.targetErr)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestYAML_ParseVars(t *testing.T) {
+	type fields struct {
+		fileName string
+	}
+
+	tests := []struct {
+		name      string
+		fields    fields
+		overrides map[string]string
+		env       map[string]string
+		want      *Config
+		targetErr error
+	}{
+		{
+			name:   "vars of the config",
+			fields: fields{fileName: testdataFolder + "/vars.config.yaml"},
+			want: &Config{
+				LambdaDefaults: LambdaDefaults{LambdaSettings: LambdaSettings{MemorySize: 512}},
+				APIGateways: []APIGateway{{
+					StackName: "teststack",
+					APIDomain: "api.example.com",
+					Lambdas: []APIGatewayLambda{{
+						Name:   "receiver",
+						Envars: map[string]string{"ACCOUNT": "123456789012", "TEMPLATE": "${vars.account}"},
+					}},
+				}},
+				Draw: Draw{ReplaceableTexts: ReplaceableTexts{"example.com": ""}},
+				Vars: map[string]string{
+					"account": "123456789012", "domain": "example.com", "api_domain": "api.example.com",
+					"memory_size": "512",
+				},
+			},
+		},
+		{
+			name:      "overrides and environment variables",
+			fields:    fields{fileName: testdataFolder + "/vars.config.yaml"},
+			overrides: map[string]string{"domain": "override.com", "region": "eu-west-1"},
+			env:       map[string]string{VarEnvPrefix + "domain": "env.com", VarEnvPrefix + "memory_size": "1024"},
+			want: &Config{
+				LambdaDefaults: LambdaDefaults{LambdaSettings: LambdaSettings{MemorySize: 1024}},
+				APIGateways: []APIGateway{{
+					StackName: "teststack",
+					APIDomain: "api.override.com",
+					Lambdas: []APIGatewayLambda{{
+						Name:   "receiver",
+						Envars: map[string]string{"ACCOUNT": "123456789012", "TEMPLATE": "${vars.account}"},
+					}},
+				}},
+				Draw: Draw{ReplaceableTexts: ReplaceableTexts{"override.com": ""}},
+				Vars: map[string]string{
+					"account": "123456789012", "domain": "override.com", "api_domain": "api.override.com",
+					"memory_size": "1024", "region": "eu-west-1",
+				},
+			},
+		},
+		{
+			name:      "undefined var",
+			fields:    fields{fileName: testdataFolder + "/vars.config.undefined.yaml"},
+			targetErr: ErrUndefinedVar,
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			got, err := NewYAML(tc.fields.fileName, WithVarOverrides(tc.overrides)).Parse()
+
+			require.ErrorIs(t, err, tc.targetErr)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -8,28 +8,85 @@ import (
 )
 
 var (
-	osReadFile    = os.ReadFile
-	yamlUnmarshal = yaml.Unmarshal
+	osReadFile          = os.ReadFile
+	yamlUnmarshal       = yaml.Unmarshal
+	yamlDecodeDocuments = decodeDocuments
 )
 
 type YAML struct {
-	fileName string
+	fileNames    []string
+	varOverrides map[string]string
+	templatePack string
 }
 
-func NewYAML(fileName string) *YAML {
-	return &YAML{fileName: fileName}
+// YAMLOption configures the parser of the configuration files.
+type YAMLOption func(*YAML)
+
+// NewYAML creates the parser of the configuration file.
+func NewYAML(fileName string, opts ...YAMLOption) *YAML {
+	return NewYAMLFiles([]string{fileName}, opts...)
+}
+
+// NewYAMLFiles creates the parser of the configuration files, which are composed in order as if a single file
+// included them.
+func NewYAMLFiles(fileNames []string, opts ...YAMLOption) *YAML {
+	y := &YAML{fileNames: fileNames}
+
+	for _, opt := range opts {
+		opt(y)
+	}
+
+	return y
 }
 
+// Parse composes the configuration files and registers the resource types of their plugins. The resource types are
+// registered globally, in place of the ones of the configuration parsed before, which are unregistered.
 func (y *YAML) Parse() (*Config, error) {
-	yamlFile, err := osReadFile(y.fileName)
+	fileNames := y.fileNames
+	if len(fileNames) == 0 {
+		fileNames = []string{""}
+	}
+
+	doc, err := loadDocuments(fileNames)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	root, err := doc.compose()
 	if err != nil {
-		return nil, fmt.Errorf("read YAML file error: %w", err)
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	resolver := newVarResolver(y.varOverrides)
+
+	if err := doc.interpolateVars(resolver, root); err != nil {
+		return nil, fmt.Errorf("%w", err)
 	}
 
 	var config Config
-	if err := yamlUnmarshal(yamlFile, &config); err != nil {
+	if err := root.Decode(&config); err != nil {
 		return nil, fmt.Errorf("unmarshal YAML file error: %w", err)
 	}
 
+	if config.Vars, err = resolver.vars(); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	if err := config.loadPlugins(); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	if err := config.applyTemplatePack(y.templatePack); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	if err := config.Naming.Validate(); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	if err := config.loadEnvironmentFiles(resolver); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
 	return &config, nil
 }
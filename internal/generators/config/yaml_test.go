@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"os"
+	"path"
 	"testing"
 
 	"gopkg.in/yaml.v3"
@@ -17,8 +18,13 @@ var (
 )
 
 func TestYAML_Parse(t *testing.T) {
+	zero := 0
+
+	includedSQSFiles := []File{{Name: "queue-sqs.tf", Tmpl: `resource "aws_sqs_queue" "{{ToSnake $.Name}}_sqs" {}`}}
+
 	type fields struct {
-		fileName string
+		fileName  string
+		fileNames []string
 	}
 
 	tests := []struct {
@@ -86,6 +92,45 @@ func TestYAML_Parse(t *testing.T) {
 				}},
 			}}},
 		},
+		{
+			setup:  func(_ testing.TB) func(testing.TB) { return func(_ testing.TB) {} },
+			name:   "Lambda runtime settings",
+			fields: fields{fileName: testdataFolder + "/lambda.config.settings.yaml"},
+			want: &Config{
+				LambdaDefaults: LambdaDefaults{
+					RoleName: "execute_lambda",
+					Runtime:  "provided.al2023",
+					LambdaSettings: LambdaSettings{
+						MemorySize:   256,
+						Timeout:      30,
+						Architecture: "arm64",
+						VPC: &LambdaVPC{
+							SubnetIDs:        []string{"var.private_subnet_a"},
+							SecurityGroupIDs: []string{"var.lambda_security_group_id"},
+						},
+					},
+				},
+				Lambdas: []Lambda{
+					{
+						Name:        "exampleWorker",
+						Source:      "./lambda/exampleWorker",
+						Description: "Worker with its own runtime settings",
+						LambdaSettings: LambdaSettings{
+							MemorySize:             1024,
+							EphemeralStorage:       2048,
+							Layers:                 []string{"aws_lambda_layer_version.common.arn"},
+							ReservedConcurrency:    &zero,
+							ProvisionedConcurrency: 2,
+						},
+					},
+					{
+						Name:        "exampleModule",
+						Source:      "git@github.com:username/terraform-aws-lambda?ref=reference",
+						Description: "Module inheriting the lambda defaults",
+					},
+				},
+			},
+		},
 		{
 			setup:  func(_ testing.TB) func(testing.TB) { return func(_ testing.TB) {} },
 			name:   "RestfulAPI",
@@ -198,17 +243,131 @@ func TestYAML_Parse(t *testing.T) {
 		},
 		{
 			setup: func(_ testing.TB) func(testing.TB) {
-				yamlUnmarshal = func(_ []byte, _ any) error {
-					return errDummy
+				yamlDecodeDocuments = func(_ []byte) ([]*yaml.Node, error) {
+					return nil, errDummy
 				}
 				return func(_ testing.TB) {
-					yamlUnmarshal = yaml.Unmarshal
+					yamlDecodeDocuments = decodeDocuments
 				}
 			},
 			name:      "Invalid YAML Syntax",
 			fields:    fields{fileName: testdataFolder + "/invalid_sintax.yaml"},
 			targetErr: errDummy,
 		},
+		{
+			setup:  func(_ testing.TB) func(testing.TB) { return func(_ testing.TB) {} },
+			name:   "Environments",
+			fields: fields{fileName: testdataFolder + "/sqs.config.environments.yaml"},
+			want: &Config{
+				SQSs: []SQS{{Name: "orders", MaxReceiveCount: 10}, {Name: "payments", MaxReceiveCount: 5}},
+				Environments: map[string]any{
+					"dev": map[string]any{},
+					"uat": map[string]any{
+						"sqs": []any{map[string]any{"name": "orders", "message_retention_seconds": 345600}},
+					},
+					"prd": map[string]any{
+						"sqs": []any{map[string]any{
+							"name": "orders", "message_retention_seconds": 1209600, "visibility_timeout_seconds": 900,
+						}},
+					},
+				},
+			},
+		},
+		{
+			setup:     func(_ testing.TB) func(testing.TB) { return func(_ testing.TB) {} },
+			name:      "Invalid environment",
+			fields:    fields{fileName: testdataFolder + "/environments.config.invalid.yaml"},
+			targetErr: ErrInvalidEnvironment,
+		},
+		{
+			setup:  func(_ testing.TB) func(testing.TB) { return func(_ testing.TB) {} },
+			name:   "Includes",
+			fields: fields{fileName: testdataFolder + "/includes.config.yaml"},
+			want: &Config{
+				SQSs: []SQS{
+					{Name: "orders", MaxReceiveCount: 10, Files: includedSQSFiles},
+					{Name: "payments", MaxReceiveCount: 5, Files: includedSQSFiles},
+				},
+				Tags: map[string]string{"team": "payments", "owner": "platform"},
+			},
+		},
+		{
+			setup: func(_ testing.TB) func(testing.TB) { return func(_ testing.TB) {} },
+			name:  "Multiple files",
+			fields: fields{fileNames: []string{
+				testdataFolder + "/includes/shared.yaml", testdataFolder + "/sqs.config.yaml",
+			}},
+			want: &Config{
+				SQSs: []SQS{
+					{Name: "orders", MaxReceiveCount: 10, Files: includedSQSFiles},
+					{Name: "target", MaxReceiveCount: 15, Files: []File{{
+						Name: "target-sqs.tf", Tmpl: `resource "aws_sqs_queue" "{{ToSnake $.Name}}_sqs" {}`,
+					}}},
+					{Name: "source", MaxReceiveCount: 10},
+				},
+				Tags: map[string]string{"team": "payments"},
+			},
+		},
+		{
+			setup:  func(_ testing.TB) func(testing.TB) { return func(_ testing.TB) {} },
+			name:   "File included twice",
+			fields: fields{fileName: testdataFolder + "/includes.config.diamond.yaml"},
+			want: &Config{
+				SQSs: []SQS{
+					{Name: "orders", MaxReceiveCount: 10, Files: includedSQSFiles},
+					{Name: "payments", MaxReceiveCount: 5, Files: includedSQSFiles},
+				},
+				Tags: map[string]string{"team": "payments", "owner": "platform"},
+			},
+		},
+		{
+			setup:  func(_ testing.TB) func(testing.TB) { return func(_ testing.TB) {} },
+			name:   "Anchored values are merged without changing their aliases",
+			fields: fields{fileName: testdataFolder + "/includes.config.anchors.yaml"},
+			want: &Config{
+				SQSs: []SQS{{Name: "orders", Tags: map[string]string{"team": "payments"}}},
+				Tags: map[string]string{"team": "payments", "owner": "platform"},
+			},
+		},
+		{
+			setup:  func(_ testing.TB) func(testing.TB) { return func(_ testing.TB) {} },
+			name:   "API Gateways of the same stack",
+			fields: fields{fileName: testdataFolder + "/includes.config.stacks.yaml"},
+			want: &Config{APIGateways: []APIGateway{
+				{StackName: "mystack", APIDomain: "api.example.com"},
+				{StackName: "mystack", APIDomain: "ws.example.com"},
+			}},
+		},
+		{
+			setup:  func(_ testing.TB) func(testing.TB) { return func(_ testing.TB) {} },
+			name:   "Directives and multiple documents",
+			fields: fields{fileName: testdataFolder + "/includes.config.documents.yaml"},
+			want: &Config{
+				SQSs: []SQS{
+					{Name: "orders", MaxReceiveCount: 10, Files: includedSQSFiles},
+					{Name: "payments", MaxReceiveCount: 5, Files: includedSQSFiles},
+				},
+				Tags: map[string]string{"team": "payments", "owner": "platform"},
+			},
+		},
+		{
+			setup:     func(_ testing.TB) func(testing.TB) { return func(_ testing.TB) {} },
+			name:      "Conflicting resource",
+			fields:    fields{fileName: testdataFolder + "/includes.config.conflict.yaml"},
+			targetErr: ErrConflictingResource,
+		},
+		{
+			setup:     func(_ testing.TB) func(testing.TB) { return func(_ testing.TB) {} },
+			name:      "Include cycle",
+			fields:    fields{fileName: testdataFolder + "/includes.config.cycle.yaml"},
+			targetErr: ErrInvalidInclude,
+		},
+		{
+			setup:     func(_ testing.TB) func(testing.TB) { return func(_ testing.TB) {} },
+			name:      "Invalid naming convention",
+			fields:    fields{fileName: testdataFolder + "/naming.config.invalid.yaml"},
+			targetErr: ErrInvalidNaming,
+		},
 	}
 
 	for i := range tests {
@@ -218,10 +377,54 @@ func TestYAML_Parse(t *testing.T) {
 			tearDown := tc.setup(t)
 			defer tearDown(t)
 
-			got, err := NewYAML(tc.fields.fileName).Parse()
+			yamlParser := NewYAML(tc.fields.fileName)
+			if tc.fields.fileNames != nil {
+				yamlParser = NewYAMLFiles(tc.fields.fileNames)
+			}
+
+			got, err := yamlParser.Parse()
 
 			require.ErrorIs(t, err, tc.targetErr)
 			require.Equal(t, tc.want, got)
 		})
 	}
 }
+
+func TestYAML_ParseConflictLocations(t *testing.T) {
+	_, err := NewYAML(testdataFolder + "/includes.config.conflict.yaml").Parse()
+
+	require.ErrorIs(t, err, ErrConflictingResource)
+	require.ErrorContains(t, err, "sqs 'orders' is defined at ../testdata/includes/shared.yaml:10 and "+
+		"../testdata/includes.config.conflict.yaml:4")
+}
+
+func TestYAML_ParseDocumentsConflictLocations(t *testing.T) {
+	_, err := NewYAML(testdataFolder + "/includes.config.documents.conflict.yaml").Parse()
+
+	require.ErrorIs(t, err, ErrConflictingResource)
+	require.ErrorContains(t, err, "sqs 'orders' is defined at ../testdata/includes/shared.yaml:10 and "+
+		"../testdata/includes.config.documents.conflict.yaml:7")
+}
+
+func TestNewYAMLFiles(t *testing.T) {
+	// The separator of the path lists is part of the folder name.
+	folder := path.Join(t.TempDir(), "stack:prd")
+	require.NoError(t, os.MkdirAll(folder, os.ModePerm))
+
+	files := map[string]string{
+		"queues.yaml": "sqs:\n  - name: orders\n",
+		"tags.yaml":   "tags:\n  team: payments\n",
+	}
+
+	fileNames := make([]string, 0, len(files))
+
+	for fileName, content := range files {
+		require.NoError(t, os.WriteFile(path.Join(folder, fileName), []byte(content), os.ModePerm))
+		fileNames = append(fileNames, path.Join(folder, fileName))
+	}
+
+	got, err := NewYAMLFiles(fileNames).Parse()
+
+	require.NoError(t, err)
+	require.Equal(t, &Config{SQSs: []SQS{{Name: "orders"}}, Tags: map[string]string{"team": "payments"}}, got)
+}
@@ -0,0 +1,107 @@
+package custom
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+	"github.com/joselitofilho/aws-terraform-generator/internal/resources"
+	"github.com/joselitofilho/aws-terraform-generator/internal/utils"
+)
+
+type Data struct {
+	Type  string
+	Name  string
+	Label string
+	// Attributes are the fields of the configuration of the resource, as declared by the schema of the plugin.
+	Attributes map[string]any
+	Tags       string
+}
+
+// Custom generates the files of the resources of the types registered by the plugins.
+type Custom struct {
+	yamlParser *config.YAML
+	output     string
+}
+
+func NewCustom(yamlParser *config.YAML, output string) *Custom {
+	return &Custom{yamlParser: yamlParser, output: output}
+}
+
+func (c *Custom) Build() error {
+	yamlConfig, err := c.yamlParser.Parse()
+	if err != nil {
+		return fmt.Errorf("%w: %w", generatorserrs.ErrYAMLParser, err)
+	}
+
+	modPath := path.Join(c.output, "mod")
+	_ = os.MkdirAll(modPath, os.ModePerm)
+
+	tg := generators.NewGenerator(yamlConfig)
+
+	for _, def := range resources.RegisteredResourceTypes() {
+		customResources := yamlConfig.CustomResources[string(def.Type)]
+		if len(customResources) == 0 {
+			continue
+		}
+
+		templates := utils.MergeStringMap(def.Templates,
+			generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.Custom[string(def.Type)]))
+
+		if err := buildType(tg, yamlConfig, def.Type, customResources, templates, modPath); err != nil {
+			return err
+		}
+
+		fmtcolor.White.Printf("Custom resources '%s' have been generated successfully\n", def.Type)
+	}
+
+	return nil
+}
+
+// buildType generates a file per template, with the output of the template for every resource of the type.
+func buildType(tg *generators.TemplateGenerator, yamlConfig *config.Config, resourceType resources.ResourceType,
+	customResources []config.CustomResource, templates map[string]string, modPath string,
+) error {
+	fileNames := make([]string, 0, len(templates))
+	for fileName := range templates {
+		fileNames = append(fileNames, fileName)
+	}
+
+	slices.Sort(fileNames)
+
+	for _, fileName := range fileNames {
+		result := make([]string, 0, len(customResources))
+
+		for _, resource := range customResources {
+			tags, err := generators.BuildTags(yamlConfig, resource.GetName())
+			if err != nil {
+				return fmt.Errorf("%w", err)
+			}
+
+			data := Data{
+				Type:       string(resourceType),
+				Name:       resource.GetName(),
+				Label:      yamlConfig.Naming.Label(resourceType, resource.GetName()),
+				Attributes: resource,
+				Tags:       tags,
+			}
+
+			output, err := tg.Build(data, fmt.Sprintf("%s-%s", resourceType, fileName), templates[fileName])
+			if err != nil {
+				return fmt.Errorf("%w", err)
+			}
+
+			result = append(result, output)
+		}
+
+		generators.MustGenerateFile(tg, nil, fileName, strings.Join(result, "\n"), path.Join(modPath, fileName), Data{})
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package custom
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testdataFolder = "../testdata"
+	testOutput     = "./testoutput"
+)
+
+func TestCustom_Build(t *testing.T) {
+	type fields struct {
+		configFileName string
+		output         string
+	}
+
+	tests := []struct {
+		name      string
+		fields    fields
+		want      string
+		targetErr error
+	}{
+		{
+			name: "templates of the plugin",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "custom.config.yaml"),
+				output:         path.Join(testOutput, "plugin"),
+			},
+			want: `resource "acme_ledger" "payments_ledger" {
+  ledger_name = "payments"
+  currency    = "EUR"
+}
+resource "acme_ledger" "refunds_ledger" {
+  ledger_name = "refunds"
+  currency    = "USD"
+}`,
+		},
+		{
+			name: "override default template of the plugin",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "custom.config.override.default.tmpls.yaml"),
+				output:         path.Join(testOutput, "override"),
+			},
+			want: "# payments in EUR",
+		},
+		{
+			name: "when yaml parser fails should return an error",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "custom.config.unknown.type.yaml"),
+				output:         path.Join(testOutput, "unknown"),
+			},
+			targetErr: generatorserrs.ErrYAMLParser,
+		},
+	}
+
+	defer func() {
+		_ = os.RemoveAll(testOutput)
+	}()
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewCustom(config.NewYAML(tc.fields.configFileName), tc.fields.output).Build()
+
+			require.ErrorIs(t, err, tc.targetErr)
+
+			if tc.targetErr != nil {
+				return
+			}
+
+			got, err := os.ReadFile(path.Join(tc.fields.output, "mod", "ledger.tf"))
+			require.NoError(t, err)
+			require.Equal(t, tc.want, string(got))
+		})
+	}
+}
@@ -0,0 +1,422 @@
+package declarations
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+)
+
+const (
+	modFolder       = "mod"
+	mainFileName    = "main.tf"
+	varsFileName    = "vars.tf"
+	outputsFileName = "outputs.tf"
+)
+
+var (
+	reVarReference        = regexp.MustCompile(`\bvar\.([A-Za-z_][A-Za-z0-9_-]*)`)
+	reVariable            = regexp.MustCompile(`^\s*variable\s+"([^"]+)"`)
+	reVariableType        = regexp.MustCompile(`^\s*type\s*=\s*(.+?)\s*$`)
+	reVariableDescription = regexp.MustCompile(`^\s*description\s*=\s*(".*")\s*$`)
+	reVariableDefault     = regexp.MustCompile(`^\s*default\s*=`)
+	reOutput              = regexp.MustCompile(`^\s*output\s+"([^"]+)"`)
+	reResource            = regexp.MustCompile(`^\s*resource\s+"([^"]+)"\s+"([^"]+)"`)
+)
+
+// Variable is the declaration of a variable the module references.
+type Variable struct {
+	Name        string
+	Type        string
+	Description string
+}
+
+// Output is the declaration of an output of the module.
+type Output struct {
+	Name        string
+	Description string
+	Value       string
+}
+
+// resourceOutput is the attribute of a resource type the module outputs.
+type resourceOutput struct {
+	suffix      string
+	attribute   string
+	description string
+}
+
+// knownVariables are the variables the built-in templates reference.
+var knownVariables = map[string]Variable{
+	"client":      {Type: "string", Description: "The client, the first part of the names of the resources."},
+	"environment": {Type: "string", Description: "The environment, such as dev or prd."},
+	"region":      {Type: "string", Description: "The AWS region of the resources."},
+	"account_id":  {Type: "string", Description: "The ID of the AWS account."},
+	"zone_id":     {Type: "string", Description: "The ID of the Route 53 hosted zone of the domains."},
+	"alerting_sns_topic_arn": {
+		Type: "string", Description: "The ARN of the SNS topic the alarms notify.",
+	},
+	"lambda_function_source_base_path": {
+		Type: "string", Description: "The base path of the source code of the lambda functions.",
+	},
+	"lambda_function_vpc_config": {
+		Type: "map(list(string))", Description: "The subnet and security group IDs of the lambda functions.",
+	},
+	"lambda_function_kms_key_arn": {
+		Type: "string", Description: "The ARN of the KMS key of the environment variables of the lambda functions.",
+	},
+	"vpc_id":     {Type: "string", Description: "The ID of the VPC of the databases."},
+	"subnet_ids": {Type: "list(string)", Description: "The IDs of the subnets of the databases."},
+	"api_http_error_alarm_period": {
+		Type: "number", Description: "The period in seconds of the HTTP error alarms of the APIs.",
+	},
+	"api_latency_threshold_millis": {
+		Type: "number", Description: "The latency threshold in milliseconds of the alarms of the APIs.",
+	},
+}
+
+// numberSuffixes are the suffixes of the names of the variables inferred as numbers.
+var numberSuffixes = []string{"_count", "_days", "_millis", "_period", "_seconds", "_size", "_timeout"}
+
+// resourceOutputs are the outputs of the resource types: queue URLs, bucket names, stream ARNs and API endpoints.
+var resourceOutputs = map[string]resourceOutput{
+	"aws_sqs_queue":        {suffix: "url", attribute: "url", description: "The URL of the %s queue."},
+	"aws_s3_bucket":        {suffix: "name", attribute: "bucket", description: "The name of the %s bucket."},
+	"aws_kinesis_stream":   {suffix: "arn", attribute: "arn", description: "The ARN of the %s stream."},
+	"aws_apigatewayv2_api": {suffix: "endpoint", attribute: "api_endpoint", description: "The endpoint of the %s API."},
+}
+
+// declaredVariable is a variable declared in a folder, which the module call must pass when it has no default.
+type declaredVariable struct {
+	Variable
+
+	hasDefault bool
+}
+
+// Declarations declares the variables the generated module references and the outputs of its resources, so the
+// module validates as generated, and passes the variables without default from the environment folders.
+type Declarations struct {
+	output string
+}
+
+func NewDeclarations(output string) *Declarations {
+	return &Declarations{output: output}
+}
+
+// Build scans the Terraform files of the module and appends the variables and the outputs they miss to the vars.tf
+// and outputs.tf files. The environment folders next to the module, the ones with a main.tf, declare the variables
+// of the module without default and pass them to the module call. The declarations already in the folders are left
+// as they are, so it runs again after every generation.
+func (d *Declarations) Build() error {
+	modPath := path.Join(d.output, modFolder)
+
+	files, err := readTerraformFiles(modPath)
+	if err != nil {
+		return err
+	}
+
+	variables, outputs := Scan(files)
+
+	if err := appendBlocks(path.Join(modPath, varsFileName), variableBlocks(variables)); err != nil {
+		return err
+	}
+
+	if err := appendBlocks(path.Join(modPath, outputsFileName), outputBlocks(outputs)); err != nil {
+		return err
+	}
+
+	files, err = readTerraformFiles(modPath)
+	if err != nil {
+		return err
+	}
+
+	required := slices.DeleteFunc(variableDeclarations(files), func(v declaredVariable) bool { return v.hasDefault })
+
+	if err := d.declareEnvironmentVariables(required); err != nil {
+		return err
+	}
+
+	fmtcolor.White.Printf("Variables (%d) and outputs (%d) have been declared successfully\n", len(variables),
+		len(outputs))
+
+	return nil
+}
+
+// Scan returns the variables the Terraform files reference and the outputs of their resources that are not declared
+// yet, sorted by name.
+func Scan(files []string) ([]Variable, []Output) {
+	references := []string{}
+	declaredVariables := map[string]bool{}
+	declaredOutputs := map[string]bool{}
+	outputs := []Output{}
+
+	for _, content := range files {
+		for _, line := range strings.Split(content, "\n") {
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "//") {
+				continue
+			}
+
+			if match := reVariable.FindStringSubmatch(line); match != nil {
+				declaredVariables[match[1]] = true
+			}
+
+			if match := reOutput.FindStringSubmatch(line); match != nil {
+				declaredOutputs[match[1]] = true
+			}
+
+			if match := reResource.FindStringSubmatch(line); match != nil {
+				if output, ok := newOutput(match[1], match[2]); ok {
+					outputs = append(outputs, output)
+				}
+			}
+
+			for _, match := range reVarReference.FindAllStringSubmatch(line, -1) {
+				references = append(references, match[1])
+			}
+		}
+	}
+
+	slices.Sort(references)
+
+	variables := []Variable{}
+
+	for _, name := range slices.Compact(references) {
+		if !declaredVariables[name] {
+			variables = append(variables, newVariable(name))
+		}
+	}
+
+	outputs = slices.DeleteFunc(outputs, func(output Output) bool { return declaredOutputs[output.Name] })
+
+	slices.SortFunc(outputs, func(a, b Output) int { return strings.Compare(a.Name, b.Name) })
+
+	outputs = slices.CompactFunc(outputs, func(a, b Output) bool { return a.Name == b.Name })
+
+	return variables, outputs
+}
+
+// declareEnvironmentVariables declares the required variables of the module in the environment folders that miss
+// them and passes them to the call of the module in their main.tf.
+func (d *Declarations) declareEnvironmentVariables(required []declaredVariable) error {
+	if len(required) == 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(d.output)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	names := make([]string, 0, len(required))
+	for _, variable := range required {
+		names = append(names, variable.Name)
+	}
+
+	for _, entry := range entries {
+		envPath := path.Join(d.output, entry.Name())
+
+		if !entry.IsDir() || entry.Name() == modFolder {
+			continue
+		}
+
+		if _, err := os.Stat(path.Join(envPath, mainFileName)); err != nil {
+			continue
+		}
+
+		files, err := readTerraformFiles(envPath)
+		if err != nil {
+			return err
+		}
+
+		declared := variableDeclarations(files)
+
+		missing := []Variable{}
+
+		for _, variable := range required {
+			if !slices.ContainsFunc(declared, func(v declaredVariable) bool { return v.Name == variable.Name }) {
+				missing = append(missing, variable.Variable)
+			}
+		}
+
+		if err := appendBlocks(path.Join(envPath, varsFileName), variableBlocks(missing)); err != nil {
+			return err
+		}
+
+		if err := generators.PassModuleArguments(path.Join(envPath, mainFileName), names); err != nil {
+			return fmt.Errorf("%w", err)
+		}
+	}
+
+	return nil
+}
+
+// variableDeclarations returns the variables declared in the Terraform files, sorted by name. Their type and
+// description are the ones of the declaration when it has them on a single line.
+func variableDeclarations(files []string) []declaredVariable {
+	declared := []declaredVariable{}
+
+	for _, content := range files {
+		lines := strings.Split(content, "\n")
+
+		for i := 0; i < len(lines); i++ {
+			match := reVariable.FindStringSubmatch(lines[i])
+			if match == nil {
+				continue
+			}
+
+			variable := declaredVariable{Variable: newVariable(match[1])}
+
+			for depth := 0; i < len(lines); i++ {
+				if depth == 1 {
+					parseVariableAttribute(&variable, lines[i])
+				}
+
+				depth += strings.Count(lines[i], "{") - strings.Count(lines[i], "}")
+				if depth <= 0 {
+					break
+				}
+			}
+
+			declared = append(declared, variable)
+		}
+	}
+
+	slices.SortFunc(declared, func(a, b declaredVariable) int { return strings.Compare(a.Name, b.Name) })
+
+	return declared
+}
+
+// parseVariableAttribute sets the type, the description or the default of the variable from the attribute line.
+func parseVariableAttribute(variable *declaredVariable, line string) {
+	if reVariableDefault.MatchString(line) {
+		variable.hasDefault = true
+	}
+
+	if match := reVariableType.FindStringSubmatch(line); match != nil && isBalanced(match[1]) {
+		variable.Type = match[1]
+	}
+
+	if match := reVariableDescription.FindStringSubmatch(line); match != nil {
+		if description, err := strconv.Unquote(match[1]); err == nil {
+			variable.Description = description
+		}
+	}
+}
+
+// isBalanced reports whether the type expression is complete on its line, such as map(list(string)).
+func isBalanced(expression string) bool {
+	return strings.Count(expression, "(") == strings.Count(expression, ")") &&
+		strings.Count(expression, "{") == strings.Count(expression, "}")
+}
+
+// newVariable returns the declaration of the variable. The type and the description of the variables the built-in
+// templates do not reference are inferred from the name, such as number for orders_sqs_delay_seconds.
+func newVariable(name string) Variable {
+	if known, ok := knownVariables[name]; ok {
+		known.Name = name
+		return known
+	}
+
+	varType := "string"
+
+	switch {
+	case strings.HasSuffix(name, "_ids"):
+		varType = "list(string)"
+	case slices.ContainsFunc(numberSuffixes, func(suffix string) bool { return strings.HasSuffix(name, suffix) }):
+		varType = "number"
+	}
+
+	return Variable{
+		Name:        name,
+		Type:        varType,
+		Description: fmt.Sprintf("The %s.", strings.ReplaceAll(name, "_", " ")),
+	}
+}
+
+func newOutput(resourceType, label string) (Output, bool) {
+	resourceOutput, ok := resourceOutputs[resourceType]
+	if !ok {
+		return Output{}, false
+	}
+
+	return Output{
+		Name:        label + "_" + resourceOutput.suffix,
+		Description: fmt.Sprintf(resourceOutput.description, label),
+		Value:       fmt.Sprintf("%s.%s.%s", resourceType, label, resourceOutput.attribute),
+	}, true
+}
+
+func variableBlocks(variables []Variable) []string {
+	blocks := make([]string, 0, len(variables))
+	for _, variable := range variables {
+		blocks = append(blocks, fmt.Sprintf("variable %q {\n  type        = %s\n  description = %q\n}\n",
+			variable.Name, variable.Type, variable.Description))
+	}
+
+	return blocks
+}
+
+func outputBlocks(outputs []Output) []string {
+	blocks := make([]string, 0, len(outputs))
+	for _, output := range outputs {
+		blocks = append(blocks, fmt.Sprintf("output %q {\n  description = %q\n  value       = %s\n}\n",
+			output.Name, output.Description, output.Value))
+	}
+
+	return blocks
+}
+
+// readTerraformFiles returns the contents of the Terraform files of the folder, sorted by file name.
+func readTerraformFiles(folder string) ([]string, error) {
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", generatorserrs.ErrModuleNotFound, err)
+	}
+
+	files := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".tf" {
+			continue
+		}
+
+		data, err := os.ReadFile(path.Join(folder, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("%w", err)
+		}
+
+		files = append(files, string(data))
+	}
+
+	return files, nil
+}
+
+// appendBlocks appends the blocks to the file, separated by blank lines, and creates the file when it does not exist.
+func appendBlocks(fileName string, blocks []string) error {
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	content, err := os.ReadFile(fileName)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("%w", err)
+	}
+
+	existing := strings.TrimRight(string(content), "\n")
+	if existing != "" {
+		existing += "\n\n"
+	}
+
+	if err := os.WriteFile(fileName, []byte(existing+strings.Join(blocks, "\n")), os.ModePerm); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
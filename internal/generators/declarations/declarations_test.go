@@ -0,0 +1,242 @@
+package declarations
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testOutput = "./testoutput"
+
+func TestDeclarations_Build(t *testing.T) {
+	type fields struct {
+		output string
+	}
+
+	tests := []struct {
+		name        string
+		fields      fields
+		files       map[string]string
+		wantVars    string
+		wantOutputs string
+		targetErr   error
+	}{
+		{
+			name:   "variables and outputs of the module",
+			fields: fields{output: path.Join(testOutput, "module")},
+			files: map[string]string{
+				"sqs.tf": `resource "aws_sqs_queue" "orders_sqs" {
+  name       = "${var.client}-${var.environment}-orders"
+  # delay_seconds = var.commented_out
+  delay_seconds = var.orders_sqs_delay_seconds
+}
+`,
+				"lambda.tf": `module "worker_lambda" {
+  vpc_config = var.lambda_function_vpc_config
+  environment = {
+    ORDERS_DB_HOST = var.orders_db_host
+  }
+}
+`,
+			},
+			wantVars: `variable "client" {
+  type        = string
+  description = "The client, the first part of the names of the resources."
+}
+
+variable "environment" {
+  type        = string
+  description = "The environment, such as dev or prd."
+}
+
+variable "lambda_function_vpc_config" {
+  type        = map(list(string))
+  description = "The subnet and security group IDs of the lambda functions."
+}
+
+variable "orders_db_host" {
+  type        = string
+  description = "The orders db host."
+}
+
+variable "orders_sqs_delay_seconds" {
+  type        = number
+  description = "The orders sqs delay seconds."
+}
+`,
+			wantOutputs: `output "orders_sqs_url" {
+  description = "The URL of the orders_sqs queue."
+  value       = aws_sqs_queue.orders_sqs.url
+}
+`,
+		},
+		{
+			name:   "keeps the declarations of the module",
+			fields: fields{output: path.Join(testOutput, "declared")},
+			files: map[string]string{
+				"vars.tf": `variable "client" {
+  type = string
+}
+`,
+				"outputs.tf": `output "reports_bucket_name" {
+  value = aws_s3_bucket.reports_bucket.id
+}
+`,
+				"s3.tf": `resource "aws_s3_bucket" "reports_bucket" {
+  bucket = "${var.client}-${var.region}-reports"
+}
+
+resource "aws_kinesis_stream" "clicks_kinesis" {}
+`,
+			},
+			wantVars: `variable "client" {
+  type = string
+}
+
+variable "region" {
+  type        = string
+  description = "The AWS region of the resources."
+}
+`,
+			wantOutputs: `output "reports_bucket_name" {
+  value = aws_s3_bucket.reports_bucket.id
+}
+
+output "clicks_kinesis_arn" {
+  description = "The ARN of the clicks_kinesis stream."
+  value       = aws_kinesis_stream.clicks_kinesis.arn
+}
+`,
+		},
+		{
+			name:      "when the module does not exist should return an error",
+			fields:    fields{output: path.Join(testOutput, "missing")},
+			targetErr: generatorserrs.ErrModuleNotFound,
+		},
+	}
+
+	defer func() {
+		_ = os.RemoveAll(testOutput)
+	}()
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			if len(tc.files) > 0 {
+				modPath := path.Join(tc.fields.output, "mod")
+				require.NoError(t, os.MkdirAll(modPath, os.ModePerm))
+
+				for fileName, content := range tc.files {
+					require.NoError(t, os.WriteFile(path.Join(modPath, fileName), []byte(content), os.ModePerm))
+				}
+			}
+
+			err := NewDeclarations(tc.fields.output).Build()
+
+			require.ErrorIs(t, err, tc.targetErr)
+
+			if tc.targetErr != nil {
+				return
+			}
+
+			for fileName, want := range map[string]string{varsFileName: tc.wantVars, outputsFileName: tc.wantOutputs} {
+				got, err := os.ReadFile(path.Join(tc.fields.output, "mod", fileName))
+				require.NoError(t, err)
+				require.Equal(t, want, string(got))
+			}
+
+			// The declarations are complete, so building again changes nothing.
+			require.NoError(t, NewDeclarations(tc.fields.output).Build())
+
+			got, err := os.ReadFile(path.Join(tc.fields.output, "mod", varsFileName))
+			require.NoError(t, err)
+			require.Equal(t, tc.wantVars, string(got))
+		})
+	}
+}
+
+func TestDeclarations_BuildEnvironments(t *testing.T) {
+	output := path.Join(testOutput, "environments")
+
+	defer func() {
+		_ = os.RemoveAll(testOutput)
+	}()
+
+	files := map[string]string{
+		"mod/vars.tf": `variable "region" {
+  type    = string
+  default = "eu-west-1"
+}
+
+variable "tags" {
+  type        = map(string)
+  description = "Tags of the resources."
+}
+`,
+		"mod/sqs.tf": `resource "aws_sqs_queue" "orders_sqs" {
+  name          = "${var.client}-${var.region}-orders"
+  delay_seconds = var.orders_sqs_delay_seconds
+  tags          = var.tags
+}
+`,
+		"dev/main.tf": `module "mystack" {
+  source = "../mod"
+
+  client = var.client
+}
+`,
+		"dev/vars.tf": `variable "client" {
+  type = string
+}
+`,
+		"lambda/orders/main.go": "package main\n",
+	}
+
+	for fileName, content := range files {
+		require.NoError(t, os.MkdirAll(path.Dir(path.Join(output, fileName)), os.ModePerm))
+		require.NoError(t, os.WriteFile(path.Join(output, fileName), []byte(content), os.ModePerm))
+	}
+
+	// The second build finds every variable declared and passed.
+	for i := 0; i < 2; i++ {
+		require.NoError(t, NewDeclarations(output).Build())
+	}
+
+	want := map[string]string{
+		"dev/main.tf": `module "mystack" {
+  source = "../mod"
+
+  client = var.client
+  orders_sqs_delay_seconds = var.orders_sqs_delay_seconds
+  tags = var.tags
+}
+`,
+		"dev/vars.tf": `variable "client" {
+  type = string
+}
+
+variable "orders_sqs_delay_seconds" {
+  type        = number
+  description = "The orders sqs delay seconds."
+}
+
+variable "tags" {
+  type        = map(string)
+  description = "Tags of the resources."
+}
+`,
+	}
+
+	for fileName, content := range want {
+		got, err := os.ReadFile(path.Join(output, fileName))
+		require.NoError(t, err)
+		require.Equal(t, content, string(got), fileName)
+	}
+
+	require.NoFileExists(t, path.Join(output, "lambda", varsFileName))
+}
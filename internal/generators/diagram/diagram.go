@@ -19,16 +19,16 @@ import (
 
 type Diagram struct {
 	diagramFilename string
-	configFilename  string
+	yamlParser      *config.YAML
 	output          string
 }
 
-func NewDiagram(diagramFilename, configFilename, output string) *Diagram {
-	return &Diagram{diagramFilename: diagramFilename, configFilename: configFilename, output: output}
+func NewDiagram(diagramFilename string, yamlParser *config.YAML, output string) *Diagram {
+	return &Diagram{diagramFilename: diagramFilename, yamlParser: yamlParser, output: output}
 }
 
 func (d *Diagram) Build() error {
-	yamlConfig, err := config.NewYAML(d.configFilename).Parse()
+	yamlConfig, err := d.yamlParser.Parse()
 	if err != nil {
 		return fmt.Errorf("%w: %w", generatorserrs.ErrYAMLParser, err)
 	}
@@ -6,6 +6,7 @@ import (
 	"path"
 	"testing"
 
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
 
 	"github.com/stretchr/testify/require"
@@ -73,7 +74,7 @@ func TestDiagram_Build(t *testing.T) {
 		tc := tests[i]
 
 		t.Run(tc.name, func(t *testing.T) {
-			err := NewDiagram(tc.fields.diagramFilename, tc.fields.configFilename, tc.fields.output).Build()
+			err := NewDiagram(tc.fields.diagramFilename, config.NewYAML(tc.fields.configFilename), tc.fields.output).Build()
 
 			require.ErrorIs(t, err, tc.targetErr)
 
@@ -20,35 +20,37 @@ import (
 
 // DefaultResourceImageMap defines the default resource images. Images from here: https://awsicons.dev/
 var DefaultResourceImageMap = config.Images{
-	awsresources.APIGatewayType: "assets/diagram/api_gateway.svg",
-	awsresources.CronType:       "assets/diagram/cron.svg",
-	awsresources.DatabaseType:   "assets/diagram/database_dynamo_db.svg",
-	awsresources.EndpointType:   "assets/diagram/endpoint.svg",
-	awsresources.GoogleBQType:   "assets/diagram/google_bigquery.svg",
-	awsresources.KinesisType:    "assets/diagram/kinesis_data_stream.svg",
-	awsresources.LambdaType:     "assets/diagram/lambda.svg",
-	awsresources.RestfulAPIType: "assets/diagram/restful_api.svg",
-	awsresources.S3Type:         "assets/diagram/s3_bucket.svg",
-	awsresources.SNSType:        "assets/diagram/sns.svg",
-	awsresources.SQSType:        "assets/diagram/sqs.svg",
-	awsresources.UnknownType:    "",
+	awsresources.APIGatewayType:   "assets/diagram/api_gateway.svg",
+	awsresources.CronType:         "assets/diagram/cron.svg",
+	awsresources.DatabaseType:     "assets/diagram/database_dynamo_db.svg",
+	awsresources.EndpointType:     "assets/diagram/endpoint.svg",
+	awsresources.EventBusType:     "assets/diagram/eventbridge_event_bus.svg",
+	awsresources.FirehoseType:     "assets/diagram/kinesis_data_firehose.svg",
+	awsresources.GoogleBQType:     "assets/diagram/google_bigquery.svg",
+	awsresources.KinesisType:      "assets/diagram/kinesis_data_stream.svg",
+	awsresources.LambdaType:       "assets/diagram/lambda.svg",
+	awsresources.RestfulAPIType:   "assets/diagram/restful_api.svg",
+	awsresources.S3Type:           "assets/diagram/s3_bucket.svg",
+	awsresources.SNSType:          "assets/diagram/sns.svg",
+	awsresources.SQSType:          "assets/diagram/sqs.svg",
+	awsresources.StepFunctionType: "assets/diagram/step_functions.svg",
+	awsresources.WebSocketType:    "assets/diagram/api_gateway.svg",
+	awsresources.UnknownType:      "",
 }
 
 type Draw struct {
-	workdirs       []string
-	files          []string
-	configFilename string
-	output         string
+	workdirs   []string
+	files      []string
+	yamlParser *config.YAML
+	output     string
 }
 
-func NewDraw(workdirs, files []string, configFilename, output string) *Draw {
-	return &Draw{workdirs: workdirs, files: files, configFilename: configFilename, output: output}
+func NewDraw(workdirs, files []string, yamlParser *config.YAML, output string) *Draw {
+	return &Draw{workdirs: workdirs, files: files, yamlParser: yamlParser, output: output}
 }
 
 func (d *Draw) Build() error {
-	yamlParser := config.NewYAML(d.configFilename)
-
-	yamlConfig, err := yamlParser.Parse()
+	yamlConfig, err := d.yamlParser.Parse()
 	if err != nil {
 		return fmt.Errorf("%w: %w", generatorerrs.ErrYAMLParser, err)
 	}
@@ -96,7 +98,7 @@ func (d *Draw) Build() error {
 		nodeAttrs[k] = v
 	}
 
-	resourceImageMap := mergeImages(DefaultResourceImageMap, yamlConfig.Draw.Images)
+	resourceImageMap := mergeImages(mergeImages(DefaultResourceImageMap, registeredImages()), yamlConfig.Draw.Images)
 	dotConfig := &dot.Config{
 		Direction:        yamlConfig.Draw.Direction,
 		Splines:          yamlConfig.Draw.Splines,
@@ -128,6 +130,19 @@ func (d *Draw) Build() error {
 	return nil
 }
 
+// registeredImages returns the images of the registered resource types.
+func registeredImages() config.Images {
+	images := config.Images{}
+
+	for _, def := range awsresources.RegisteredResourceTypes() {
+		if def.Image != "" {
+			images[def.Type] = def.Image
+		}
+	}
+
+	return images
+}
+
 func mergeImages(defaultImages, configImages config.Images) config.Images {
 	result := defaultImages
 
@@ -5,6 +5,8 @@ import (
 	"path"
 	"testing"
 
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+
 	"github.com/stretchr/testify/require"
 )
 
@@ -47,7 +49,7 @@ func TestDraw_Build(t *testing.T) {
 			d := NewDraw(
 				tc.fields.workdirs,
 				tc.fields.files,
-				tc.fields.configFileName,
+				config.NewYAML(tc.fields.configFileName),
 				tc.fields.output,
 			)
 
@@ -0,0 +1,264 @@
+package generators
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+)
+
+var (
+	reModuleBlock  = regexp.MustCompile(`^\s*module\s+"[^"]+"\s*\{`)
+	reModuleSource = regexp.MustCompile(`^\s*source\s*=\s*"\.\./mod/?"`)
+)
+
+// EnvironmentVariable represents a resource setting whose value differs between the environments. The module
+// declares it as a variable with the base value as default and the folder of each environment sets its value.
+type EnvironmentVariable struct {
+	Name    string
+	Type    string
+	Default string
+	// Values maps the environments to their values.
+	Values map[string]string
+}
+
+// Environment represents the configuration of an environment.
+type Environment struct {
+	Name   string
+	Config *config.Config
+}
+
+// EnvironmentVariables collects the environment variables of a generator.
+type EnvironmentVariables struct {
+	Environments []Environment
+	Variables    []EnvironmentVariable
+}
+
+// NewEnvironmentVariables resolves the configuration of every environment of the base configuration.
+func NewEnvironmentVariables(yamlConfig *config.Config) (*EnvironmentVariables, error) {
+	names := yamlConfig.EnvironmentNames()
+
+	envVars := &EnvironmentVariables{Environments: make([]Environment, 0, len(names))}
+
+	for _, name := range names {
+		envConfig, err := yamlConfig.Environment(name)
+		if err != nil {
+			return nil, fmt.Errorf("%w", err)
+		}
+
+		envVars.Environments = append(envVars.Environments, Environment{Name: name, Config: envConfig})
+	}
+
+	return envVars, nil
+}
+
+// EnvironmentSetting represents a setting of the data of a resource whose value may differ between the environments.
+type EnvironmentSetting[T any] struct {
+	Name string
+	// Type is the Terraform type of the variable.
+	Type string
+	// Value returns the setting in the data as a Terraform value, or empty when it is not set.
+	Value func(data *T) string
+	// Var returns the field of the data that references the variable of the setting.
+	Var func(data *T) *string
+}
+
+// SetEnvironmentVariables references variables named <label>_<setting> in the Var fields of the settings of the
+// resource data that differ between the environments. The build function returns the data of the resource in the
+// configuration of an environment, or false when the environment has no such resource.
+func SetEnvironmentVariables[T any](envVars *EnvironmentVariables, data *T, label string,
+	build func(envConfig *config.Config) (T, bool), settings []EnvironmentSetting[T],
+) {
+	if len(envVars.Environments) == 0 {
+		return
+	}
+
+	envData := make(map[string]*T, len(envVars.Environments))
+
+	for _, env := range envVars.Environments {
+		d, ok := build(env.Config)
+		if !ok {
+			d = *data
+		}
+
+		envData[env.Name] = &d
+	}
+
+	for _, setting := range settings {
+		values := make(map[string]string, len(envData))
+		for name, d := range envData {
+			values[name] = setting.Value(d)
+		}
+
+		envVars.set(setting.Value(data), setting.Var(data), label+"_"+setting.Name, setting.Type, values)
+	}
+}
+
+// set references a new variable in the var field when any environment has a different value than the setting. Empty
+// values are declared as null, so the setting falls back to the provider default.
+func (e *EnvironmentVariables) set(setting string, varField *string, name, varType string, values map[string]string) {
+	differs := false
+
+	for _, value := range values {
+		if value != setting {
+			differs = true
+			break
+		}
+	}
+
+	if !differs {
+		return
+	}
+
+	e.Variables = append(e.Variables, EnvironmentVariable{
+		Name: name, Type: varType, Default: hclValue(setting), Values: values,
+	})
+
+	*varField = "var." + name
+}
+
+// Write declares the variables in mod/<name>_environments.tf. In every environment folder, it declares them in
+// <name>_environments.tf, sets their values in <name>.auto.tfvars and passes them to the call of the module in
+// main.tf.
+func (e *EnvironmentVariables) Write(tg *TemplateGenerator, output, name string) error {
+	if len(e.Variables) == 0 {
+		return nil
+	}
+
+	declarations := make([]string, 0, len(e.Variables))
+	for _, variable := range e.Variables {
+		declarations = append(declarations, fmt.Sprintf("variable %q {\n  type    = %s\n  default = %s\n}\n",
+			variable.Name, variable.Type, variable.Default))
+	}
+
+	filename := name + "_environments.tf"
+	MustGenerateFile(tg, nil, filename, strings.Join(declarations, "\n"), path.Join(output, "mod", filename), nil)
+
+	width := 0
+	names := make([]string, 0, len(e.Variables))
+	envDeclarations := make([]string, 0, len(e.Variables))
+
+	for _, variable := range e.Variables {
+		width = max(width, len(variable.Name))
+		names = append(names, variable.Name)
+		envDeclarations = append(envDeclarations, fmt.Sprintf("variable %q {\n  type = %s\n}\n",
+			variable.Name, variable.Type))
+	}
+
+	for _, env := range e.Environments {
+		var sb strings.Builder
+
+		for _, variable := range e.Variables {
+			sb.WriteString(fmt.Sprintf("%-*s = %s\n", width, variable.Name, hclValue(variable.Values[env.Name])))
+		}
+
+		envPath := path.Join(output, env.Name)
+		_ = os.MkdirAll(envPath, os.ModePerm)
+
+		err := os.WriteFile(path.Join(envPath, filename), []byte(strings.Join(envDeclarations, "\n")), os.ModePerm)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		if err := os.WriteFile(path.Join(envPath, name+".auto.tfvars"), []byte(sb.String()), os.ModePerm); err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		if err := PassModuleArguments(path.Join(envPath, "main.tf"), names); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PassModuleArguments passes the variables of the environment folder to the call of the ../mod module in the main
+// file, after the arguments it already has. The folders created by structure call the module in main.tf; when there
+// is no such call, it warns that the variables are not passed.
+func PassModuleArguments(fileName string, names []string) error {
+	content, err := os.ReadFile(fileName)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("%w", err)
+	}
+
+	lines := strings.Split(string(content), "\n")
+
+	start, end, ok := moduleCall(lines)
+	if !ok {
+		fmtcolor.Yellow.Printf("%s does not call the ../mod module: pass the variables %s to it\n", fileName,
+			strings.Join(names, ", "))
+
+		return nil
+	}
+
+	arguments := []string{}
+
+	for _, name := range names {
+		reArgument := regexp.MustCompile(`^\s*` + regexp.QuoteMeta(name) + `\s*=`)
+		if !slices.ContainsFunc(lines[start:end], reArgument.MatchString) {
+			arguments = append(arguments, fmt.Sprintf("  %s = var.%s", name, name))
+		}
+	}
+
+	if len(arguments) == 0 {
+		return nil
+	}
+
+	lines = slices.Insert(lines, end, arguments...)
+
+	if err := os.WriteFile(fileName, []byte(strings.Join(lines, "\n")), os.ModePerm); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+// moduleCall returns the line of the first module block whose source is ../mod and the line of its closing brace.
+func moduleCall(lines []string) (start, end int, ok bool) {
+	for i, line := range lines {
+		if !reModuleBlock.MatchString(line) {
+			continue
+		}
+
+		depth := 0
+		source := false
+
+		for j := i; j < len(lines); j++ {
+			depth += strings.Count(lines[j], "{") - strings.Count(lines[j], "}")
+			source = source || reModuleSource.MatchString(lines[j])
+
+			if depth == 0 {
+				if source {
+					return i, j, true
+				}
+
+				break
+			}
+		}
+	}
+
+	return 0, 0, false
+}
+
+// OptionalNumber formats a number setting for the templates. Zero means unset, which the templates leave out.
+func OptionalNumber(n int) string {
+	if n == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(n)
+}
+
+func hclValue(value string) string {
+	if value == "" {
+		return "null"
+	}
+
+	return value
+}
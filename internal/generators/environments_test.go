@@ -0,0 +1,74 @@
+package generators
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvironmentVariables_Write(t *testing.T) {
+	output := "./testoutput/environments"
+
+	defer func() {
+		_ = os.RemoveAll("./testoutput")
+	}()
+
+	require.NoError(t, os.MkdirAll(path.Join(output, "mod"), os.ModePerm))
+	require.NoError(t, os.MkdirAll(path.Join(output, "prd"), os.ModePerm))
+	require.NoError(t, os.WriteFile(path.Join(output, "prd", "main.tf"), []byte(`provider "aws" {
+  region = var.region
+}
+
+module "mystack" {
+  source = "../mod"
+
+  client = var.client
+  tags   = { Environment = "${var.environment}" }
+}
+`), os.ModePerm))
+
+	envVars := &EnvironmentVariables{
+		Environments: []Environment{{Name: "dev"}, {Name: "prd"}},
+		Variables: []EnvironmentVariable{{
+			Name: "orders_sqs_delay_seconds", Type: "number", Default: "null",
+			Values: map[string]string{"dev": "", "prd": "30"},
+		}},
+	}
+
+	// The second write passes nothing new to the module.
+	for i := 0; i < 2; i++ {
+		require.NoError(t, envVars.Write(NewGenerator(&config.Config{}), output, "sqs"))
+	}
+
+	want := map[string]string{
+		"mod/sqs_environments.tf": "variable \"orders_sqs_delay_seconds\" {\n  type    = number\n  default = null\n}\n",
+		"dev/sqs_environments.tf": "variable \"orders_sqs_delay_seconds\" {\n  type = number\n}\n",
+		"dev/sqs.auto.tfvars":     "orders_sqs_delay_seconds = null\n",
+		"prd/sqs_environments.tf": "variable \"orders_sqs_delay_seconds\" {\n  type = number\n}\n",
+		"prd/sqs.auto.tfvars":     "orders_sqs_delay_seconds = 30\n",
+		"prd/main.tf": `provider "aws" {
+  region = var.region
+}
+
+module "mystack" {
+  source = "../mod"
+
+  client = var.client
+  tags   = { Environment = "${var.environment}" }
+  orders_sqs_delay_seconds = var.orders_sqs_delay_seconds
+}
+`,
+	}
+
+	for fileName, content := range want {
+		got, err := os.ReadFile(path.Join(output, fileName))
+		require.NoError(t, err)
+		require.Equal(t, content, string(got), fileName)
+	}
+
+	require.NoFileExists(t, path.Join(output, "dev", "main.tf"))
+}
@@ -8,4 +8,29 @@ var (
 
 	// ErrYAMLParser represents a failure in the YAML parser.
 	ErrYAMLParser = errors.New("YAML parser fails")
+
+	// ErrUnknownSecret represents a lambda that reads a secret that is not configured.
+	ErrUnknownSecret = errors.New("unknown secret")
+
+	// ErrUnknownDatabase represents a lambda that connects to a database that is not configured.
+	ErrUnknownDatabase = errors.New("unknown database")
+
+	// ErrMissingRequiredTag represents a taggable resource that misses one of the required tags.
+	ErrMissingRequiredTag = errors.New("missing required tag")
+
+	// ErrRequiredValue represents a template that misses a value it requires.
+	ErrRequiredValue = errors.New("required value")
+
+	// ErrInvalidQueueSource represents a queue wired to a source that cannot send to it, such as the S3 notifications of
+	// a FIFO queue.
+	ErrInvalidQueueSource = errors.New("invalid queue source")
+
+	// ErrUnknownConsumer represents a Kinesis trigger reading through a consumer that its stream does not declare.
+	ErrUnknownConsumer = errors.New("unknown kinesis consumer")
+
+	// ErrInvalidReplication represents a bucket replicated to a bucket that is not configured or not versioned.
+	ErrInvalidReplication = errors.New("invalid replication")
+
+	// ErrModuleNotFound represents an output folder without the mod folder of a generated module.
+	ErrModuleNotFound = errors.New("module not found")
 )
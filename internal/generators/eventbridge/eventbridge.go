@@ -0,0 +1,220 @@
+package eventbridge
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/ettle/strcase"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
+	"github.com/joselitofilho/aws-terraform-generator/internal/utils"
+)
+
+type Data struct {
+	Name    string
+	Default bool
+	Rules   []RuleData
+	Tags    string
+}
+
+type RuleData struct {
+	Name         string
+	Label        string
+	Description  string
+	EventBusName string
+	EventPattern string
+	Enabled      bool
+	Targets      []TargetData
+	// RolePolicies lists what the rule role is allowed to do. The role is only needed by Kinesis and Step Functions
+	// targets.
+	RolePolicies []RolePolicyData
+}
+
+type TargetData struct {
+	Type                   string
+	Label                  string
+	ARN                    string
+	RoleARN                string
+	InputPaths             map[string]string
+	InputTemplate          string
+	DeadLetterQueueARN     string
+	MaximumRetryAttempts   *int
+	MaximumEventAgeSeconds int
+}
+
+type RolePolicyData struct {
+	Actions     string
+	ResourceARN string
+}
+
+type EventBridge struct {
+	yamlParser *config.YAML
+	output     string
+}
+
+func NewEventBridge(yamlParser *config.YAML, output string) *EventBridge {
+	return &EventBridge{yamlParser: yamlParser, output: output}
+}
+
+func (e *EventBridge) Build() error {
+	yamlConfig, err := e.yamlParser.Parse()
+	if err != nil {
+		return fmt.Errorf("%w: %w", generatorserrs.ErrYAMLParser, err)
+	}
+
+	modPath := path.Join(e.output, "mod")
+	_ = os.MkdirAll(modPath, os.ModePerm)
+
+	result := make([]string, 0, len(yamlConfig.EventBuses))
+
+	templates := utils.MergeStringMap(defaultTfTemplateFiles,
+		generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.EventBridge))
+
+	tg := generators.NewGenerator(yamlConfig)
+
+	for i := range yamlConfig.EventBuses {
+		conf := yamlConfig.EventBuses[i]
+
+		tags, err := generators.BuildTags(yamlConfig, conf.Name)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		data := buildData(&conf, yamlConfig.Naming)
+		data.Tags = tags
+
+		if len(conf.Files) > 0 {
+			filesConf := generators.CreateFilesMap(conf.Files)
+
+			generators.MustGenerateFiles(tg, nil, filesConf, data, modPath)
+
+			fmtcolor.White.Printf("EventBridge '%s' has been generated successfully\n", conf.Name)
+
+			continue
+		}
+
+		output, err := tg.Build(data, "eventbridge-tf-template", templates[filenameEventBridgeTf])
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		result = append(result, output)
+	}
+
+	if len(result) > 0 {
+		outputFile := path.Join(modPath, filenameEventBridgeTf)
+
+		generators.MustGenerateFile(tg, nil, filenameEventBridgeTf, strings.Join(result, "\n"), outputFile, Data{})
+
+		fmtcolor.White.Println("EventBridge has been generated successfully")
+	}
+
+	return nil
+}
+
+func buildData(conf *config.EventBus, naming config.Naming) Data {
+	data := Data{
+		Name:    conf.Name,
+		Default: conf.IsDefault(),
+		Rules:   make([]RuleData, 0, len(conf.Rules)),
+	}
+
+	var eventBusName string
+	if !conf.IsDefault() {
+		eventBusName = fmt.Sprintf("aws_cloudwatch_event_bus.%s_event_bus.name", strcase.ToSnake(conf.Name))
+	}
+
+	for i := range conf.Rules {
+		data.Rules = append(data.Rules, buildRule(conf.Name, eventBusName, &conf.Rules[i], naming))
+	}
+
+	return data
+}
+
+func buildRule(busName, eventBusName string, conf *config.EventRule, naming config.Naming) RuleData {
+	key := generators.EventRuleKey(busName, conf.Name)
+
+	rule := RuleData{
+		Name:         conf.Name,
+		Label:        generators.EventRuleLabel(busName, conf.Name),
+		Description:  conf.Description,
+		EventBusName: eventBusName,
+		EventPattern: generators.HCLString(conf.EventPattern),
+		Enabled:      conf.IsEnabled(),
+	}
+
+	roleARN := fmt.Sprintf("aws_iam_role.%s_role.arn", rule.Label)
+
+	addTargets := func(targetType, arnFormat, actions string, targets []config.EventTarget) {
+		for i := range targets {
+			target := buildTarget(key, targetType, arnFormat, &targets[i], naming)
+
+			if actions != "" {
+				target.RoleARN = roleARN
+
+				rule.RolePolicies = append(rule.RolePolicies, RolePolicyData{Actions: actions, ResourceARN: target.ARN})
+			}
+
+			rule.Targets = append(rule.Targets, target)
+		}
+	}
+
+	addTargets(targetTypeLambda, "aws_lambda_function.%s.arn", "", conf.Lambdas)
+	addTargets(targetTypeSQS, "aws_sqs_queue.%s.arn", "", conf.SQSs)
+	addTargets(targetTypeKinesis, "aws_kinesis_stream.%s.arn",
+		`"kinesis:PutRecord", "kinesis:PutRecords"`, conf.Kinesis)
+	addTargets(targetTypeStepFunction, "aws_sfn_state_machine.%s.arn",
+		`"states:StartExecution"`, conf.StepFunctions)
+
+	return rule
+}
+
+func buildTarget(key, targetType, arnFormat string, conf *config.EventTarget, naming config.Naming) TargetData {
+	name := strcase.ToSnake(conf.Name)
+
+	target := TargetData{
+		Type:                   targetType,
+		Label:                  fmt.Sprintf("%s_%s_%s_target", key, name, targetType),
+		ARN:                    fmt.Sprintf(arnFormat, targetLabel(naming, targetType, conf.Name)),
+		MaximumRetryAttempts:   conf.MaximumRetryAttempts,
+		MaximumEventAgeSeconds: conf.MaximumEventAgeSeconds,
+	}
+
+	if conf.InputTransformer != nil {
+		target.InputPaths = make(map[string]string, len(conf.InputTransformer.InputPaths))
+		for k, v := range conf.InputTransformer.InputPaths {
+			target.InputPaths[k] = generators.HCLString(v)
+		}
+
+		target.InputTemplate = generators.HCLString(conf.InputTransformer.InputTemplate)
+	}
+
+	if conf.DeadLetterQueue != "" {
+		target.DeadLetterQueueARN = fmt.Sprintf("aws_sqs_queue.%s.arn",
+			naming.Label(awsresources.SQSType, conf.DeadLetterQueue))
+	}
+
+	return target
+}
+
+// targetLabel returns the Terraform label of the target. Step Functions have no naming convention and share the
+// label of the stepfunctions generator.
+func targetLabel(naming config.Naming, targetType, name string) string {
+	switch targetType {
+	case targetTypeLambda:
+		return naming.Label(awsresources.LambdaType, name)
+	case targetTypeSQS:
+		return naming.Label(awsresources.SQSType, name)
+	case targetTypeKinesis:
+		return naming.Label(awsresources.KinesisType, name)
+	default:
+		return generators.StateMachineLabel(name)
+	}
+}
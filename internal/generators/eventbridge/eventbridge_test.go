@@ -0,0 +1,130 @@
+package eventbridge
+
+import (
+	_ "embed"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testdataFolder = "../testdata"
+	testOutput     = "./testoutput"
+)
+
+func TestEventBridge_Build(t *testing.T) {
+	type fields struct {
+		configFileName string
+		output         string
+	}
+
+	tests := []struct {
+		name             string
+		fields           fields
+		extraValidations func(testing.TB, string, error)
+		targetErr        error
+	}{
+		{
+			name: "custom and default event buses with pattern-based targets",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "eventbridge.config.yaml"),
+				output:         path.Join(testOutput, "default"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				eventBridgeTfData, err := os.ReadFile(path.Join(output, "mod", "eventbridge.tf"))
+				require.NoError(tb, err)
+
+				content := string(eventBridgeTfData)
+				require.Contains(tb, content, `resource "aws_cloudwatch_event_bus" "orders_event_bus" {`)
+				require.NotContains(tb, content, `resource "aws_cloudwatch_event_bus" "default_event_bus" {`)
+				require.Contains(tb, content, `resource "aws_cloudwatch_event_rule" "orders_order_created_rule" {`)
+				require.Contains(tb, content, "event_bus_name = aws_cloudwatch_event_bus.orders_event_bus.name")
+				require.Contains(tb, content,
+					`event_pattern  = "{\"source\": [\"orders\"], \"detail-type\": [\"OrderCreated\"]}"`)
+				require.Contains(tb, content,
+					`resource "aws_cloudwatch_event_target" "orders_order_created_order_processor_lambda_target" {`)
+				require.Contains(tb, content, "arn            = aws_lambda_function.order_processor_lambda.arn")
+				require.Contains(tb, content, `orderId = "$.detail.id"`)
+				require.Contains(tb, content, `input_template = "{\"id\": <orderId>, \"link\": \"$${orders}/<orderId>\"}"`)
+				require.Contains(tb, content, "arn = aws_sqs_queue.order_events_dlq_sqs.arn")
+				require.Contains(tb, content, "maximum_retry_attempts       = 3")
+				require.Contains(tb, content, "maximum_event_age_in_seconds = 3600")
+				require.Contains(tb, content,
+					`resource "aws_lambda_permission" "orders_order_created_order_processor_lambda_target_permission" {`)
+				require.Contains(tb, content, "arn            = aws_sqs_queue.order_queue_sqs.arn")
+				require.Contains(tb, content, "arn            = aws_kinesis_stream.order_stream_kinesis.arn")
+				require.Contains(tb, content, "arn            = aws_sfn_state_machine.order_workflow_state_machine.arn")
+				require.Contains(tb, content, "role_arn       = aws_iam_role.orders_order_created_rule_role.arn")
+				require.Contains(tb, content, `name = "${var.client}-${var.environment}-orders-order-created-rule-role"`)
+				require.Contains(tb, content, `Action   = ["states:StartExecution"]`)
+				require.Contains(tb, content, `resource "aws_cloudwatch_event_rule" "default_instance_stopped_rule" {`)
+				require.Contains(tb, content, `state          = "DISABLED"`)
+			},
+		},
+		{
+			name: "override default template",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "eventbridge.config.override.default.tmpls.yaml"),
+				output:         path.Join(testOutput, "override"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				require.FileExists(tb, path.Join(output, "mod", "eventbridge.tf"))
+			},
+		},
+		{
+			name: "at least one event bus customising",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "eventbridge.config.custom.yaml"),
+				output:         path.Join(testOutput, "one"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				modPath := path.Join(output, "mod")
+				require.FileExists(tb, path.Join(modPath, "eventbridge.tf"))
+				require.FileExists(tb, path.Join(modPath, "orders.tf"))
+			},
+		},
+		{
+			name: "when yaml parser fails should return an error",
+			fields: fields{
+				configFileName: "",
+				output:         "",
+			},
+			targetErr: generatorserrs.ErrYAMLParser,
+		},
+	}
+
+	defer func() {
+		_ = os.RemoveAll(testOutput)
+	}()
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewEventBridge(config.NewYAML(tc.fields.configFileName), tc.fields.output).Build()
+
+			require.ErrorIs(t, err, tc.targetErr)
+
+			if tc.extraValidations != nil {
+				tc.extraValidations(t, tc.fields.output, err)
+			}
+		})
+	}
+}
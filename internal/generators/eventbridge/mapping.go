@@ -0,0 +1,27 @@
+package eventbridge
+
+import (
+	_ "embed"
+	"maps"
+)
+
+const filenameEventBridgeTf = "eventbridge.tf"
+
+const (
+	targetTypeLambda       = "lambda"
+	targetTypeSQS          = "sqs"
+	targetTypeKinesis      = "kinesis"
+	targetTypeStepFunction = "state_machine"
+)
+
+//go:embed tmpls/eventbridge.tf.tmpl
+var tmplEventBridgeTf []byte
+
+var defaultTfTemplateFiles = map[string]string{
+	filenameEventBridgeTf: string(tmplEventBridgeTf),
+}
+
+// DefaultTemplates returns the built-in templates of the generator by file name.
+func DefaultTemplates() map[string]string {
+	return maps.Clone(defaultTfTemplateFiles)
+}
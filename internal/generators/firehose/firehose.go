@@ -0,0 +1,198 @@
+package firehose
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
+	"github.com/joselitofilho/aws-terraform-generator/internal/utils"
+)
+
+type Data struct {
+	Name                    string
+	KinesisStreamARN        string
+	BucketARN               string
+	Prefix                  string
+	ErrorOutputPrefix       string
+	BufferingSize           int
+	BufferingInterval       int
+	Compression             string
+	DynamicPartitioning     *DynamicPartitioningData
+	TransformationLambdaARN string
+	Tags                    string
+}
+
+type DynamicPartitioningData struct {
+	RetryDuration           int
+	MetadataExtractionQuery string
+}
+
+type Firehose struct {
+	yamlParser *config.YAML
+	output     string
+}
+
+func NewFirehose(yamlParser *config.YAML, output string) *Firehose {
+	return &Firehose{yamlParser: yamlParser, output: output}
+}
+
+func (f *Firehose) Build() error {
+	yamlConfig, err := f.yamlParser.Parse()
+	if err != nil {
+		return fmt.Errorf("%w: %w", generatorserrs.ErrYAMLParser, err)
+	}
+
+	modPath := path.Join(f.output, "mod")
+	_ = os.MkdirAll(modPath, os.ModePerm)
+
+	result := make([]string, 0, len(yamlConfig.Firehoses))
+
+	templates := utils.MergeStringMap(defaultTfTemplateFiles,
+		generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.Firehose))
+
+	tg := generators.NewGenerator(yamlConfig)
+
+	for i := range yamlConfig.Firehoses {
+		conf := yamlConfig.Firehoses[i]
+
+		tags, err := generators.BuildTags(yamlConfig, conf.Name)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		data := buildData(&conf, yamlConfig.Naming)
+		data.Tags = tags
+
+		if len(conf.Files) > 0 {
+			filesConf := generators.CreateFilesMap(conf.Files)
+
+			generators.MustGenerateFiles(tg, nil, filesConf, data, modPath)
+
+			fmtcolor.White.Printf("Firehose '%s' has been generated successfully\n", conf.Name)
+
+			continue
+		}
+
+		output, err := tg.Build(data, "firehose-tf-template", templates[filenameFirehoseTf])
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		result = append(result, output)
+	}
+
+	if len(result) > 0 {
+		outputFile := path.Join(modPath, filenameFirehoseTf)
+
+		generators.MustGenerateFile(tg, nil, filenameFirehoseTf, strings.Join(result, "\n"), outputFile, Data{})
+
+		fmtcolor.White.Println("Firehose has been generated successfully")
+	}
+
+	return nil
+}
+
+func buildData(conf *config.Firehose, naming config.Naming) Data {
+	data := Data{
+		Name:              conf.Name,
+		BucketARN:         fmt.Sprintf("aws_s3_bucket.%s.arn", naming.Label(awsresources.S3Type, conf.Bucket)),
+		Prefix:            conf.Prefix,
+		ErrorOutputPrefix: conf.ErrorOutputPrefix,
+		BufferingSize:     defaultBufferingSize,
+		BufferingInterval: defaultBufferingInterval,
+		Compression:       conf.Compression,
+	}
+
+	// Without a Kinesis source the delivery stream accepts direct PUT requests.
+	if conf.KinesisSource != "" {
+		data.KinesisStreamARN = fmt.Sprintf("aws_kinesis_stream.%s.arn",
+			naming.Label(awsresources.KinesisType, conf.KinesisSource))
+	}
+
+	if conf.TransformationLambda != "" {
+		data.TransformationLambdaARN = fmt.Sprintf("aws_lambda_function.%s.arn",
+			naming.Label(awsresources.LambdaType, conf.TransformationLambda))
+	}
+
+	if data.Compression == "" {
+		data.Compression = defaultCompression
+	}
+
+	if conf.DynamicPartitioning != nil && len(conf.DynamicPartitioning.PartitionKeys) > 0 {
+		data.DynamicPartitioning = buildDynamicPartitioning(conf.DynamicPartitioning)
+
+		// Dynamic partitioning requires a buffer of at least 64 MB, an error output prefix and a prefix
+		// that uses the partition keys.
+		data.BufferingSize = defaultPartitionedBufferingSize
+
+		if data.ErrorOutputPrefix == "" {
+			data.ErrorOutputPrefix = defaultErrorOutputPrefix
+		}
+
+		if data.Prefix == "" {
+			data.Prefix = buildPartitionedPrefix(conf.DynamicPartitioning.PartitionKeys)
+		}
+	}
+
+	if conf.Buffering != nil {
+		if conf.Buffering.SizeMB > 0 {
+			data.BufferingSize = conf.Buffering.SizeMB
+		}
+
+		if conf.Buffering.IntervalSeconds > 0 {
+			data.BufferingInterval = conf.Buffering.IntervalSeconds
+		}
+	}
+
+	return data
+}
+
+func buildDynamicPartitioning(conf *config.FirehoseDynamicPartitioning) *DynamicPartitioningData {
+	keys := sortedKeys(conf.PartitionKeys)
+
+	queries := make([]string, 0, len(keys))
+	for _, key := range keys {
+		queries = append(queries, fmt.Sprintf("%s:%s", key, conf.PartitionKeys[key]))
+	}
+
+	retryDuration := conf.RetryDurationSeconds
+	if retryDuration == 0 {
+		retryDuration = defaultRetryDuration
+	}
+
+	return &DynamicPartitioningData{
+		RetryDuration:           retryDuration,
+		MetadataExtractionQuery: fmt.Sprintf("%q", "{"+strings.Join(queries, ",")+"}"),
+	}
+}
+
+func buildPartitionedPrefix(partitionKeys map[string]string) string {
+	var sb strings.Builder
+
+	sb.WriteString(defaultPartitionedPrefix)
+
+	for _, key := range sortedKeys(partitionKeys) {
+		sb.WriteString(fmt.Sprintf("%s=!{partitionKeyFromQuery:%s}/", key, key))
+	}
+
+	return sb.String()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,121 @@
+package firehose
+
+import (
+	_ "embed"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testdataFolder = "../testdata"
+	testOutput     = "./testoutput"
+)
+
+func TestFirehose_Build(t *testing.T) {
+	type fields struct {
+		configFileName string
+		output         string
+	}
+
+	tests := []struct {
+		name             string
+		fields           fields
+		extraValidations func(testing.TB, string, error)
+		targetErr        error
+	}{
+		{
+			name: "direct put and kinesis source delivery streams",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "firehose.config.yaml"),
+				output:         path.Join(testOutput, "default"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				firehoseTfData, err := os.ReadFile(path.Join(output, "mod", "firehose.tf"))
+				require.NoError(tb, err)
+
+				content := string(firehoseTfData)
+				require.Contains(tb, content,
+					`resource "aws_kinesis_firehose_delivery_stream" "my_direct_put_firehose_firehose" {`)
+				require.Contains(tb, content, "bucket_arn         = aws_s3_bucket.my_bucket_bucket.arn")
+				require.Contains(tb, content, `compression_format = "UNCOMPRESSED"`)
+				require.Contains(tb, content, "kinesis_stream_arn = aws_kinesis_stream.my_kinesis_kinesis.arn")
+				require.Contains(tb, content, "buffering_size     = 128")
+				require.Contains(tb, content, "buffering_interval = 60")
+				require.Contains(tb, content, `compression_format = "GZIP"`)
+				require.Contains(tb, content, `prefix              = "events/"`)
+				require.Contains(tb, content, `error_output_prefix = "errors/!{firehose:error-output-type}/"`)
+				require.Contains(tb, content, "dynamic_partitioning_configuration {")
+				require.Contains(tb, content,
+					`parameter_value = "${aws_lambda_function.my_transformer_lambda.arn}:$LATEST"`)
+				require.Contains(tb, content,
+					`parameter_value = "{customer_id:.customer_id,event_type:.type}"`)
+			},
+		},
+		{
+			name: "override default template",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "firehose.config.override.default.tmpls.yaml"),
+				output:         path.Join(testOutput, "override"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				require.FileExists(tb, path.Join(output, "mod", "firehose.tf"))
+			},
+		},
+		{
+			name: "at least one firehose customising",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "firehose.config.custom.yaml"),
+				output:         path.Join(testOutput, "one"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				modPath := path.Join(output, "mod")
+				require.FileExists(tb, path.Join(modPath, "firehose.tf"))
+				require.FileExists(tb, path.Join(modPath, "myFirehose.tf"))
+			},
+		},
+		{
+			name: "when yaml parser fails should return an error",
+			fields: fields{
+				configFileName: "",
+				output:         "",
+			},
+			targetErr: generatorserrs.ErrYAMLParser,
+		},
+	}
+
+	defer func() {
+		_ = os.RemoveAll(testOutput)
+	}()
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewFirehose(config.NewYAML(tc.fields.configFileName), tc.fields.output).Build()
+
+			require.ErrorIs(t, err, tc.targetErr)
+
+			if tc.extraValidations != nil {
+				tc.extraValidations(t, tc.fields.output, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package firehose
+
+import (
+	_ "embed"
+	"maps"
+)
+
+const filenameFirehoseTf = "firehose.tf"
+
+const (
+	defaultBufferingSize            = 5
+	defaultPartitionedBufferingSize = 64
+	defaultBufferingInterval        = 300
+	defaultCompression              = "UNCOMPRESSED"
+	defaultErrorOutputPrefix        = "errors/!{firehose:error-output-type}/"
+	defaultRetryDuration            = 300
+	defaultPartitionedPrefix        = "data/"
+)
+
+//go:embed tmpls/firehose.tf.tmpl
+var tmplFirehoseTf []byte
+
+var defaultTfTemplateFiles = map[string]string{
+	filenameFirehoseTf: string(tmplFirehoseTf),
+}
+
+// DefaultTemplates returns the built-in templates of the generator by file name.
+func DefaultTemplates() map[string]string {
+	return maps.Clone(defaultTfTemplateFiles)
+}
@@ -0,0 +1,161 @@
+package generators
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+	"github.com/joselitofilho/aws-terraform-generator/internal/resources"
+)
+
+// triggerFields are the fields of the lambda data and configuration with the triggers of each type.
+var triggerFields = map[string]string{
+	"cron":    "Crons",
+	"kinesis": "KinesisTriggers",
+	"sqs":     "SQSTriggers",
+}
+
+var (
+	hclStringReplacer = strings.NewReplacer(
+		`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`, "${", "$${", "%{", "%%{",
+	)
+	hclTemplateReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+)
+
+// HCLString returns the value as a quoted HCL string. The interpolation and directive sequences are escaped, so the
+// value is kept literally.
+func HCLString(value string) string {
+	return `"` + hclStringReplacer.Replace(value) + `"`
+}
+
+// HCLTemplate returns the value as a quoted HCL string like HCLString, but keeps the interpolation and directive
+// sequences, such as ${var.environment}.
+func HCLTemplate(value string) string {
+	return `"` + hclTemplateReplacer.Replace(value) + `"`
+}
+
+// HCLStrings returns the values as quoted HCL strings.
+func HCLStrings(values []string) []string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, HCLString(value))
+	}
+
+	return quoted
+}
+
+// HCLStringList returns the values as quoted HCL strings separated by commas, the items of an HCL list.
+func HCLStringList(values []string) string {
+	return strings.Join(HCLStrings(values), ", ")
+}
+
+// templateFuncs returns the function library of the templates. The Label and ResourceName functions follow the naming
+// conventions of the configuration, the lookupResource function looks the resources up in the configuration, and the
+// system, incoming and outgoing functions query the whole system.
+func templateFuncs(yamlConfig *config.Config) template.FuncMap {
+	system := newSystem(yamlConfig)
+
+	return template.FuncMap{
+		"default":    defaultValue,
+		"join":       join,
+		"quote":      func(value any) string { return fmt.Sprintf("%q", fmt.Sprint(value)) },
+		"hclString":  func(value any) string { return HCLString(fmt.Sprint(value)) },
+		"jsonencode": jsonencode,
+		"indent":     indent,
+		"required":   required,
+		"hasTrigger": hasTrigger,
+		"Label": func(resourceType, name string) string {
+			return yamlConfig.Naming.Label(resources.ResourceType(resourceType), name)
+		},
+		"ResourceName": func(resourceType, name string) (string, error) {
+			return yamlConfig.Naming.Name(resources.ResourceType(resourceType), name)
+		},
+		"lookupResource": func(resourceType, name string) (config.Resource, error) {
+			return yamlConfig.LookupResource(resources.ResourceType(resourceType), name)
+		},
+		"system":   func() *System { return system },
+		"incoming": system.Incoming,
+		"outgoing": system.Outgoing,
+	}
+}
+
+// defaultValue returns the value, or the default value when the value is empty.
+func defaultValue(defaultVal, value any) any {
+	if isEmpty(value) {
+		return defaultVal
+	}
+
+	return value
+}
+
+// join joins the items of the list with the separator.
+func join(separator string, list any) string {
+	value := reflect.ValueOf(list)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return fmt.Sprint(list)
+	}
+
+	items := make([]string, 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		items = append(items, fmt.Sprint(value.Index(i).Interface()))
+	}
+
+	return strings.Join(items, separator)
+}
+
+func jsonencode(value any) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("%w", err)
+	}
+
+	return string(data), nil
+}
+
+// indent indents every line of the text with the number of spaces.
+func indent(spaces int, text string) string {
+	pad := strings.Repeat(" ", spaces)
+
+	return pad + strings.ReplaceAll(text, "\n", "\n"+pad)
+}
+
+// required returns the value, or fails the rendering with the message when the value is empty.
+func required(message string, value any) (any, error) {
+	if isEmpty(value) {
+		return nil, fmt.Errorf("%w: %s", generatorserrs.ErrRequiredValue, message)
+	}
+
+	return value, nil
+}
+
+// hasTrigger reports whether the lambda, either its data or its configuration, has triggers of the type: cron,
+// kinesis or sqs.
+func hasTrigger(triggerType string, lambda any) bool {
+	value := reflect.Indirect(reflect.ValueOf(lambda))
+	if value.Kind() != reflect.Struct {
+		return false
+	}
+
+	field := value.FieldByName(triggerFields[triggerType])
+
+	return field.IsValid() && field.Kind() == reflect.Slice && field.Len() > 0
+}
+
+func isEmpty(value any) bool {
+	v := reflect.ValueOf(value)
+
+	switch v.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return v.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	default:
+		return v.IsZero()
+	}
+}
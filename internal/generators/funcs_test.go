@@ -0,0 +1,126 @@
+package generators
+
+import (
+	"testing"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGenerator_Funcs(t *testing.T) {
+	yamlConfig := &config.Config{
+		SQSs: []config.SQS{{Name: "orders", MaxReceiveCount: 5}},
+		APIGateways: []config.APIGateway{{
+			StackName: "teststack", Lambdas: []config.APIGatewayLambda{{Name: "receiver", Runtime: "go1.x"}},
+		}},
+		Lambdas: []config.Lambda{{
+			Name: "worker", SQSTriggers: []config.SQSTrigger{{SourceARN: "aws_sqs_queue.orders_sqs.arn"}},
+		}},
+		Partials: map[string]string{"tags": `tags = {{$.Tags}}`, "name": `{{ToSnake .}}`},
+	}
+
+	tests := []struct {
+		name      string
+		tmpl      string
+		data      any
+		want      string
+		targetErr error
+	}{
+		{
+			name: "default",
+			tmpl: `{{default 30 $.Timeout}} {{default "go1.x" $.Runtime}}`,
+			data: struct {
+				Timeout int
+				Runtime string
+			}{Runtime: "python3.12"},
+			want: "30 python3.12",
+		},
+		{
+			name: "join and quote",
+			tmpl: `[{{range $i, $s := $}}{{if $i}}, {{end}}{{quote $s}}{{end}}] {{join ", " $}}`,
+			data: []string{"a", "b"},
+			want: `["a", "b"] a, b`,
+		},
+		{
+			name: "hclString",
+			tmpl: `{{hclString $}}`,
+			data: "say \"hi\" to ${name}\n",
+			want: `"say \"hi\" to $${name}\n"`,
+		},
+		{
+			name: "jsonencode",
+			tmpl: `{{jsonencode $}}`,
+			data: map[string]any{"detail-type": []string{"order"}},
+			want: `{"detail-type":["order"]}`,
+		},
+		{
+			name: "indent",
+			tmpl: `{{indent 2 $}}`,
+			data: "a\nb",
+			want: "  a\n  b",
+		},
+		{
+			name: "required",
+			tmpl: `{{required "the domain is required" $}}`,
+			data: "example.com",
+			want: "example.com",
+		},
+		{
+			name:      "required without value",
+			tmpl:      `{{required "the domain is required" $}}`,
+			data:      "",
+			targetErr: generatorserrs.ErrRequiredValue,
+		},
+		{
+			name: "hasTrigger",
+			tmpl: `{{hasTrigger "sqs" $}} {{hasTrigger "cron" $}}`,
+			data: config.Lambda{SQSTriggers: []config.SQSTrigger{{SourceARN: "arn"}}},
+			want: "true false",
+		},
+		{
+			name: "lookupResource",
+			tmpl: `{{(lookupResource "sqs" "orders").MaxReceiveCount}} {{(lookupResource "lambda" "receiver").Runtime}}`,
+			want: "5 go1.x",
+		},
+		{
+			name:      "lookupResource of an unknown resource",
+			tmpl:      `{{lookupResource "sqs" "payments"}}`,
+			targetErr: config.ErrResourceNotFound,
+		},
+		{
+			name: "incoming and outgoing",
+			tmpl: `{{range incoming "lambda" "worker" "sqs"}}{{.Label}}{{end}} ` +
+				`{{range outgoing "sqs" "orders"}}{{.Type}}.{{.Label}}{{end}}`,
+			want: "orders_sqs lambda.worker_lambda",
+		},
+		{
+			name: "system",
+			tmpl: `{{len (system.Resources "sqs")}} {{(index system.Config.SQSs 0).MaxReceiveCount}}`,
+			want: "1 5",
+		},
+		{
+			name: "partials",
+			tmpl: `{{template "name" "MyQueue"}} {{template "tags" $}}`,
+			data: struct{ Tags string }{Tags: "local.tags"},
+			want: "my_queue tags = local.tags",
+		},
+		{
+			name: "partial overridden by the template",
+			tmpl: `{{define "name"}}{{ToKebab .}}{{end}}{{template "name" "MyQueue"}}`,
+			want: "my-queue",
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewGenerator(yamlConfig).Build(tc.data, "test", tc.tmpl)
+
+			require.ErrorIs(t, err, tc.targetErr)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
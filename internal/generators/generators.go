@@ -1,29 +1,133 @@
 package generators
 
 import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
 	"strings"
-	"text/template"
 
 	templategenerators "github.com/diagram-code-generator/template/pkg/generators"
+	"github.com/ettle/strcase"
 
 	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+	"github.com/joselitofilho/aws-terraform-generator/internal/resources"
 )
 
-// NewGenerator initialises a new instance of templategenerators.TemplateGenerator with additional template functions
-// provided as a template.FuncMap.
-func NewGenerator() *templategenerators.TemplateGenerator {
-	return templategenerators.NewTemplateGenerator(
-		templategenerators.WithExtraFuncs(template.FuncMap{
-			"getFileByName":  func(files map[string]File, name string) File { return files[name] },
-			"getFileImports": func(files map[string]File, name string) []string { return files[name].Imports },
-		}),
-	)
+var (
+	reHCLIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+	// reTemplateDefinition matches the names of the templates defined in a template.
+	reTemplateDefinition = regexp.MustCompile(`\{\{-?\s*(?:define|block)\s+"([^"]+)"`)
+)
+
+// TemplateGenerator is a templategenerators.TemplateGenerator whose templates can use the partials of the
+// configuration with {{template "<name>" .}}.
+type TemplateGenerator struct {
+	*templategenerators.TemplateGenerator
+
+	partials     map[string]string
+	partialNames []string
+}
+
+// NewGenerator initialises a new instance of TemplateGenerator for the configuration, whose templates can use the
+// function library of templateFuncs and the partials of the configuration.
+func NewGenerator(yamlConfig *config.Config) *TemplateGenerator {
+	funcs := templateFuncs(yamlConfig)
+	funcs["getFileByName"] = func(files map[string]File, name string) File { return files[name] }
+	funcs["getFileImports"] = func(files map[string]File, name string) []string { return files[name].Imports }
+
+	names := make([]string, 0, len(yamlConfig.Partials))
+	for name := range yamlConfig.Partials {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return &TemplateGenerator{
+		TemplateGenerator: templategenerators.NewTemplateGenerator(templategenerators.WithExtraFuncs(funcs)),
+		partials:          yamlConfig.Partials,
+		partialNames:      names,
+	}
+}
+
+// Build executes the template with the partials using the data supplied and returns the resulting string.
+func (tg *TemplateGenerator) Build(data any, templateName, templateContent string) (string, error) {
+	output, err := tg.TemplateGenerator.Build(data, templateName, tg.withPartials(templateContent))
+	if err != nil {
+		return "", fmt.Errorf("%w", err)
+	}
+
+	return output, nil
+}
+
+// GenerateFile generates a file using the template with the partials and writes the output to the outputFile.
+func (tg *TemplateGenerator) GenerateFile(
+	templatesMap map[string]string, fileName, fileTmpl, outputFile string, data any,
+) error {
+	if fileTmpl == "" {
+		fileTmpl = templatesMap[fileName]
+	}
+
+	err := tg.TemplateGenerator.GenerateFile(nil, fileName, tg.withPartials(fileTmpl), outputFile, data)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+// GenerateFiles generates the files using the templates with the partials and writes the outputs to the output
+// directory.
+func (tg *TemplateGenerator) GenerateFiles(
+	defaultTemplatesMap, templatesMap map[string]string, data any, output string,
+) error {
+	merged := make(map[string]string, len(defaultTemplatesMap)+len(templatesMap))
+
+	for _, templates := range []map[string]string{defaultTemplatesMap, templatesMap} {
+		for filename, tmpl := range templates {
+			merged[filename] = tg.withPartials(tmpl)
+		}
+	}
+
+	if err := tg.TemplateGenerator.GenerateFiles(nil, merged, data, output); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+// withPartials appends the definitions of the partials to the template, so the line numbers of the template stay the
+// same. The template overrides the partials it defines itself.
+func (tg *TemplateGenerator) withPartials(tmpl string) string {
+	if tmpl == "" || len(tg.partialNames) == 0 {
+		return tmpl
+	}
+
+	defined := map[string]bool{}
+	for _, match := range reTemplateDefinition.FindAllStringSubmatch(tmpl, -1) {
+		defined[match[1]] = true
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(tmpl)
+
+	for _, name := range tg.partialNames {
+		if !defined[name] {
+			sb.WriteString(fmt.Sprintf("{{define %q}}%s{{end}}", name, tg.partials[name]))
+		}
+	}
+
+	return sb.String()
 }
 
 // MustGenerateFile generates a single file using the provided template. It logs any errors encountered during the
 // generation process, except for the ErrUnsupportedFileType error, which is ignored.
-func MustGenerateFile(tg *templategenerators.TemplateGenerator,
+func MustGenerateFile(tg *TemplateGenerator,
 	templatesMap map[string]string, fileName, fileTmpl, outputFile string, data any,
 ) {
 	err := tg.GenerateFile(templatesMap, fileName, fileTmpl, outputFile, data)
@@ -34,7 +138,7 @@ func MustGenerateFile(tg *templategenerators.TemplateGenerator,
 
 // MustGenerateFiles generates multiple files at once using the provided templates. It logs any errors encountered
 // during the generation process.
-func MustGenerateFiles(tg *templategenerators.TemplateGenerator,
+func MustGenerateFiles(tg *TemplateGenerator,
 	defaultTemplatesMap map[string]string, filesMap map[string]File, data any, output string,
 ) {
 	templatesMap := map[string]string{}
@@ -91,3 +195,310 @@ func FilterTemplatesMap(filter string, templatesMap map[string]string) map[strin
 
 	return filtred
 }
+
+// BuildLambdaSettings converts the runtime settings of a Lambda function into the values rendered by the templates.
+// Settings that are not configured are left empty, so the templates fall back to the provider or module defaults.
+func BuildLambdaSettings(conf *config.LambdaSettings) LambdaSettings {
+	settings := LambdaSettings{
+		MemorySize:             conf.MemorySize,
+		Timeout:                conf.Timeout,
+		EphemeralStorage:       conf.EphemeralStorage,
+		Layers:                 strings.Join(conf.Layers, ", "),
+		ReservedConcurrency:    conf.ReservedConcurrency,
+		ProvisionedConcurrency: conf.ProvisionedConcurrency,
+	}
+
+	if conf.Architecture != "" {
+		settings.Architectures = fmt.Sprintf("%q", conf.Architecture)
+	}
+
+	if conf.VPC != nil {
+		settings.VPC = &LambdaVPC{
+			SubnetIDs:        strings.Join(conf.VPC.SubnetIDs, ", "),
+			SecurityGroupIDs: strings.Join(conf.VPC.SecurityGroupIDs, ", "),
+		}
+	}
+
+	return settings
+}
+
+// LambdaEnvironmentSettings are the runtime settings of a Lambda function that may differ between the environments.
+var LambdaEnvironmentSettings = []EnvironmentSetting[LambdaSettings]{
+	{
+		Name: "memory_size", Type: "number",
+		Value: func(s *LambdaSettings) string { return OptionalNumber(s.MemorySize) },
+		Var:   func(s *LambdaSettings) *string { return &s.MemorySizeVar },
+	},
+	{
+		Name: "timeout", Type: "number",
+		Value: func(s *LambdaSettings) string { return OptionalNumber(s.Timeout) },
+		Var:   func(s *LambdaSettings) *string { return &s.TimeoutVar },
+	},
+}
+
+// BuildLambdaSecrets resolves the secrets read by a Lambda function and the databases it connects to from the
+// configured ones. Secrets Manager secrets are exposed through their ARN and SSM parameters through their name.
+func BuildLambdaSecrets(lambdaName string, secretNames, rdsNames []string, yamlConfig *config.Config,
+) (LambdaSecrets, error) {
+	secretByName := make(map[string]*config.Secret, len(yamlConfig.Secrets))
+	for i := range yamlConfig.Secrets {
+		secretByName[yamlConfig.Secrets[i].Name] = &yamlConfig.Secrets[i]
+	}
+
+	rdsByName := make(map[string]*config.RDS, len(yamlConfig.RDS))
+	for i := range yamlConfig.RDS {
+		rdsByName[yamlConfig.RDS[i].Name] = &yamlConfig.RDS[i]
+	}
+
+	result := LambdaSecrets{
+		Secrets:        make([]LambdaSecret, 0, len(secretNames)+len(rdsNames)),
+		databaseEnvars: map[string]string{},
+	}
+
+	var secretARNs, parameterARNs, kmsKeyIDs []string
+
+	for _, name := range secretNames {
+		secret, ok := secretByName[name]
+		if !ok {
+			return LambdaSecrets{}, fmt.Errorf("%w: lambda '%s' reads '%s'", generatorserrs.ErrUnknownSecret,
+				lambdaName, name)
+		}
+
+		lambdaSecret := LambdaSecret{Name: name, FieldName: strcase.ToCamel(name), SSM: secret.IsSSM()}
+
+		if secret.IsSSM() {
+			label := "aws_ssm_parameter." + SecretLabel(secret)
+			lambdaSecret.Envar = fmt.Sprintf("%s_PARAMETER_NAME", strcase.ToSNAKE(name))
+			lambdaSecret.Value = label + ".name"
+			parameterARNs = append(parameterARNs, label+".arn")
+		} else {
+			label := "aws_secretsmanager_secret." + SecretLabel(secret)
+			lambdaSecret.Envar = fmt.Sprintf("%s_SECRET_ARN", strcase.ToSNAKE(name))
+			lambdaSecret.Value = label + ".arn"
+			secretARNs = append(secretARNs, label+".arn")
+		}
+
+		if secret.KMSKeyID != "" && !slices.Contains(kmsKeyIDs, secret.KMSKeyID) {
+			kmsKeyIDs = append(kmsKeyIDs, secret.KMSKeyID)
+		}
+
+		result.Secrets = append(result.Secrets, lambdaSecret)
+	}
+
+	for _, name := range rdsNames {
+		rds, ok := rdsByName[name]
+		if !ok {
+			return LambdaSecrets{}, fmt.Errorf("%w: lambda '%s' connects to '%s'", generatorserrs.ErrUnknownDatabase,
+				lambdaName, name)
+		}
+
+		// A database named ordersDb gets ORDERS_DB_HOST rather than ORDERS_DB_DB_HOST.
+		prefix := strings.TrimSuffix(strcase.ToSNAKE(name), "_DB") + "_DB"
+		label := yamlConfig.Naming.Label(resources.DatabaseType, name)
+
+		resource := "aws_db_instance." + label
+		host, databaseName, user := resource+".address", resource+".db_name", resource+".username"
+
+		if rds.IsAurora() {
+			resource = "aws_rds_cluster." + label
+			host, databaseName, user = resource+".endpoint", resource+".database_name", resource+".master_username"
+		}
+
+		if rds.Proxy != nil {
+			host = fmt.Sprintf("aws_db_proxy.%s.endpoint", label)
+		}
+
+		result.databaseEnvars[prefix+"_HOST"] = host
+		result.databaseEnvars[prefix+"_PORT"] = resource + ".port"
+		result.databaseEnvars[prefix+"_NAME"] = databaseName
+		result.databaseEnvars[prefix+"_USER"] = user
+
+		credentialsARN := fmt.Sprintf("aws_secretsmanager_secret.%s_credentials.arn", label)
+
+		result.Secrets = append(result.Secrets, LambdaSecret{
+			Name:      name,
+			FieldName: strcase.ToCamel(strings.TrimSuffix(strcase.ToSnake(name), "_db") + "_db_credentials"),
+			Envar:     prefix + "_CREDENTIALS_SECRET_ARN",
+			Value:     credentialsARN,
+		})
+
+		secretARNs = append(secretARNs, credentialsARN)
+	}
+
+	result.SecretARNs = strings.Join(secretARNs, ", ")
+	result.ParameterARNs = strings.Join(parameterARNs, ", ")
+	result.KMSKeyIDs = strings.Join(kmsKeyIDs, ", ")
+
+	return result, nil
+}
+
+// ExcludeTemplate returns a copy of the templates map without the given file.
+func ExcludeTemplate(templatesMap map[string]string, filename string) map[string]string {
+	result := make(map[string]string, len(templatesMap))
+
+	for name, tmpl := range templatesMap {
+		if name != filename {
+			result[name] = tmpl
+		}
+	}
+
+	return result
+}
+
+// BuildTags merges the tags of the configuration with the given tags of a resource, the latter taking precedence,
+// and renders them as a Terraform map. It returns an empty string when there are no tags and fails when one of the
+// required tags of the configuration is missing. The values are HCL templates, so they can interpolate variables.
+func BuildTags(yamlConfig *config.Config, resourceName string, resourceTags ...map[string]string) (string, error) {
+	tags := mergeTags(yamlConfig, resourceTags...)
+
+	if err := checkRequiredTags(yamlConfig, resourceName, tags); err != nil {
+		return "", err
+	}
+
+	if len(tags) == 0 {
+		return "", nil
+	}
+
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	names := make([]string, 0, len(keys))
+	width := 0
+
+	for _, key := range keys {
+		name := key
+		if !reHCLIdentifier.MatchString(key) {
+			name = HCLString(key)
+		}
+
+		names = append(names, name)
+		width = max(width, len(name))
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("{\n")
+
+	for i, key := range keys {
+		sb.WriteString(fmt.Sprintf("    %-*s = %s\n", width, names[i], HCLTemplate(tags[key])))
+	}
+
+	sb.WriteString("  }")
+
+	return sb.String(), nil
+}
+
+// ValidateTags checks the required tags of every taggable resource of the configuration without generating anything.
+// The error joins one error per resource missing a required tag.
+func ValidateTags(yamlConfig *config.Config) error {
+	if len(yamlConfig.RequiredTags) == 0 {
+		return nil
+	}
+
+	var errs []error
+
+	check := func(resourceName string, resourceTags ...map[string]string) {
+		if err := checkRequiredTags(yamlConfig, resourceName, mergeTags(yamlConfig, resourceTags...)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for i := range yamlConfig.APIGateways {
+		apiConf := &yamlConfig.APIGateways[i]
+		if apiConf.APIG {
+			check(apiConf.StackName, apiConf.Tags)
+		}
+
+		for j := range apiConf.Lambdas {
+			check(apiConf.Lambdas[j].Name, apiConf.Tags, apiConf.Lambdas[j].Tags)
+		}
+	}
+
+	for i := range yamlConfig.Lambdas {
+		check(yamlConfig.Lambdas[i].Name, yamlConfig.Lambdas[i].Tags)
+	}
+
+	for i := range yamlConfig.SQSs {
+		check(yamlConfig.SQSs[i].Name, yamlConfig.SQSs[i].Tags)
+	}
+
+	for i := range yamlConfig.Kinesis {
+		check(yamlConfig.Kinesis[i].Name, yamlConfig.Kinesis[i].Tags)
+	}
+
+	for i := range yamlConfig.Buckets {
+		check(yamlConfig.Buckets[i].Name, yamlConfig.Buckets[i].Tags)
+	}
+
+	for i := range yamlConfig.SNSs {
+		check(yamlConfig.SNSs[i].Name)
+	}
+
+	for i := range yamlConfig.RDS {
+		check(yamlConfig.RDS[i].Name)
+	}
+
+	for i := range yamlConfig.Secrets {
+		check(yamlConfig.Secrets[i].Name)
+	}
+
+	for i := range yamlConfig.Firehoses {
+		check(yamlConfig.Firehoses[i].Name)
+	}
+
+	for i := range yamlConfig.EventBuses {
+		check(yamlConfig.EventBuses[i].Name)
+	}
+
+	for i := range yamlConfig.StepFunctions {
+		check(yamlConfig.StepFunctions[i].Name)
+	}
+
+	for _, def := range resources.RegisteredResourceTypes() {
+		for _, resource := range yamlConfig.CustomResources[string(def.Type)] {
+			check(resource.GetName())
+		}
+	}
+
+	if yamlConfig.Observability != nil {
+		check("observability")
+	}
+
+	return errors.Join(errs...)
+}
+
+// mergeTags merges the tags of the configuration with the given tags of a resource, the latter taking precedence.
+func mergeTags(yamlConfig *config.Config, resourceTags ...map[string]string) map[string]string {
+	tags := maps.Clone(yamlConfig.Tags)
+	if tags == nil {
+		tags = map[string]string{}
+	}
+
+	for _, t := range resourceTags {
+		maps.Copy(tags, t)
+	}
+
+	return tags
+}
+
+// checkRequiredTags fails when the tags of the resource miss one of the required tags of the configuration.
+func checkRequiredTags(yamlConfig *config.Config, resourceName string, tags map[string]string) error {
+	var missing []string
+
+	for _, key := range yamlConfig.RequiredTags {
+		if tags[key] == "" {
+			missing = append(missing, key)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: '%s' misses %s", generatorserrs.ErrMissingRequiredTag, resourceName,
+			strings.Join(missing, ", "))
+	}
+
+	return nil
+}
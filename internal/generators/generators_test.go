@@ -6,14 +6,14 @@ import (
 	"path"
 	"testing"
 
-	templategenerators "github.com/diagram-code-generator/template/pkg/generators"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
 	"github.com/stretchr/testify/require"
 )
 
 func TestMustGenerateFile(t *testing.T) {
 	type args struct {
-		tg           *templategenerators.TemplateGenerator
+		tg           *TemplateGenerator
 		templatesMap map[string]string
 		fileName     string
 		fileTmpl     string
@@ -32,7 +32,7 @@ func TestMustGenerateFile(t *testing.T) {
 		{
 			name: "successful go file generation and formatting",
 			args: args{
-				tg:           NewGenerator(),
+				tg:           NewGenerator(&config.Config{}),
 				templatesMap: map[string]string{"test.go": "type  My{{.Name}}Struct    struct   {}"},
 				fileName:     "test.go",
 				outputFile:   path.Join(testOutput, "output.go"),
@@ -47,7 +47,7 @@ func TestMustGenerateFile(t *testing.T) {
 		{
 			name: "successful go file generation using extra functions",
 			args: args{
-				tg: NewGenerator(),
+				tg: NewGenerator(&config.Config{}),
 				templatesMap: map[string]string{"lambda.go": "{{getFileByName $.Files \"lambda.go\"}} " +
 					"{{ range getFileImports $.Files \"lambda.go\" }}\"{{ . }}\"{{end}}"},
 				fileName:   "lambda.go",
@@ -67,7 +67,7 @@ func TestMustGenerateFile(t *testing.T) {
 		{
 			name: "when file ext is not supported should log a message and the file will not be generated",
 			args: args{
-				tg:           NewGenerator(),
+				tg:           NewGenerator(&config.Config{}),
 				templatesMap: map[string]string{"test.txt": "Hello, {{.Name}}!"},
 				fileName:     "test.txt",
 				outputFile:   path.Join(testOutput, "output.txt"),
@@ -99,7 +99,7 @@ func TestMustGenerateFile(t *testing.T) {
 
 func TestMustGenerateFiles(t *testing.T) {
 	type args struct {
-		tg                  *templategenerators.TemplateGenerator
+		tg                  *TemplateGenerator
 		defaultTemplatesMap map[string]string
 		filesMap            map[string]File
 		data                any
@@ -116,7 +116,7 @@ func TestMustGenerateFiles(t *testing.T) {
 		{
 			name: "generate single file",
 			args: args{
-				tg: NewGenerator(),
+				tg: NewGenerator(&config.Config{}),
 				defaultTemplatesMap: map[string]string{
 					"template.txt": "Hello, {{.Name}}!",
 				},
@@ -349,3 +349,195 @@ func TestFilterTemplatesMap(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildLambdaSettings(t *testing.T) {
+	reservedConcurrency := 0
+
+	tests := []struct {
+		name string
+		conf *config.LambdaSettings
+		want LambdaSettings
+	}{
+		{
+			name: "empty settings",
+			conf: &config.LambdaSettings{},
+			want: LambdaSettings{},
+		},
+		{
+			name: "all settings",
+			conf: &config.LambdaSettings{
+				MemorySize:             512,
+				Timeout:                30,
+				Architecture:           "arm64",
+				EphemeralStorage:       1024,
+				Layers:                 []string{"aws_lambda_layer_version.common.arn", "var.extension_layer_arn"},
+				ReservedConcurrency:    &reservedConcurrency,
+				ProvisionedConcurrency: 2,
+				VPC: &config.LambdaVPC{
+					SubnetIDs:        []string{"var.subnet_a", "var.subnet_b"},
+					SecurityGroupIDs: []string{"aws_security_group.lambda.id"},
+				},
+			},
+			want: LambdaSettings{
+				MemorySize:             512,
+				Timeout:                30,
+				Architectures:          `"arm64"`,
+				EphemeralStorage:       1024,
+				Layers:                 "aws_lambda_layer_version.common.arn, var.extension_layer_arn",
+				ReservedConcurrency:    &reservedConcurrency,
+				ProvisionedConcurrency: 2,
+				VPC: &LambdaVPC{
+					SubnetIDs:        "var.subnet_a, var.subnet_b",
+					SecurityGroupIDs: "aws_security_group.lambda.id",
+				},
+			},
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			got := BuildLambdaSettings(tc.conf)
+
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestBuildTags(t *testing.T) {
+	type args struct {
+		yamlConfig   *config.Config
+		resourceTags []map[string]string
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		want      string
+		targetErr error
+	}{
+		{
+			name: "no tags",
+			args: args{yamlConfig: &config.Config{}},
+			want: "",
+		},
+		{
+			name: "resource tags override the default tags",
+			args: args{
+				yamlConfig: &config.Config{Tags: map[string]string{"Team": "payments", "Environment": "dev"}},
+				resourceTags: []map[string]string{
+					{"Environment": "${var.environment}"},
+					{"cost-center": "1234", "app:name": "orders"},
+				},
+			},
+			want: "{\n" +
+				"    Environment = \"${var.environment}\"\n" +
+				"    Team        = \"payments\"\n" +
+				"    \"app:name\"  = \"orders\"\n" +
+				"    cost-center = \"1234\"\n" +
+				"  }",
+		},
+		{
+			name: "values are quoted as HCL templates",
+			args: args{
+				yamlConfig: &config.Config{Tags: map[string]string{
+					"Description": "Orders \"API\"\n${var.environment}", "a\"b": "é",
+				}},
+			},
+			want: "{\n" +
+				"    Description = \"Orders \\\"API\\\"\\n${var.environment}\"\n" +
+				"    \"a\\\"b\"      = \"é\"\n" +
+				"  }",
+		},
+		{
+			name: "required tags",
+			args: args{
+				yamlConfig: &config.Config{
+					Tags:         map[string]string{"Team": "payments"},
+					RequiredTags: []string{"Team", "Owner"},
+				},
+				resourceTags: []map[string]string{{"Owner": "john"}},
+			},
+			want: "{\n    Owner = \"john\"\n    Team  = \"payments\"\n  }",
+		},
+		{
+			name: "missing required tags",
+			args: args{
+				yamlConfig: &config.Config{
+					Tags:         map[string]string{"Team": "payments"},
+					RequiredTags: []string{"Team", "Owner", "CostCenter"},
+				},
+			},
+			targetErr: generatorserrs.ErrMissingRequiredTag,
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := BuildTags(tc.args.yamlConfig, "resource", tc.args.resourceTags...)
+
+			require.ErrorIs(t, err, tc.targetErr)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestValidateTags(t *testing.T) {
+	tests := []struct {
+		name       string
+		yamlConfig *config.Config
+		want       []string
+	}{
+		{
+			name:       "no required tags",
+			yamlConfig: &config.Config{SQSs: []config.SQS{{Name: "orders"}}},
+		},
+		{
+			name: "every resource missing a required tag",
+			yamlConfig: &config.Config{
+				Tags:         map[string]string{"Team": "payments"},
+				RequiredTags: []string{"Team", "Owner"},
+				APIGateways: []config.APIGateway{{
+					StackName: "mystack",
+					APIG:      true,
+					Tags:      map[string]string{"Owner": "john"},
+					Lambdas:   []config.APIGatewayLambda{{Name: "orderReader"}},
+				}},
+				Lambdas: []config.Lambda{{Name: "orderProcessor"}, {
+					Name: "orderAuditor", Tags: map[string]string{"Owner": "mary"},
+				}},
+				SQSs:          []config.SQS{{Name: "orders"}},
+				RDS:           []config.RDS{{Name: "ordersDb"}},
+				Observability: &config.Observability{},
+			},
+			want: []string{"'orderProcessor' misses Owner", "'orders' misses Owner", "'ordersDb' misses Owner",
+				"'observability' misses Owner"},
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateTags(tc.yamlConfig)
+
+			if len(tc.want) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, generatorserrs.ErrMissingRequiredTag)
+
+			for _, want := range tc.want {
+				require.Contains(t, err.Error(), want)
+			}
+
+			require.NotContains(t, err.Error(), "mystack")
+			require.NotContains(t, err.Error(), "orderReader")
+			require.NotContains(t, err.Error(), "orderAuditor")
+		})
+	}
+}
@@ -11,29 +11,54 @@ import (
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
 	"github.com/joselitofilho/aws-terraform-generator/internal/utils"
 )
 
 type Data struct {
-	Name            string
-	KMSEncription   bool
-	RetentionPeriod string
-	KMSKeyID        string
+	Name               string
+	KMSEncription      bool
+	RetentionPeriod    string
+	RetentionPeriodVar string
+	KMSKeyID           string
+	StreamMode         string
+	ShardCount         int
+	ShardCountVar      string
+	ShardLevelMetrics  string
+	Consumers          []ConsumerData
+	Tags               string
+}
+
+type ConsumerData struct {
+	Name  string
+	Label string
+}
+
+// environmentSettings are the settings of a stream that may differ between the environments.
+var environmentSettings = []generators.EnvironmentSetting[Data]{
+	{
+		Name: "shard_count", Type: "number",
+		Value: func(d *Data) string { return generators.OptionalNumber(d.ShardCount) },
+		Var:   func(d *Data) *string { return &d.ShardCountVar },
+	},
+	{
+		Name: "retention_period", Type: "number",
+		Value: func(d *Data) string { return d.RetentionPeriod },
+		Var:   func(d *Data) *string { return &d.RetentionPeriodVar },
+	},
 }
 
 type Kinesis struct {
-	configFileName string
-	output         string
+	yamlParser *config.YAML
+	output     string
 }
 
-func NewKinesis(configFileName, output string) *Kinesis {
-	return &Kinesis{configFileName: configFileName, output: output}
+func NewKinesis(yamlParser *config.YAML, output string) *Kinesis {
+	return &Kinesis{yamlParser: yamlParser, output: output}
 }
 
 func (k *Kinesis) Build() error {
-	yamlParser := config.NewYAML(k.configFileName)
-
-	yamlConfig, err := yamlParser.Parse()
+	yamlConfig, err := k.yamlParser.Parse()
 	if err != nil {
 		return fmt.Errorf("%w: %w", generatorserrs.ErrYAMLParser, err)
 	}
@@ -46,18 +71,35 @@ func (k *Kinesis) Build() error {
 	templates := utils.MergeStringMap(defaultTfTemplateFiles,
 		generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.Kinesis))
 
-	tg := generators.NewGenerator()
+	tg := generators.NewGenerator(yamlConfig)
+
+	envVars, err := generators.NewEnvironmentVariables(yamlConfig)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
 
 	for i := range yamlConfig.Kinesis {
 		conf := yamlConfig.Kinesis[i]
 
-		data := Data{
-			Name:            conf.Name,
-			KMSEncription:   conf.KMSKeyID != "",
-			RetentionPeriod: conf.RetentionPeriod,
-			KMSKeyID:        conf.KMSKeyID,
+		tags, err := generators.BuildTags(yamlConfig, conf.Name, conf.Tags)
+		if err != nil {
+			return fmt.Errorf("%w", err)
 		}
 
+		data := buildData(&conf, yamlConfig.Naming)
+		data.Tags = tags
+
+		generators.SetEnvironmentVariables(envVars, &data, yamlConfig.Naming.Label(awsresources.KinesisType, conf.Name),
+			func(envConfig *config.Config) (Data, bool) {
+				for j := range envConfig.Kinesis {
+					if envConfig.Kinesis[j].Name == conf.Name {
+						return buildData(&envConfig.Kinesis[j], envConfig.Naming), true
+					}
+				}
+
+				return Data{}, false
+			}, environmentSettings)
+
 		if len(conf.Files) > 0 {
 			filesConf := generators.CreateFilesMap(conf.Files)
 
@@ -84,5 +126,50 @@ func (k *Kinesis) Build() error {
 		fmtcolor.White.Println("Kinesis has been generated successfully")
 	}
 
+	if err := envVars.Write(tg, k.output, "kinesis"); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
 	return nil
 }
+
+func buildData(conf *config.Kinesis, naming config.Naming) Data {
+	streamLabel := naming.Label(awsresources.KinesisType, conf.Name)
+
+	consumers := make([]ConsumerData, 0, len(conf.Consumers))
+	for _, consumer := range conf.Consumers {
+		consumers = append(consumers, ConsumerData{
+			Name: consumer, Label: generators.KinesisConsumerLabel(streamLabel, consumer),
+		})
+	}
+
+	data := Data{
+		Name:            conf.Name,
+		KMSEncription:   conf.KMSKeyID != "",
+		RetentionPeriod: conf.RetentionPeriod,
+		KMSKeyID:        conf.KMSKeyID,
+		StreamMode:      strings.ToUpper(conf.StreamMode),
+		Consumers:       consumers,
+	}
+
+	if data.StreamMode == "" {
+		data.StreamMode = config.KinesisStreamModeProvisioned
+	}
+
+	// On-demand streams scale automatically and do not accept a shard count.
+	if data.StreamMode == config.KinesisStreamModeProvisioned {
+		data.ShardCount = conf.ShardCount
+		if data.ShardCount == 0 {
+			data.ShardCount = defaultShardCount
+		}
+	}
+
+	metrics := make([]string, 0, len(conf.ShardLevelMetrics))
+	for _, metric := range conf.ShardLevelMetrics {
+		metrics = append(metrics, fmt.Sprintf("%q", metric))
+	}
+
+	data.ShardLevelMetrics = strings.Join(metrics, ", ")
+
+	return data
+}
@@ -6,6 +6,7 @@ import (
 	"path"
 	"testing"
 
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
 
 	"github.com/stretchr/testify/require"
@@ -42,6 +43,50 @@ func TestKinesis_Build(t *testing.T) {
 				require.FileExists(tb, path.Join(output, "mod", "kinesis.tf"))
 			},
 		},
+		{
+			name: "stream modes, shard metrics and consumers",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "kinesis.config.modes.yaml"),
+				output:         path.Join(testOutput, "modes"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				kinesisTfData, err := os.ReadFile(path.Join(output, "mod", "kinesis.tf"))
+				require.NoError(tb, err)
+
+				content := string(kinesisTfData)
+				require.Contains(tb, content, `stream_mode = "ON_DEMAND"`)
+				require.NotContains(tb, content, "shard_count      = 4")
+				require.Contains(tb, content, "shard_count      = 3")
+				require.Contains(tb, content, `shard_level_metrics = ["IncomingBytes", "OutgoingBytes"]`)
+				require.Contains(tb, content,
+					`resource "aws_kinesis_stream_consumer" "my_on_demand_kinesis_kinesis_my_consumer_consumer" {`)
+				require.Contains(tb, content,
+					`resource "aws_kinesis_stream_consumer" "my_provisioned_kinesis_kinesis_my_consumer_consumer" {`)
+				require.Contains(tb, content, "stream_arn = aws_kinesis_stream.my_on_demand_kinesis_kinesis.arn")
+			},
+		},
+		{
+			name: "tags merged with the tags of the configuration",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "kinesis.config.tags.yaml"),
+				output:         path.Join(testOutput, "tags"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				kinesisTfData, err := os.ReadFile(path.Join(output, "mod", "kinesis.tf"))
+				require.NoError(tb, err)
+
+				content := string(kinesisTfData)
+				require.Contains(tb, content, "  tags = {\n    Stream = \"events\"\n    Team   = \"payments\"\n  }")
+			},
+		},
 		{
 			name: "override default template for multiple kinesis",
 			fields: fields{
@@ -107,7 +152,7 @@ func TestKinesis_Build(t *testing.T) {
 		tc := tests[i]
 
 		t.Run(tc.name, func(t *testing.T) {
-			err := NewKinesis(tc.fields.configFileName, tc.fields.output).Build()
+			err := NewKinesis(config.NewYAML(tc.fields.configFileName), tc.fields.output).Build()
 
 			require.ErrorIs(t, err, tc.targetErr)
 
@@ -2,13 +2,21 @@ package kinesis
 
 import (
 	_ "embed"
+	"maps"
 )
 
 const filenameKinesisTf = "kinesis.tf"
 
+const defaultShardCount = 1
+
 //go:embed tmpls/kinesis.tf.tmpl
 var tmplKinesisTf []byte
 
 var defaultTfTemplateFiles = map[string]string{
 	filenameKinesisTf: string(tmplKinesisTf),
 }
+
+// DefaultTemplates returns the built-in templates of the generator by file name.
+func DefaultTemplates() map[string]string {
+	return maps.Clone(defaultTfTemplateFiles)
+}
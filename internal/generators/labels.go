@@ -0,0 +1,40 @@
+package generators
+
+import (
+	"fmt"
+
+	"github.com/ettle/strcase"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+)
+
+// KinesisConsumerLabel returns the Terraform label of an enhanced fan-out consumer. The consumer names are only unique
+// per stream, so the label starts with the label of the stream.
+func KinesisConsumerLabel(streamLabel, consumer string) string {
+	return fmt.Sprintf("%s_%s_consumer", streamLabel, strcase.ToSnake(consumer))
+}
+
+// EventRuleKey returns the start of the Terraform labels of an EventBridge rule and of its targets. The rule names are
+// only unique per bus, so the key starts with the name of the bus.
+func EventRuleKey(busName, ruleName string) string {
+	return fmt.Sprintf("%s_%s", strcase.ToSnake(busName), strcase.ToSnake(ruleName))
+}
+
+// EventRuleLabel returns the Terraform label of an EventBridge rule.
+func EventRuleLabel(busName, ruleName string) string {
+	return EventRuleKey(busName, ruleName) + "_rule"
+}
+
+// SecretLabel returns the Terraform label of a secret, an SSM parameter or a Secrets Manager secret.
+func SecretLabel(secret *config.Secret) string {
+	if secret.IsSSM() {
+		return fmt.Sprintf("%s_parameter", strcase.ToSnake(secret.Name))
+	}
+
+	return fmt.Sprintf("%s_secret", strcase.ToSnake(secret.Name))
+}
+
+// StateMachineLabel returns the Terraform label of a Step Functions state machine.
+func StateMachineLabel(name string) string {
+	return fmt.Sprintf("%s_state_machine", strcase.ToSnake(name))
+}
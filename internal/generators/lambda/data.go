@@ -7,28 +7,56 @@ import (
 )
 
 type KinesisTrigger struct {
-	SourceARN string
+	Label                        string
+	SourceARN                    string
+	Enabled                      bool
+	BatchSize                    int
+	StartingPosition             string
+	ParallelizationFactor        int
+	BisectBatchOnFunctionError   bool
+	OnFailureDestinationARN      string
+	MaximumBatchingWindowSeconds int
+	FilterPatterns               []string
+	ReportBatchItemFailures      bool
 }
 
 type SQSTrigger struct {
-	SourceARN string
+	Label                        string
+	SourceARN                    string
+	Enabled                      bool
+	BatchSize                    int
+	MaximumBatchingWindowSeconds int
+	MaximumConcurrency           int
+	FilterPatterns               []string
+	ReportBatchItemFailures      bool
 }
 
 type Cron struct {
+	// Key is the unique suffix of the cron resources. It is empty for the first unnamed cron of a lambda.
+	Key                string
+	Label              string
 	ScheduleExpression string
 	IsEnabled          string
+	Input              string
+	Scheduler          bool
+	TimeZone           string
 }
 
 type Data struct {
+	generators.LambdaSettings
+	generators.LambdaSecrets
+
 	Name            string
 	AsModule        bool
 	Source          string
 	RoleName        string
 	Runtime         string
 	Description     string
+	Tags            string
 	Envars          map[string]string
 	KinesisTriggers []KinesisTrigger
 	SQSTriggers     []SQSTrigger
 	Crons           []Cron
+	HasSchedules    bool
 	Files           map[string]generators.File
 }
@@ -5,28 +5,30 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"slices"
 	"strings"
 
+	"github.com/ettle/strcase"
+
 	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
 	"github.com/joselitofilho/aws-terraform-generator/internal/utils"
 )
 
 type Lambda struct {
-	configFileName string
-	output         string
+	yamlParser *config.YAML
+	output     string
 }
 
-func NewLambda(configFileName, output string) *Lambda {
-	return &Lambda{configFileName: configFileName, output: output}
+func NewLambda(yamlParser *config.YAML, output string) *Lambda {
+	return &Lambda{yamlParser: yamlParser, output: output}
 }
 
 func (l *Lambda) Build() error {
-	yamlParser := config.NewYAML(l.configFileName)
-
-	yamlConfig, err := yamlParser.Parse()
+	yamlConfig, err := l.yamlParser.Parse()
 	if err != nil {
 		return fmt.Errorf("%w: %w", generatorserrs.ErrYAMLParser, err)
 	}
@@ -37,38 +39,75 @@ func (l *Lambda) Build() error {
 	goTemplates := utils.MergeStringMap(defaultGoTemplatesMap,
 		generators.FilterTemplatesMap(".go", generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.Lambda)))
 
-	tg := generators.NewGenerator()
+	tg := generators.NewGenerator(yamlConfig)
+
+	envVars, err := generators.NewEnvironmentVariables(yamlConfig)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
 
 	for i := range yamlConfig.Lambdas {
 		lambdaConf := yamlConfig.Lambdas[i]
 
-		crons := buildCrons(&lambdaConf)
-		kinesisTriggers := buildKinesisTriggers(&lambdaConf)
+		crons := buildCrons(&lambdaConf, yamlConfig.Naming)
+		kinesisTriggers, err := buildKinesisTriggers(&lambdaConf, yamlConfig)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
 		sqsTriggers := buildSQSTriggers(&lambdaConf)
 
 		filesConf := generators.CreateFilesMap(lambdaConf.Files)
 
 		asModule := strings.Contains(lambdaConf.Source, "git@")
 
-		roleName := lambdaConf.RoleName
-		if roleName == "" {
-			roleName = "iam_for_lambda"
+		defaults := &yamlConfig.LambdaDefaults
+
+		roleName := utils.FirstNonEmpty(lambdaConf.RoleName, defaults.RoleName, defaultRoleName)
+		settings := lambdaConf.LambdaSettings.WithDefaults(defaults.LambdaSettings)
+
+		secrets, err := generators.BuildLambdaSecrets(lambdaConf.Name, lambdaConf.Secrets, lambdaConf.RDS,
+			yamlConfig)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		tags, err := generators.BuildTags(yamlConfig, lambdaConf.Name, lambdaConf.Tags)
+		if err != nil {
+			return fmt.Errorf("%w", err)
 		}
 
 		data := Data{
+			LambdaSettings:  generators.BuildLambdaSettings(&settings),
+			LambdaSecrets:   secrets,
 			Name:            lambdaConf.Name,
 			AsModule:        asModule,
 			Source:          lambdaConf.Source,
 			RoleName:        roleName,
-			Runtime:         lambdaConf.Runtime,
+			Runtime:         utils.FirstNonEmpty(lambdaConf.Runtime, defaults.Runtime),
 			Description:     lambdaConf.Description,
-			Envars:          lambdaConf.Envars,
+			Tags:            tags,
+			Envars:          secrets.MergeEnvars(lambdaConf.Envars),
 			KinesisTriggers: kinesisTriggers,
 			SQSTriggers:     sqsTriggers,
 			Crons:           crons,
+			HasSchedules:    hasSchedules(crons),
 			Files:           filesConf,
 		}
 
+		generators.SetEnvironmentVariables(envVars, &data.LambdaSettings,
+			yamlConfig.Naming.Label(awsresources.LambdaType, lambdaConf.Name),
+			func(envConfig *config.Config) (generators.LambdaSettings, bool) {
+				for j := range envConfig.Lambdas {
+					if envConfig.Lambdas[j].Name == lambdaConf.Name {
+						settings := envConfig.Lambdas[j].LambdaSettings.WithDefaults(envConfig.LambdaDefaults.LambdaSettings)
+						return generators.BuildLambdaSettings(&settings), true
+					}
+				}
+
+				return generators.LambdaSettings{}, false
+			}, generators.LambdaEnvironmentSettings)
+
 		output := path.Join(l.output, "mod")
 		_ = os.MkdirAll(output, os.ModePerm)
 
@@ -81,44 +120,187 @@ func (l *Lambda) Build() error {
 		output = fmt.Sprintf("%s/lambda/%s", l.output, lambdaConf.Name)
 		_ = os.MkdirAll(output, os.ModePerm)
 
-		generators.MustGenerateFiles(tg, goTemplates, filesConf, data, output)
+		lambdaGoTemplates := goTemplates
+		if len(secrets.Secrets) == 0 {
+			lambdaGoTemplates = generators.ExcludeTemplate(goTemplates, filenameGoConfig)
+		}
+
+		generators.MustGenerateFiles(tg, lambdaGoTemplates, filesConf, data, output)
 
 		fmtcolor.White.Printf("Lambda '%s' has been generated successfully\n", lambdaConf.Name)
 	}
 
+	if err := envVars.Write(tg, l.output, "lambda"); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
 	return nil
 }
 
-func buildCrons(lambdaConf *config.Lambda) []Cron {
+// buildCrons returns the crons of the lambda. The EventBridge rules and the EventBridge Scheduler schedules are
+// labelled as the crons of the lambda by the naming conventions, with the name of the cron when it has one.
+func buildCrons(lambdaConf *config.Lambda, naming config.Naming) []Cron {
+	seen := map[string]int{}
+
 	crons := make([]Cron, len(lambdaConf.Crons))
 	for i := range lambdaConf.Crons {
+		cronConf := lambdaConf.Crons[i]
+
+		key := strcase.ToSnake(cronConf.Name)
+
+		seen[key]++
+		if count := seen[key]; count > 1 {
+			key = strings.TrimPrefix(fmt.Sprintf("%s_%d", key, count), "_")
+		}
+
+		label := naming.Label(awsresources.CronType, lambdaConf.Name)
+		if key != "" {
+			label = fmt.Sprintf("%s_%s", label, key)
+		}
+
+		var input string
+		if cronConf.Input != "" {
+			input = generators.HCLString(cronConf.Input)
+		}
+
 		crons[i] = Cron{
-			ScheduleExpression: lambdaConf.Crons[i].ScheduleExpression,
-			IsEnabled:          lambdaConf.Crons[i].IsEnabled,
+			Key:                key,
+			Label:              label,
+			ScheduleExpression: cronConf.ScheduleExpression,
+			IsEnabled:          utils.FirstNonEmpty(cronConf.IsEnabled, "true"),
+			Input:              input,
+			Scheduler:          cronConf.UsesScheduler(),
+			TimeZone:           cronConf.TimeZone,
 		}
 	}
 
 	return crons
 }
 
-func buildKinesisTriggers(lambdaConf *config.Lambda) []KinesisTrigger {
+func hasSchedules(crons []Cron) bool {
+	for i := range crons {
+		if crons[i].Scheduler {
+			return true
+		}
+	}
+
+	return false
+}
+
+func buildKinesisTriggers(lambdaConf *config.Lambda, yamlConfig *config.Config) ([]KinesisTrigger, error) {
+	labels := newTriggerLabels(lambdaConf.Name, kinesisTriggerLabelSuffix)
+
 	kinesisTriggers := make([]KinesisTrigger, len(lambdaConf.KinesisTriggers))
 	for i := range lambdaConf.KinesisTriggers {
+		triggerConf := lambdaConf.KinesisTriggers[i]
+
+		// Enhanced fan-out consumers read the stream through the consumer ARN.
+		sourceARN := triggerConf.SourceARN
+		if triggerConf.Consumer != "" {
+			consumerLabel, err := kinesisConsumerLabel(&triggerConf, yamlConfig)
+			if err != nil {
+				return nil, err
+			}
+
+			sourceARN = fmt.Sprintf("aws_kinesis_stream_consumer.%s.arn", consumerLabel)
+		}
+
+		batchSize := triggerConf.BatchSize
+		if batchSize == 0 {
+			batchSize = defaultBatchSize
+		}
+
+		startingPosition := strings.ToUpper(triggerConf.StartingPosition)
+		if startingPosition == "" {
+			startingPosition = defaultStartingPosition
+		}
+
 		kinesisTriggers[i] = KinesisTrigger{
-			SourceARN: lambdaConf.KinesisTriggers[i].SourceARN,
+			Label:                        labels.next(sourceARN),
+			SourceARN:                    sourceARN,
+			Enabled:                      triggerConf.IsEnabled(),
+			BatchSize:                    batchSize,
+			StartingPosition:             startingPosition,
+			ParallelizationFactor:        triggerConf.ParallelizationFactor,
+			BisectBatchOnFunctionError:   triggerConf.BisectBatchOnFunctionError,
+			OnFailureDestinationARN:      triggerConf.OnFailureDestinationARN,
+			MaximumBatchingWindowSeconds: triggerConf.MaximumBatchingWindowSeconds,
+			FilterPatterns:               generators.HCLStrings(triggerConf.FilterCriteria),
+			ReportBatchItemFailures:      triggerConf.ReportBatchItemFailures,
 		}
 	}
 
-	return kinesisTriggers
+	return kinesisTriggers, nil
+}
+
+// kinesisConsumerLabel returns the label of the consumer of the trigger, which must be declared by the stream of its
+// source ARN.
+func kinesisConsumerLabel(triggerConf *config.KinesisTrigger, yamlConfig *config.Config) (string, error) {
+	streamLabel := awsresources.ParseResourceARN(triggerConf.SourceARN, awsresources.KinesisType).Label
+
+	for i := range yamlConfig.Kinesis {
+		kinesisConf := &yamlConfig.Kinesis[i]
+		if yamlConfig.Naming.Label(awsresources.KinesisType, kinesisConf.Name) != streamLabel {
+			continue
+		}
+
+		if slices.Contains(kinesisConf.Consumers, triggerConf.Consumer) {
+			return generators.KinesisConsumerLabel(streamLabel, triggerConf.Consumer), nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s of %s", generatorserrs.ErrUnknownConsumer, triggerConf.Consumer,
+		triggerConf.SourceARN)
 }
 
 func buildSQSTriggers(lambdaConf *config.Lambda) []SQSTrigger {
+	labels := newTriggerLabels(lambdaConf.Name, sqsTriggerLabelSuffix)
+
 	sqsTriggers := make([]SQSTrigger, len(lambdaConf.SQSTriggers))
 	for i := range lambdaConf.SQSTriggers {
+		triggerConf := lambdaConf.SQSTriggers[i]
+
+		batchSize := triggerConf.BatchSize
+		if batchSize == 0 {
+			batchSize = defaultBatchSize
+		}
+
 		sqsTriggers[i] = SQSTrigger{
-			SourceARN: lambdaConf.SQSTriggers[i].SourceARN,
+			Label:                        labels.next(triggerConf.SourceARN),
+			SourceARN:                    triggerConf.SourceARN,
+			Enabled:                      triggerConf.IsEnabled(),
+			BatchSize:                    batchSize,
+			MaximumBatchingWindowSeconds: triggerConf.MaximumBatchingWindowSeconds,
+			MaximumConcurrency:           triggerConf.MaximumConcurrency,
+			FilterPatterns:               generators.HCLStrings(triggerConf.FilterCriteria),
+			ReportBatchItemFailures:      triggerConf.ReportBatchItemFailures,
 		}
 	}
 
 	return sqsTriggers
 }
+
+// triggerLabels builds the Terraform labels of the event source mappings of a lambda. Each label is derived from
+// the lambda and the event source, and gets a numeric suffix when the same source is mapped more than once.
+type triggerLabels struct {
+	prefix string
+	suffix string
+	seen   map[string]int
+}
+
+func newTriggerLabels(lambdaName, suffix string) *triggerLabels {
+	return &triggerLabels{prefix: strcase.ToSnake(lambdaName), suffix: suffix, seen: map[string]int{}}
+}
+
+func (l *triggerLabels) next(sourceARN string) string {
+	arn := awsresources.ParseResourceARN(sourceARN, awsresources.UnknownType)
+
+	label := fmt.Sprintf("%s_%s_%s", l.prefix, strcase.ToSnake(arn.LabelOrName()), l.suffix)
+
+	l.seen[label]++
+	if count := l.seen[label]; count > 1 {
+		label = fmt.Sprintf("%s_%d", label, count)
+	}
+
+	return label
+}
@@ -6,6 +6,7 @@ import (
 	"path"
 	"testing"
 
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
 
 	"github.com/stretchr/testify/require"
@@ -47,6 +48,344 @@ func TestLambda_Build(t *testing.T) {
 				require.FileExists(tb, path.Join(lambdaPath, "main.go"))
 			},
 		},
+		{
+			name: "kinesis trigger with enhanced fan-out consumer",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "lambda.config.kinesis.yaml"),
+				output:         path.Join(testOutput, "kinesis", "teststack"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				lambdaTfData, err := os.ReadFile(path.Join(output, "mod", "exampleReceiver.tf"))
+				require.NoError(tb, err)
+
+				content := string(lambdaTfData)
+				require.Contains(tb, content,
+					"event_source_arn  = aws_kinesis_stream_consumer.my_on_demand_kinesis_kinesis_my_consumer_consumer.arn")
+				require.Contains(tb, content, "batch_size        = 100")
+				require.Contains(tb, content, `starting_position = "TRIM_HORIZON"`)
+				require.Contains(tb, content, "parallelization_factor = 2")
+				require.Contains(tb, content, "bisect_batch_on_function_error = true")
+				require.Contains(tb, content, "destination_arn = aws_sqs_queue.example_receiver_dlq_sqs.arn")
+			},
+		},
+		{
+			name: "tuned event source mappings with unique names",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "lambda.config.triggers.yaml"),
+				output:         path.Join(testOutput, "triggers", "teststack"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				lambdaTfData, err := os.ReadFile(path.Join(output, "mod", "exampleWorker.tf"))
+				require.NoError(tb, err)
+
+				content := string(lambdaTfData)
+				require.Contains(tb, content,
+					`resource "aws_lambda_event_source_mapping" "example_worker_orders_sqs_trigger" {`)
+				require.Contains(tb, content,
+					`resource "aws_lambda_event_source_mapping" "example_worker_orders_sqs_trigger_2" {`)
+				require.Contains(tb, content,
+					`resource "aws_lambda_event_source_mapping" "example_worker_payments_sqs_trigger" {`)
+				require.Contains(tb, content,
+					`resource "aws_lambda_event_source_mapping" "example_worker_events_kinesis_mapping" {`)
+				require.Contains(tb, content, "batch_size       = 10")
+				require.Contains(tb, content, "enabled          = false")
+				require.Contains(tb, content, "maximum_batching_window_in_seconds = 5")
+				require.Contains(tb, content, "maximum_concurrency = 20")
+				require.Contains(tb, content, `function_response_types = ["ReportBatchItemFailures"]`)
+				require.Contains(tb, content, `pattern = "{\"body\": {\"type\": [\"order\"]}}"`)
+				require.Contains(tb, content, "maximum_batching_window_in_seconds = 10")
+				require.Contains(tb, content, `pattern = "{\"data\": {\"source\": [\"app\"]}}"`)
+			},
+		},
+		{
+			name: "crons following the naming conventions",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "lambda.config.crons.naming.yaml"),
+				output:         path.Join(testOutput, "crons-naming"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				lambdaTfData, err := os.ReadFile(path.Join(output, "mod", "exampleScheduled.tf"))
+				require.NoError(tb, err)
+
+				content := string(lambdaTfData)
+				require.Contains(tb, content, `resource "aws_cloudwatch_event_rule" "example_scheduled_trigger" {`)
+				require.Contains(tb, content, "arn  = aws_lambda_function.fn_example_scheduled.arn")
+				require.Contains(tb, content, `resource "aws_scheduler_schedule" "example_scheduled_trigger_nightly" {`)
+				require.Contains(tb, content, "role_arn = aws_iam_role.fn_example_scheduled_scheduler.arn")
+				require.Contains(tb, content, `resource "aws_iam_role" "fn_example_scheduled_scheduler" {`)
+			},
+		},
+		{
+			name: "multiple crons with unique names",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "lambda.config.crons.yaml"),
+				output:         path.Join(testOutput, "crons"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				lambdaTf := path.Join(output, "mod", "exampleScheduled.tf")
+				require.FileExists(tb, lambdaTf)
+
+				lambdaTfData, err := os.ReadFile(lambdaTf)
+				require.NoError(tb, err)
+
+				content := string(lambdaTfData)
+				require.Contains(tb, content, `resource "aws_cloudwatch_event_rule" "example_scheduled_cron" {`)
+				require.Contains(tb, content, `resource "aws_cloudwatch_event_target" "example_scheduled_cron_target" {`)
+				require.Contains(tb, content, `resource "aws_lambda_permission" "example_scheduled_allow_cron" {`)
+				require.Contains(tb, content, `resource "aws_cloudwatch_event_rule" "example_scheduled_cron_hourly" {`)
+				require.Contains(tb, content, `resource "aws_lambda_permission" "example_scheduled_allow_cron_hourly" {`)
+				require.Contains(tb, content, `statement_id  = "AllowExecutionFromCloudWatchHourly"`)
+				require.Contains(tb, content, `input = "{\"mode\": \"incremental\"}"`)
+				require.Contains(tb, content, `resource "aws_cloudwatch_event_rule" "example_scheduled_cron_2" {`)
+				require.Contains(tb, content, `name                = "runExampleScheduled2"`)
+				require.Contains(tb, content, `resource "aws_scheduler_schedule" "example_scheduled_cron_nightly" {`)
+				require.Contains(tb, content, `schedule_expression_timezone = "Europe/Berlin"`)
+				require.Contains(tb, content, `state               = true ? "ENABLED" : "DISABLED"`)
+				require.Contains(tb, content, "role_arn = aws_iam_role.example_scheduled_lambda_scheduler.arn")
+				require.Contains(tb, content, `resource "aws_iam_role" "example_scheduled_lambda_scheduler" {`)
+				require.Contains(tb, content, `name = "${var.client}-${var.environment}-example-scheduled-scheduler"`)
+			},
+		},
+		{
+			name: "runtime settings inherited from the lambda defaults",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "lambda.config.settings.yaml"),
+				output:         path.Join(testOutput, "settings", "teststack"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				workerTfData, err := os.ReadFile(path.Join(output, "mod", "exampleWorker.tf"))
+				require.NoError(tb, err)
+
+				content := string(workerTfData)
+				require.Contains(tb, content, "role          = aws_iam_role.execute_lambda.arn")
+				require.Contains(tb, content, `runtime = "provided.al2023"`)
+				require.Contains(tb, content, "memory_size = 1024")
+				require.Contains(tb, content, "timeout     = 30")
+				require.Contains(tb, content, `architectures = ["arm64"]`)
+				require.Contains(tb, content, "layers        = [aws_lambda_layer_version.common.arn]")
+				require.Contains(tb, content, "reserved_concurrent_executions = 0")
+				require.Contains(tb, content, "publish = true")
+				require.Contains(tb, content, "size = 2048")
+				require.Contains(tb, content, "subnet_ids         = [var.private_subnet_a]")
+				require.Contains(tb, content, "security_group_ids = [var.lambda_security_group_id]")
+				require.Contains(tb, content,
+					`resource "aws_lambda_provisioned_concurrency_config" "example_worker_provisioned_concurrency" {`)
+				require.Contains(tb, content, "qualifier                         = aws_lambda_function.example_worker_lambda.version")
+
+				moduleTfData, err := os.ReadFile(path.Join(output, "mod", "exampleModule.tf"))
+				require.NoError(tb, err)
+
+				content = string(moduleTfData)
+				require.Contains(tb, content, "lambda_function_memory_size              = 256")
+				require.Contains(tb, content, "lambda_function_timeout                  = 30")
+				require.Contains(tb, content, `lambda_function_architectures            = ["arm64"]`)
+				require.Contains(tb, content, "subnet_ids         = [var.private_subnet_a]")
+				require.NotContains(tb, content, "var.lambda_function_vpc_config")
+				require.NotContains(tb, content, "lambda_function_reserved_concurrent_executions")
+			},
+		},
+		{
+			name: "lambdas reading secrets and parameters",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "secrets.config.yaml"),
+				output:         path.Join(testOutput, "secrets", "teststack"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				processorTfData, err := os.ReadFile(path.Join(output, "mod", "orderProcessor.tf"))
+				require.NoError(tb, err)
+
+				content := string(processorTfData)
+				require.Contains(tb, content, "DB_HOST = var.orders_db_host")
+				require.Contains(tb, content,
+					"ORDERS_DB_PASSWORD_SECRET_ARN = aws_secretsmanager_secret.orders_db_password_secret.arn")
+				require.Contains(tb, content,
+					"REPORTS_API_KEY_PARAMETER_NAME = aws_ssm_parameter.reports_api_key_parameter.name")
+				require.Contains(tb, content, `resource "aws_iam_role_policy" "order_processor_secrets" {`)
+				require.Contains(tb, content, "role = aws_iam_role.iam_for_lambda.id")
+				require.Contains(tb, content, `Action   = ["secretsmanager:GetSecretValue"]
+        Resource = [aws_secretsmanager_secret.orders_db_password_secret.arn]`)
+				require.Contains(tb, content, `Action   = ["ssm:GetParameter"]
+        Resource = [aws_ssm_parameter.reports_api_key_parameter.arn]`)
+				require.Contains(tb, content, `Action   = ["kms:Decrypt"]
+        Resource = [aws_kms_key.secrets.arn]`)
+
+				lambdaPath := path.Join(output, "lambda", "orderProcessor")
+
+				configGoData, err := os.ReadFile(path.Join(lambdaPath, "config.go"))
+				require.NoError(tb, err)
+
+				content = string(configGoData)
+				require.Contains(tb, content, "ordersDbPassword string")
+				require.Contains(tb, content,
+					`conf.ordersDbPassword, err = getSecretValue(ctx, secretsClient, "ORDERS_DB_PASSWORD_SECRET_ARN")`)
+				require.Contains(tb, content,
+					`conf.reportsApiKey, err = getParameterValue(ctx, ssmClient, "REPORTS_API_KEY_PARAMETER_NAME")`)
+
+				lambdaGoData, err := os.ReadFile(path.Join(lambdaPath, "lambda.go"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(lambdaGoData), "return &orderProcessorLambda{config: conf}")
+
+				moduleTfData, err := os.ReadFile(path.Join(output, "mod", "orderModule.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(moduleTfData), `role = "iam_for_lambda"`)
+
+				auditorTfData, err := os.ReadFile(path.Join(output, "mod", "orderAuditor.tf"))
+				require.NoError(tb, err)
+				require.NotContains(tb, string(auditorTfData), "aws_iam_role_policy")
+				require.NoFileExists(tb, path.Join(output, "lambda", "orderAuditor", "config.go"))
+			},
+		},
+		{
+			name: "when a lambda reads an unknown secret should return an error",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "secrets.config.unknown.secret.yaml"),
+				output:         path.Join(testOutput, "unknownsecret", "teststack"),
+			},
+			targetErr: generatorserrs.ErrUnknownSecret,
+		},
+		{
+			name: "lambdas connecting to databases",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "rds.config.yaml"),
+				output:         path.Join(testOutput, "rds", "teststack"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				processorTfData, err := os.ReadFile(path.Join(output, "mod", "orderProcessor.tf"))
+				require.NoError(tb, err)
+
+				content := string(processorTfData)
+				require.Contains(tb, content, "ORDERS_DB_HOST = aws_db_proxy.orders_db_rds.endpoint")
+				require.Contains(tb, content, "ORDERS_DB_PORT = aws_rds_cluster.orders_db_rds.port")
+				require.Contains(tb, content, "ORDERS_DB_NAME = aws_rds_cluster.orders_db_rds.database_name")
+				require.Contains(tb, content, "ORDERS_DB_USER = aws_rds_cluster.orders_db_rds.master_username")
+				require.Contains(tb, content,
+					"ORDERS_DB_CREDENTIALS_SECRET_ARN = aws_secretsmanager_secret.orders_db_rds_credentials.arn")
+				require.Contains(tb, content, "Resource = [aws_secretsmanager_secret.orders_db_rds_credentials.arn]")
+
+				builderTfData, err := os.ReadFile(path.Join(output, "mod", "reportBuilder.tf"))
+				require.NoError(tb, err)
+
+				content = string(builderTfData)
+				require.Contains(tb, content, "REPORTS_DB_HOST = aws_db_instance.reports_db_rds.address")
+				require.Contains(tb, content, "REPORTS_DB_NAME = aws_db_instance.reports_db_rds.db_name")
+
+				configGoData, err := os.ReadFile(path.Join(output, "lambda", "orderProcessor", "config.go"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(configGoData), "ordersDbCredentials string")
+			},
+		},
+		{
+			name: "when a kinesis trigger reads through a consumer of another stream should return an error",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "lambda.config.unknown.consumer.yaml"),
+				output:         path.Join(testOutput, "unknownconsumer", "teststack"),
+			},
+			targetErr: generatorserrs.ErrUnknownConsumer,
+		},
+		{
+			name: "when a lambda connects to an unknown database should return an error",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "lambda.config.unknown.database.yaml"),
+				output:         path.Join(testOutput, "unknowndatabase", "teststack"),
+			},
+			targetErr: generatorserrs.ErrUnknownDatabase,
+		},
+		{
+			name: "tags merged with the tags of the configuration",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "lambda.config.tags.yaml"),
+				output:         path.Join(testOutput, "tags", "teststack"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				lambdaTfData, err := os.ReadFile(path.Join(output, "mod", "exampleReceiver.tf"))
+				require.NoError(tb, err)
+
+				content := string(lambdaTfData)
+				require.Contains(tb, content, "  tags = {\n"+
+					"    Environment = \"${var.environment}\"\n"+
+					"    Owner       = \"john\"\n"+
+					"    Team        = \"payments\"\n"+
+					"    cost-center = \"1234\"\n"+
+					"  }")
+				require.Contains(tb, content, `resource "aws_cloudwatch_event_rule" "example_receiver_cron" {`)
+
+				moduleTfData, err := os.ReadFile(path.Join(output, "mod", "exampleModule.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(moduleTfData), "lambda_function_tags = {")
+				require.Contains(tb, string(moduleTfData), `Owner       = "mary"`)
+			},
+		},
+		{
+			name: "runtime settings that differ between the environments",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "lambda.config.environments.yaml"),
+				output:         path.Join(testOutput, "environments", "teststack"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				lambdaTfData, err := os.ReadFile(path.Join(output, "mod", "myReceiver.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(lambdaTfData), "memory_size = var.my_receiver_lambda_memory_size")
+
+				varsTfData, err := os.ReadFile(path.Join(output, "mod", "lambda_environments.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(varsTfData), "variable \"my_receiver_lambda_memory_size\" {\n"+
+					"  type    = number\n"+
+					"  default = 128\n"+
+					"}")
+
+				devTfvarsData, err := os.ReadFile(path.Join(output, "dev", "lambda.auto.tfvars"))
+				require.NoError(tb, err)
+				require.Equal(tb, "my_receiver_lambda_memory_size = 128\n", string(devTfvarsData))
+
+				prdTfvarsData, err := os.ReadFile(path.Join(output, "prd", "lambda.auto.tfvars"))
+				require.NoError(tb, err)
+				require.Equal(tb, "my_receiver_lambda_memory_size = 1024\n", string(prdTfvarsData))
+			},
+		},
+		{
+			name: "when a lambda misses a required tag should return an error",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "lambda.config.missing.tags.yaml"),
+				output:         path.Join(testOutput, "missingtags", "teststack"),
+			},
+			targetErr: generatorserrs.ErrMissingRequiredTag,
+		},
 		{
 			name: "override default template for multiple lambda",
 			fields: fields{
@@ -100,7 +439,7 @@ func TestLambda_Build(t *testing.T) {
 		tc := tests[i]
 
 		t.Run(tc.name, func(t *testing.T) {
-			err := NewLambda(tc.fields.configFileName, tc.fields.output).Build()
+			err := NewLambda(config.NewYAML(tc.fields.configFileName), tc.fields.output).Build()
 
 			require.ErrorIs(t, err, tc.targetErr)
 
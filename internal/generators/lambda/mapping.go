@@ -2,12 +2,26 @@ package lambda
 
 import (
 	_ "embed"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/utils"
 )
 
 const (
 	filenameTfLambda = "lambda.tf"
 	filenameGoLambda = "lambda.go"
 	filenameGoMain   = "main.go"
+	filenameGoConfig = "config.go"
+)
+
+const (
+	defaultBatchSize        = 1
+	defaultStartingPosition = "LATEST"
+	defaultRoleName         = "iam_for_lambda"
+)
+
+const (
+	kinesisTriggerLabelSuffix = "mapping"
+	sqsTriggerLabelSuffix     = "trigger"
 )
 
 var (
@@ -19,6 +33,9 @@ var (
 
 	//go:embed tmpls/main.go.tmpl
 	mainGoTmpl []byte
+
+	//go:embed tmpls/config.go.tmpl
+	configGoTmpl []byte
 )
 
 var (
@@ -29,5 +46,11 @@ var (
 	defaultGoTemplatesMap = map[string]string{
 		filenameGoLambda: string(lambdaGoTmpl),
 		filenameGoMain:   string(mainGoTmpl),
+		filenameGoConfig: string(configGoTmpl),
 	}
 )
+
+// DefaultTemplates returns the built-in templates of the generator by file name.
+func DefaultTemplates() map[string]string {
+	return utils.MergeStringMap(defaultTfTemplatesMap, defaultGoTemplatesMap)
+}
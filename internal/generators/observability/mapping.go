@@ -0,0 +1,39 @@
+package observability
+
+import (
+	_ "embed"
+	"maps"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+)
+
+const filenameObservabilityTf = "observability.tf"
+
+const (
+	defaultAlarmAction       = "var.alerting_sns_topic_arn"
+	defaultDashboardName     = "local.stack_name"
+	defaultPeriod            = 300
+	defaultEvaluationPeriods = 1
+	// defaultLambdaTimeout is the timeout AWS gives to the functions that do not set it, in seconds.
+	defaultLambdaTimeout = 3
+	// defaultDurationPercent is the percentage of the timeout of a function that raises the duration alarm.
+	defaultDurationPercent = 80
+)
+
+var (
+	defaultLambdaAlarms  = config.LambdaAlarms{Errors: 1, Throttles: 1}
+	defaultSQSAlarms     = config.SQSAlarms{AgeOfOldestMessage: 300, DLQDepth: 1}
+	defaultKinesisAlarms = config.KinesisAlarms{IteratorAge: 60000}
+)
+
+//go:embed tmpls/observability.tf.tmpl
+var tmplObservabilityTf []byte
+
+var defaultTfTemplateFiles = map[string]string{
+	filenameObservabilityTf: string(tmplObservabilityTf),
+}
+
+// DefaultTemplates returns the built-in templates of the generator by file name.
+func DefaultTemplates() map[string]string {
+	return maps.Clone(defaultTfTemplateFiles)
+}
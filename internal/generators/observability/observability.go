@@ -0,0 +1,365 @@
+package observability
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/ettle/strcase"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
+	"github.com/joselitofilho/aws-terraform-generator/internal/utils"
+)
+
+// Alarm represents a CloudWatch metric alarm on a single resource.
+type Alarm struct {
+	Label          string
+	Name           string
+	Description    string
+	Namespace      string
+	MetricName     string
+	Statistic      string
+	Threshold      int
+	DimensionName  string
+	DimensionValue string
+}
+
+// Metric represents a line of a dashboard widget.
+type Metric struct {
+	Namespace      string
+	MetricName     string
+	DimensionName  string
+	DimensionValue string
+}
+
+// Widget represents a metric widget of the dashboard.
+type Widget struct {
+	Title   string
+	Stat    string
+	Metrics []Metric
+}
+
+type Data struct {
+	AlarmActions      string
+	Period            int
+	EvaluationPeriods int
+	Alarms            []Alarm
+	// DashboardName is a Terraform expression. It is empty when the dashboard is disabled.
+	DashboardName string
+	Widgets       []Widget
+	Tags          string
+}
+
+type Observability struct {
+	yamlParser *config.YAML
+	output     string
+}
+
+func NewObservability(yamlParser *config.YAML, output string) *Observability {
+	return &Observability{yamlParser: yamlParser, output: output}
+}
+
+func (o *Observability) Build() error {
+	yamlConfig, err := o.yamlParser.Parse()
+	if err != nil {
+		return fmt.Errorf("%w: %w", generatorserrs.ErrYAMLParser, err)
+	}
+
+	if yamlConfig.Observability == nil {
+		return nil
+	}
+
+	data := buildData(yamlConfig)
+	if len(data.Alarms) == 0 && len(data.Widgets) == 0 {
+		return nil
+	}
+
+	data.Tags, err = generators.BuildTags(yamlConfig, "observability")
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	modPath := path.Join(o.output, "mod")
+	_ = os.MkdirAll(modPath, os.ModePerm)
+
+	templates := utils.MergeStringMap(defaultTfTemplateFiles,
+		generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.Observability))
+
+	tg := generators.NewGenerator(yamlConfig)
+
+	outputFile := path.Join(modPath, filenameObservabilityTf)
+
+	generators.MustGenerateFile(tg, nil, filenameObservabilityTf, templates[filenameObservabilityTf], outputFile, data)
+
+	fmtcolor.White.Println("Observability has been generated successfully")
+
+	return nil
+}
+
+func buildData(yamlConfig *config.Config) Data {
+	conf := yamlConfig.Observability
+
+	data := Data{
+		AlarmActions:      defaultAlarmAction,
+		Period:            conf.Period,
+		EvaluationPeriods: conf.EvaluationPeriods,
+	}
+
+	if len(conf.AlarmActions) > 0 {
+		data.AlarmActions = strings.Join(conf.AlarmActions, ", ")
+	}
+
+	if data.Period == 0 {
+		data.Period = defaultPeriod
+	}
+
+	if data.EvaluationPeriods == 0 {
+		data.EvaluationPeriods = defaultEvaluationPeriods
+	}
+
+	lambdaMetrics := buildLambdaAlarms(yamlConfig, &data)
+	sqsMetrics, dlqMetrics := buildSQSAlarms(yamlConfig, &data)
+	kinesisMetrics := buildKinesisAlarms(yamlConfig, &data)
+
+	if conf.Dashboard.Disabled {
+		return data
+	}
+
+	data.DashboardName = defaultDashboardName
+	if conf.Dashboard.Name != "" {
+		data.DashboardName = fmt.Sprintf("%q", conf.Dashboard.Name)
+	}
+
+	// The widgets are derived from the resources in the configuration, whether their alarms are disabled or not.
+	addWidget := func(title, stat, namespace, metricName string, metrics []Metric) {
+		if len(metrics) == 0 {
+			return
+		}
+
+		widget := Widget{Title: title, Stat: stat, Metrics: make([]Metric, 0, len(metrics))}
+		for _, metric := range metrics {
+			metric.Namespace, metric.MetricName = namespace, metricName
+			widget.Metrics = append(widget.Metrics, metric)
+		}
+
+		data.Widgets = append(data.Widgets, widget)
+	}
+
+	addWidget("Lambda invocations", "Sum", "AWS/Lambda", "Invocations", lambdaMetrics)
+	addWidget("Lambda errors", "Sum", "AWS/Lambda", "Errors", lambdaMetrics)
+	addWidget("Lambda throttles", "Sum", "AWS/Lambda", "Throttles", lambdaMetrics)
+	addWidget("Lambda duration", "Maximum", "AWS/Lambda", "Duration", lambdaMetrics)
+	addWidget("SQS age of oldest message", "Maximum", "AWS/SQS", "ApproximateAgeOfOldestMessage", sqsMetrics)
+	addWidget("SQS dead-letter queue depth", "Maximum", "AWS/SQS", "ApproximateNumberOfMessagesVisible", dlqMetrics)
+	addWidget("Kinesis incoming records", "Sum", "AWS/Kinesis", "IncomingRecords", kinesisMetrics)
+	addWidget("Kinesis iterator age", "Maximum", "AWS/Kinesis", "GetRecords.IteratorAgeMilliseconds", kinesisMetrics)
+
+	return data
+}
+
+// lambdaConfig is a lambda of the lambdas section or of an API Gateway.
+type lambdaConfig struct {
+	name     string
+	source   string
+	settings config.LambdaSettings
+	alarms   *config.LambdaAlarms
+}
+
+// lambdaConfigs returns the lambdas of the lambdas section followed by the ones of the API Gateways.
+func lambdaConfigs(yamlConfig *config.Config) []lambdaConfig {
+	result := make([]lambdaConfig, 0, len(yamlConfig.Lambdas))
+
+	for i := range yamlConfig.Lambdas {
+		lambdaConf := &yamlConfig.Lambdas[i]
+		result = append(result, lambdaConfig{
+			name:     lambdaConf.Name,
+			source:   lambdaConf.Source,
+			settings: lambdaConf.LambdaSettings,
+			alarms:   lambdaConf.Alarms,
+		})
+	}
+
+	for i := range yamlConfig.APIGateways {
+		for j := range yamlConfig.APIGateways[i].Lambdas {
+			lambdaConf := &yamlConfig.APIGateways[i].Lambdas[j]
+			result = append(result, lambdaConfig{
+				name:     lambdaConf.Name,
+				source:   lambdaConf.Source,
+				settings: lambdaConf.LambdaSettings,
+				alarms:   lambdaConf.Alarms,
+			})
+		}
+	}
+
+	return result
+}
+
+// alarmName returns the name of an alarm in AWS. The names are unique per account and region, so they start with the
+// name of the stack.
+func alarmName(resourceName, suffix string) string {
+	return fmt.Sprintf("${local.stack_name}-%s-%s", strcase.ToKebab(resourceName), suffix)
+}
+
+// buildLambdaAlarms adds the alarms of the lambdas, including the ones of the API Gateways, and returns their
+// dashboard metrics. Lambdas deployed as modules are skipped because the modules ship their own alarms.
+func buildLambdaAlarms(yamlConfig *config.Config, data *Data) []Metric {
+	defaults := yamlConfig.Observability.Lambda.WithDefaults(defaultLambdaAlarms)
+
+	var metrics []Metric
+
+	for _, lambdaConf := range lambdaConfigs(yamlConfig) {
+		if strings.Contains(lambdaConf.source, "git@") {
+			continue
+		}
+
+		label := yamlConfig.Naming.Label(awsresources.LambdaType, lambdaConf.name)
+		metric := Metric{
+			DimensionName:  "FunctionName",
+			DimensionValue: fmt.Sprintf("aws_lambda_function.%s.function_name", label),
+		}
+
+		metrics = append(metrics, metric)
+
+		alarms := defaults
+		if lambdaConf.alarms != nil {
+			alarms = lambdaConf.alarms.WithDefaults(defaults)
+		}
+
+		if defaults.Disabled || alarms.Disabled {
+			continue
+		}
+
+		if alarms.Duration == 0 {
+			timeout := lambdaConf.settings.WithDefaults(yamlConfig.LambdaDefaults.LambdaSettings).Timeout
+			if timeout == 0 {
+				timeout = defaultLambdaTimeout
+			}
+
+			alarms.Duration = timeout * 1000 * defaultDurationPercent / 100
+		}
+
+		add := func(suffix, description, metricName, statistic string, threshold int) {
+			data.Alarms = append(data.Alarms, Alarm{
+				Label:          fmt.Sprintf("%s_%s_alarm", label, strcase.ToSnake(suffix)),
+				Name:           alarmName(lambdaConf.name, suffix),
+				Description:    fmt.Sprintf("%s of the %s Lambda function", description, lambdaConf.name),
+				Namespace:      "AWS/Lambda",
+				MetricName:     metricName,
+				Statistic:      statistic,
+				Threshold:      threshold,
+				DimensionName:  metric.DimensionName,
+				DimensionValue: metric.DimensionValue,
+			})
+		}
+
+		add("errors", "Errors", "Errors", "Sum", alarms.Errors)
+		add("throttles", "Throttles", "Throttles", "Sum", alarms.Throttles)
+		add("duration", "Duration", "Duration", "Maximum", alarms.Duration)
+	}
+
+	return metrics
+}
+
+// buildSQSAlarms adds the alarms of the queues and returns the dashboard metrics of the queues and of their
+// dead-letter queues.
+func buildSQSAlarms(yamlConfig *config.Config, data *Data) (queueMetrics, dlqMetrics []Metric) {
+	defaults := yamlConfig.Observability.SQS.WithDefaults(defaultSQSAlarms)
+
+	for i := range yamlConfig.SQSs {
+		sqsConf := &yamlConfig.SQSs[i]
+
+		label := yamlConfig.Naming.Label(awsresources.SQSType, sqsConf.Name)
+		queueMetric := Metric{DimensionName: "QueueName", DimensionValue: fmt.Sprintf("aws_sqs_queue.%s.name", label)}
+		dlqMetric := Metric{DimensionName: "QueueName", DimensionValue: fmt.Sprintf("aws_sqs_queue.%s_dlq.name", label)}
+
+		queueMetrics = append(queueMetrics, queueMetric)
+		if sqsConf.HasDLQ() {
+			dlqMetrics = append(dlqMetrics, dlqMetric)
+		}
+
+		alarms := defaults
+		if sqsConf.Alarms != nil {
+			alarms = sqsConf.Alarms.WithDefaults(defaults)
+		}
+
+		if defaults.Disabled || alarms.Disabled {
+			continue
+		}
+
+		data.Alarms = append(data.Alarms, Alarm{
+			Label:          fmt.Sprintf("%s_age_of_oldest_message_alarm", label),
+			Name:           alarmName(sqsConf.Name, "age-of-oldest-message"),
+			Description:    fmt.Sprintf("Age of the oldest message of the %s queue", sqsConf.Name),
+			Namespace:      "AWS/SQS",
+			MetricName:     "ApproximateAgeOfOldestMessage",
+			Statistic:      "Maximum",
+			Threshold:      alarms.AgeOfOldestMessage,
+			DimensionName:  queueMetric.DimensionName,
+			DimensionValue: queueMetric.DimensionValue,
+		})
+
+		if sqsConf.HasDLQ() {
+			data.Alarms = append(data.Alarms, Alarm{
+				Label:          fmt.Sprintf("%s_dlq_depth_alarm", label),
+				Name:           alarmName(sqsConf.Name, "dlq-depth"),
+				Description:    fmt.Sprintf("Messages in the dead-letter queue of the %s queue", sqsConf.Name),
+				Namespace:      "AWS/SQS",
+				MetricName:     "ApproximateNumberOfMessagesVisible",
+				Statistic:      "Maximum",
+				Threshold:      alarms.DLQDepth,
+				DimensionName:  dlqMetric.DimensionName,
+				DimensionValue: dlqMetric.DimensionValue,
+			})
+		}
+	}
+
+	return queueMetrics, dlqMetrics
+}
+
+// buildKinesisAlarms adds the alarms of the streams and returns their dashboard metrics.
+func buildKinesisAlarms(yamlConfig *config.Config, data *Data) []Metric {
+	defaults := yamlConfig.Observability.Kinesis.WithDefaults(defaultKinesisAlarms)
+
+	var metrics []Metric
+
+	for i := range yamlConfig.Kinesis {
+		kinesisConf := &yamlConfig.Kinesis[i]
+
+		label := yamlConfig.Naming.Label(awsresources.KinesisType, kinesisConf.Name)
+		metric := Metric{
+			DimensionName:  "StreamName",
+			DimensionValue: fmt.Sprintf("aws_kinesis_stream.%s.name", label),
+		}
+
+		metrics = append(metrics, metric)
+
+		alarms := defaults
+		if kinesisConf.Alarms != nil {
+			alarms = kinesisConf.Alarms.WithDefaults(defaults)
+		}
+
+		if defaults.Disabled || alarms.Disabled {
+			continue
+		}
+
+		data.Alarms = append(data.Alarms, Alarm{
+			Label:          fmt.Sprintf("%s_iterator_age_alarm", label),
+			Name:           alarmName(kinesisConf.Name, "iterator-age"),
+			Description:    fmt.Sprintf("Iterator age of the %s stream", kinesisConf.Name),
+			Namespace:      "AWS/Kinesis",
+			MetricName:     "GetRecords.IteratorAgeMilliseconds",
+			Statistic:      "Maximum",
+			Threshold:      alarms.IteratorAge,
+			DimensionName:  metric.DimensionName,
+			DimensionValue: metric.DimensionValue,
+		})
+	}
+
+	return metrics
+}
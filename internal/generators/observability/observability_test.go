@@ -0,0 +1,219 @@
+package observability
+
+import (
+	_ "embed"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testdataFolder = "../testdata"
+	testOutput     = "./testoutput"
+)
+
+func TestObservability_Build(t *testing.T) {
+	type fields struct {
+		configFileName string
+		output         string
+	}
+
+	tests := []struct {
+		name             string
+		fields           fields
+		extraValidations func(testing.TB, string, error)
+		targetErr        error
+	}{
+		{
+			name: "alarms with overridden thresholds and dashboard",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "observability.config.yaml"),
+				output:         path.Join(testOutput, "default"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				observabilityTfData, err := os.ReadFile(path.Join(output, "mod", "observability.tf"))
+				require.NoError(tb, err)
+
+				content := string(observabilityTfData)
+				require.Contains(tb, content,
+					"alarm_actions = [var.alerting_sns_topic_arn, var.pager_sns_topic_arn]")
+				require.Contains(tb, content, "evaluation_periods  = 2")
+				require.Contains(tb, content, "period              = 60")
+
+				require.Contains(tb, content,
+					`resource "aws_cloudwatch_metric_alarm" "order_processor_lambda_errors_alarm" {`)
+				require.Contains(tb, content, `alarm_name        = "${local.stack_name}-order-processor-errors"`)
+				require.Contains(tb, content, "threshold           = 5")
+				require.Contains(tb, content,
+					"FunctionName = aws_lambda_function.order_processor_lambda.function_name")
+				require.Contains(tb, content,
+					`resource "aws_cloudwatch_metric_alarm" "order_processor_lambda_throttles_alarm" {`)
+				require.Contains(tb, content, "threshold           = 8000")
+				require.Contains(tb, content, "threshold           = 10")
+				require.Contains(tb, content, "threshold           = 30000")
+				require.NotContains(tb, content, "order_auditor_lambda_errors_alarm")
+				require.NotContains(tb, content, "order_module")
+
+				require.Contains(tb, content,
+					`resource "aws_cloudwatch_metric_alarm" "order_reader_lambda_throttles_alarm" {`)
+				require.Contains(tb, content, `alarm_name        = "${local.stack_name}-order-reader-throttles"`)
+				require.Contains(tb, content, "threshold           = 4")
+
+				require.Contains(tb, content,
+					`resource "aws_cloudwatch_metric_alarm" "orders_sqs_age_of_oldest_message_alarm" {`)
+				require.Contains(tb, content, "threshold           = 600")
+				require.Contains(tb, content, `alarm_name        = "${local.stack_name}-orders-dlq-depth"`)
+				require.Contains(tb, content, "QueueName = aws_sqs_queue.orders_sqs_dlq.name")
+				require.Contains(tb, content, "threshold           = 3600")
+				require.NotContains(tb, content, "audit_sqs_dlq_depth_alarm")
+
+				require.Contains(tb, content,
+					`resource "aws_cloudwatch_metric_alarm" "order_stream_kinesis_iterator_age_alarm" {`)
+				require.Contains(tb, content, "threshold           = 120000")
+				require.Contains(tb, content, `alarm_name        = "${local.stack_name}-order-stream-iterator-age"`)
+
+				require.Contains(tb, content, `resource "aws_cloudwatch_dashboard" "stack" {`)
+				require.Contains(tb, content, `dashboard_name = "orders-dashboard"`)
+				require.Contains(tb, content, `title  = "Lambda invocations"`)
+				require.Contains(tb, content, `["AWS/Lambda", "Invocations", "FunctionName", `+
+					"aws_lambda_function.order_auditor_lambda.function_name],")
+				require.Contains(tb, content, `["AWS/Lambda", "Invocations", "FunctionName", `+
+					"aws_lambda_function.order_reader_lambda.function_name],")
+				require.Contains(tb, content, `title  = "SQS dead-letter queue depth"`)
+				require.Contains(tb, content, `title  = "Kinesis iterator age"`)
+			},
+		},
+		{
+			name: "labels following the naming conventions",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "observability.config.naming.yaml"),
+				output:         path.Join(testOutput, "naming"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				observabilityTfData, err := os.ReadFile(path.Join(output, "mod", "observability.tf"))
+				require.NoError(tb, err)
+
+				content := string(observabilityTfData)
+				require.Contains(tb, content, `resource "aws_cloudwatch_metric_alarm" "fn_order_processor_errors_alarm" {`)
+				require.Contains(tb, content, "FunctionName = aws_lambda_function.fn_order_processor.function_name")
+				require.Contains(tb, content,
+					`resource "aws_cloudwatch_metric_alarm" "queue_orders_age_of_oldest_message_alarm" {`)
+				require.Contains(tb, content, `resource "aws_cloudwatch_metric_alarm" "queue_orders_dlq_depth_alarm" {`)
+				require.Contains(tb, content, "QueueName = aws_sqs_queue.queue_orders_dlq.name")
+				require.Contains(tb, content,
+					`resource "aws_cloudwatch_metric_alarm" "stream_order_stream_iterator_age_alarm" {`)
+			},
+		},
+		{
+			name: "default thresholds",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "observability.config.defaults.yaml"),
+				output:         path.Join(testOutput, "defaults"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				observabilityTfData, err := os.ReadFile(path.Join(output, "mod", "observability.tf"))
+				require.NoError(tb, err)
+
+				content := string(observabilityTfData)
+				require.Contains(tb, content, "alarm_actions = [var.alerting_sns_topic_arn]")
+				require.Contains(tb, content, "evaluation_periods  = 1")
+				require.Contains(tb, content, "period              = 300")
+				require.Contains(tb, content, "threshold           = 1")
+				require.Contains(tb, content, "threshold           = 2400")
+				require.Contains(tb, content, "threshold           = 60000")
+				require.Contains(tb, content, "dashboard_name = local.stack_name")
+				require.NotContains(tb, content, "SQS")
+			},
+		},
+		{
+			name: "dashboard disabled",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "observability.config.no.dashboard.yaml"),
+				output:         path.Join(testOutput, "nodashboard"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				observabilityTfData, err := os.ReadFile(path.Join(output, "mod", "observability.tf"))
+				require.NoError(tb, err)
+
+				content := string(observabilityTfData)
+				require.Contains(tb, content, "orders_sqs_dlq_depth_alarm")
+				require.NotContains(tb, content, "aws_cloudwatch_dashboard")
+			},
+		},
+		{
+			name: "without observability section",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "secrets.config.yaml"),
+				output:         path.Join(testOutput, "none"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				require.NoFileExists(tb, path.Join(output, "mod", "observability.tf"))
+			},
+		},
+		{
+			name: "override default template",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "observability.config.override.default.tmpls.yaml"),
+				output:         path.Join(testOutput, "override"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				observabilityTfData, err := os.ReadFile(path.Join(output, "mod", "observability.tf"))
+				require.NoError(tb, err)
+				require.Equal(tb,
+					`resource "aws_cloudwatch_metric_alarm" "order_stream_kinesis_iterator_age_alarm" {}`,
+					string(observabilityTfData))
+			},
+		},
+		{
+			name: "when yaml parser fails should return an error",
+			fields: fields{
+				configFileName: "",
+				output:         "",
+			},
+			targetErr: generatorserrs.ErrYAMLParser,
+		},
+	}
+
+	defer func() {
+		_ = os.RemoveAll(testOutput)
+	}()
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewObservability(config.NewYAML(tc.fields.configFileName), tc.fields.output).Build()
+
+			require.ErrorIs(t, err, tc.targetErr)
+
+			if tc.extraValidations != nil {
+				tc.extraValidations(t, tc.fields.output, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package rds
+
+import (
+	_ "embed"
+	"maps"
+)
+
+const filenameRDSTf = "rds.tf"
+
+const (
+	defaultInstances          = 1
+	defaultMinCapacity        = 0.5
+	defaultMaxCapacity        = 2
+	defaultAllocatedStorage   = 20
+	defaultMasterUsername     = "dbadmin"
+	defaultInstanceClass      = "db.t4g.micro"
+	defaultVPCID              = "var.vpc_id"
+	defaultSubnetIDs          = "var.subnet_ids"
+	serverlessInstanceClass   = "db.serverless"
+	engineFamilyMySQL         = "MYSQL"
+	engineFamilyPostgreSQL    = "POSTGRESQL"
+	defaultPortMySQL          = 3306
+	defaultPortPostgreSQL     = 5432
+	postgreSQLEngineSubstring = "postgres"
+)
+
+//go:embed tmpls/rds.tf.tmpl
+var tmplRDSTf []byte
+
+var defaultTfTemplateFiles = map[string]string{
+	filenameRDSTf: string(tmplRDSTf),
+}
+
+// DefaultTemplates returns the built-in templates of the generator by file name.
+func DefaultTemplates() map[string]string {
+	return maps.Clone(defaultTfTemplateFiles)
+}
@@ -0,0 +1,250 @@
+package rds
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/ettle/strcase"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
+	"github.com/joselitofilho/aws-terraform-generator/internal/utils"
+)
+
+type Data struct {
+	Name string
+	// Label is the Terraform label shared by every resource of the database.
+	Label string
+	// Identifier is the name of the database in AWS.
+	Identifier         string
+	Aurora             bool
+	Serverless         bool
+	Engine             string
+	EngineVersion      string
+	InstanceClass      string
+	Instances          int
+	MinCapacity        float64
+	MaxCapacity        float64
+	AllocatedStorage   int
+	DatabaseName       string
+	MasterUsername     string
+	Port               int
+	VPCID              string
+	SubnetIDs          string
+	DeletionProtection bool
+	// LambdaSecurityGroupIDs are the security groups of the lambdas connecting to the database.
+	LambdaSecurityGroupIDs string
+	// LambdaDefaultVPC is true when a lambda connecting to the database has no VPC of its own, so it runs in the
+	// security groups of var.lambda_function_vpc_config.
+	LambdaDefaultVPC bool
+	Proxy            *ProxyData
+	Tags             string
+}
+
+type ProxyData struct {
+	EngineFamily          string
+	IdleClientTimeout     int
+	RequireTLS            bool
+	MaxConnectionsPercent int
+}
+
+type RDS struct {
+	yamlParser *config.YAML
+	output     string
+}
+
+func NewRDS(yamlParser *config.YAML, output string) *RDS {
+	return &RDS{yamlParser: yamlParser, output: output}
+}
+
+func (r *RDS) Build() error {
+	yamlConfig, err := r.yamlParser.Parse()
+	if err != nil {
+		return fmt.Errorf("%w: %w", generatorserrs.ErrYAMLParser, err)
+	}
+
+	modPath := path.Join(r.output, "mod")
+	_ = os.MkdirAll(modPath, os.ModePerm)
+
+	result := make([]string, 0, len(yamlConfig.RDS))
+
+	templates := utils.MergeStringMap(defaultTfTemplateFiles,
+		generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.RDS))
+
+	accessByRDS := lambdaAccessByRDS(yamlConfig)
+
+	tg := generators.NewGenerator(yamlConfig)
+
+	for i := range yamlConfig.RDS {
+		conf := yamlConfig.RDS[i]
+
+		tags, err := generators.BuildTags(yamlConfig, conf.Name)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		data, err := buildData(&conf, yamlConfig.Naming, accessByRDS[conf.Name])
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		data.Tags = tags
+
+		if len(conf.Files) > 0 {
+			filesConf := generators.CreateFilesMap(conf.Files)
+
+			generators.MustGenerateFiles(tg, nil, filesConf, data, modPath)
+
+			fmtcolor.White.Printf("RDS '%s' has been generated successfully\n", conf.Name)
+
+			continue
+		}
+
+		output, err := tg.Build(data, "rds-tf-template", templates[filenameRDSTf])
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		result = append(result, output)
+	}
+
+	if len(result) > 0 {
+		outputFile := path.Join(modPath, filenameRDSTf)
+
+		generators.MustGenerateFile(tg, nil, filenameRDSTf, strings.Join(result, "\n"), outputFile, Data{})
+
+		fmtcolor.White.Println("RDS has been generated successfully")
+	}
+
+	return nil
+}
+
+func buildData(conf *config.RDS, naming config.Naming, access lambdaAccess) (Data, error) {
+	identifier, err := naming.Name(awsresources.DatabaseType, naming.Case(awsresources.DatabaseType, conf.Name))
+	if err != nil {
+		return Data{}, fmt.Errorf("%w", err)
+	}
+
+	data := Data{
+		Name:                   conf.Name,
+		Label:                  naming.Label(awsresources.DatabaseType, conf.Name),
+		Identifier:             identifier,
+		Aurora:                 conf.IsAurora(),
+		Engine:                 conf.GetEngine(),
+		EngineVersion:          conf.EngineVersion,
+		InstanceClass:          conf.InstanceClass,
+		DatabaseName:           utils.FirstNonEmpty(conf.DatabaseName, strcase.ToSnake(conf.Name)),
+		MasterUsername:         utils.FirstNonEmpty(conf.MasterUsername, defaultMasterUsername),
+		Port:                   conf.Port,
+		VPCID:                  utils.FirstNonEmpty(conf.VPCID, defaultVPCID),
+		SubnetIDs:              defaultSubnetIDs,
+		DeletionProtection:     conf.DeletionProtection,
+		LambdaSecurityGroupIDs: strings.Join(access.securityGroupIDs, ", "),
+		LambdaDefaultVPC:       access.defaultVPC,
+	}
+
+	if len(conf.SubnetIDs) > 0 {
+		data.SubnetIDs = fmt.Sprintf("[%s]", strings.Join(conf.SubnetIDs, ", "))
+	}
+
+	isPostgreSQL := strings.Contains(data.Engine, postgreSQLEngineSubstring)
+
+	if data.Port == 0 {
+		data.Port = defaultPortMySQL
+		if isPostgreSQL {
+			data.Port = defaultPortPostgreSQL
+		}
+	}
+
+	if data.Aurora {
+		data.Instances = max(conf.Instances, defaultInstances)
+
+		// Aurora clusters without an instance class run on Serverless v2.
+		if data.InstanceClass == "" {
+			data.Serverless = true
+			data.InstanceClass = serverlessInstanceClass
+			data.MinCapacity = conf.MinCapacity
+			data.MaxCapacity = conf.MaxCapacity
+
+			if data.MinCapacity == 0 {
+				data.MinCapacity = defaultMinCapacity
+			}
+
+			if data.MaxCapacity == 0 {
+				data.MaxCapacity = defaultMaxCapacity
+			}
+		}
+	} else {
+		data.InstanceClass = utils.FirstNonEmpty(data.InstanceClass, defaultInstanceClass)
+		data.AllocatedStorage = max(conf.AllocatedStorage, defaultAllocatedStorage)
+	}
+
+	if conf.Proxy != nil {
+		data.Proxy = &ProxyData{
+			EngineFamily:          engineFamilyMySQL,
+			IdleClientTimeout:     conf.Proxy.IdleClientTimeout,
+			RequireTLS:            conf.Proxy.RequireTLS,
+			MaxConnectionsPercent: conf.Proxy.MaxConnectionsPercent,
+		}
+
+		if isPostgreSQL {
+			data.Proxy.EngineFamily = engineFamilyPostgreSQL
+		}
+	}
+
+	return data, nil
+}
+
+// lambdaAccess are the lambdas connecting to a database.
+type lambdaAccess struct {
+	// securityGroupIDs are the security groups of the lambdas with a VPC of their own.
+	securityGroupIDs []string
+	// defaultVPC is true when a lambda has no VPC of its own.
+	defaultVPC bool
+}
+
+// lambdaAccessByRDS collects the security groups of the lambdas connecting to each database, so that the database
+// lets them in. The lambdas without a VPC run in the security groups of var.lambda_function_vpc_config.
+func lambdaAccessByRDS(yamlConfig *config.Config) map[string]lambdaAccess {
+	result := map[string]lambdaAccess{}
+
+	add := func(rdsNames []string, settings config.LambdaSettings) {
+		settings = settings.WithDefaults(yamlConfig.LambdaDefaults.LambdaSettings)
+
+		for _, name := range rdsNames {
+			access := result[name]
+
+			if settings.VPC == nil {
+				access.defaultVPC = true
+			} else {
+				for _, securityGroupID := range settings.VPC.SecurityGroupIDs {
+					if !slices.Contains(access.securityGroupIDs, securityGroupID) {
+						access.securityGroupIDs = append(access.securityGroupIDs, securityGroupID)
+					}
+				}
+			}
+
+			result[name] = access
+		}
+	}
+
+	for i := range yamlConfig.Lambdas {
+		add(yamlConfig.Lambdas[i].RDS, yamlConfig.Lambdas[i].LambdaSettings)
+	}
+
+	for i := range yamlConfig.APIGateways {
+		for j := range yamlConfig.APIGateways[i].Lambdas {
+			lambdaConf := &yamlConfig.APIGateways[i].Lambdas[j]
+			add(lambdaConf.RDS, lambdaConf.LambdaSettings)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,163 @@
+package rds
+
+import (
+	_ "embed"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testdataFolder = "../testdata"
+	testOutput     = "./testoutput"
+)
+
+func TestRDS_Build(t *testing.T) {
+	type fields struct {
+		configFileName string
+		output         string
+	}
+
+	tests := []struct {
+		name             string
+		fields           fields
+		extraValidations func(testing.TB, string, error)
+		targetErr        error
+	}{
+		{
+			name: "aurora serverless cluster with proxy and rds instance",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "rds.config.yaml"),
+				output:         path.Join(testOutput, "default"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				rdsTfData, err := os.ReadFile(path.Join(output, "mod", "rds.tf"))
+				require.NoError(tb, err)
+
+				content := string(rdsTfData)
+				require.Contains(tb, content, `resource "aws_db_subnet_group" "orders_db_rds" {`)
+				require.Contains(tb, content, "subnet_ids = var.subnet_ids")
+				require.Contains(tb, content, "security_groups = [var.lambda_security_group_id]")
+				require.Contains(tb, content, "self      = true")
+				require.Contains(tb, content, `resource "aws_secretsmanager_secret" "orders_db_rds_credentials" {`)
+				require.Contains(tb, content, `resource "aws_rds_cluster" "orders_db_rds" {`)
+				require.Contains(tb, content, `engine                    = "aurora-postgresql"`)
+				require.Contains(tb, content, "deletion_protection       = true")
+				require.Contains(tb, content, "min_capacity = 0.5")
+				require.Contains(tb, content, "max_capacity = 4")
+				require.Contains(tb, content, `instance_class       = "db.serverless"`)
+				require.Contains(tb, content, `engine_family          = "POSTGRESQL"`)
+				require.Contains(tb, content, "idle_client_timeout    = 1800")
+				require.Contains(tb, content, "max_connections_percent = 90")
+				require.Contains(tb, content,
+					"db_cluster_identifier = aws_rds_cluster.orders_db_rds.cluster_identifier")
+
+				require.Contains(tb, content, "subnet_ids = [var.reports_subnet_a, var.reports_subnet_b]")
+				require.Contains(tb, content, "vpc_id = var.reports_vpc_id")
+				require.Contains(tb, content, "security_groups = [var.reports_security_group_id]")
+				require.Contains(tb, content, `resource "aws_db_instance" "reports_db_rds" {`)
+				require.Contains(tb, content, `instance_class            = "db.t4g.small"`)
+				require.Contains(tb, content, "allocated_storage         = 50")
+				require.Contains(tb, content, `db_name                   = "reports"`)
+				require.Contains(tb, content, `username = "reporter"`)
+				require.Contains(tb, content, "port                      = 3306")
+				require.NotContains(tb, content, `resource "aws_db_proxy" "reports_db_rds" {`)
+			},
+		},
+		{
+			name: "override default template",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "rds.config.override.default.tmpls.yaml"),
+				output:         path.Join(testOutput, "override"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				rdsTfData, err := os.ReadFile(path.Join(output, "mod", "rds.tf"))
+				require.NoError(tb, err)
+				require.Equal(tb, `resource "aws_rds_cluster" "orders_db_rds" {}`, string(rdsTfData))
+			},
+		},
+		{
+			name: "at least one database customising",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "rds.config.custom.yaml"),
+				output:         path.Join(testOutput, "one"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				modPath := path.Join(output, "mod")
+				require.FileExists(tb, path.Join(modPath, "orders_db.tf"))
+
+				rdsTfData, err := os.ReadFile(path.Join(modPath, "rds.tf"))
+				require.NoError(tb, err)
+
+				content := string(rdsTfData)
+				require.Contains(tb, content, `instance_class            = "db.t4g.micro"`)
+				require.Contains(tb, content, "allocated_storage         = 20")
+				require.Contains(tb, content, "port                      = 5432")
+				require.NotContains(tb, content, "security_groups")
+			},
+		},
+		{
+			name: "lambda without vpc should let in the security groups of the default vpc config",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "rds.config.default.vpc.yaml"),
+				output:         path.Join(testOutput, "defaultvpc"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				rdsTfData, err := os.ReadFile(path.Join(output, "mod", "rds.tf"))
+				require.NoError(tb, err)
+
+				content := string(rdsTfData)
+				require.Contains(tb, content,
+					`security_groups = lookup(var.lambda_function_vpc_config, "security_group_ids", [])`)
+				require.NotContains(tb, content, "security_groups = [")
+			},
+		},
+		{
+			name: "when yaml parser fails should return an error",
+			fields: fields{
+				configFileName: "",
+				output:         "",
+			},
+			targetErr: generatorserrs.ErrYAMLParser,
+		},
+	}
+
+	defer func() {
+		_ = os.RemoveAll(testOutput)
+	}()
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewRDS(config.NewYAML(tc.fields.configFileName), tc.fields.output).Build()
+
+			require.ErrorIs(t, err, tc.targetErr)
+
+			if tc.extraValidations != nil {
+				tc.extraValidations(t, tc.fields.output, err)
+			}
+		})
+	}
+}
@@ -2,13 +2,24 @@ package s3
 
 import (
 	_ "embed"
+	"maps"
 )
 
 const filenameS3tf = "s3.tf"
 
+const (
+	defaultObjectOwnership = "BucketOwnerEnforced"
+	expirationRuleID       = "expiration"
+)
+
 //go:embed tmpls/s3.tf.tmpl
 var tmplS3tf []byte
 
 var defaultTfTemplateFiles = map[string]string{
 	filenameS3tf: string(tmplS3tf),
 }
+
+// DefaultTemplates returns the built-in templates of the generator by file name.
+func DefaultTemplates() map[string]string {
+	return maps.Clone(defaultTfTemplateFiles)
+}
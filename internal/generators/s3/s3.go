@@ -11,27 +11,68 @@ import (
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
 	"github.com/joselitofilho/aws-terraform-generator/internal/utils"
 )
 
 type Data struct {
-	Name           string
-	ExpirationDays int
+	Name              string
+	ExpirationDays    int
+	Versioning        bool
+	SSEAlgorithm      string
+	KMSKeyID          string
+	BucketKeyEnabled  bool
+	BlockPublicAccess bool
+	ObjectOwnership   string
+	CORSRules         []CORSRuleData
+	LifecycleRules    []LifecycleRuleData
+	Replication       *ReplicationData
+	Logging           *LoggingData
+	Tags              string
+}
+
+type CORSRuleData struct {
+	AllowedHeaders string
+	AllowedMethods string
+	AllowedOrigins string
+	ExposeHeaders  string
+	MaxAgeSeconds  int
+}
+
+type TransitionData struct {
+	Days         int
+	StorageClass string
+}
+
+type LifecycleRuleData struct {
+	ID                              string
+	Prefix                          string
+	ExpirationDays                  int
+	NoncurrentVersionExpirationDays int
+	Transitions                     []TransitionData
+}
+
+type ReplicationData struct {
+	DestinationBucketARN string
+	StorageClass         string
+}
+
+type LoggingData struct {
+	TargetBucket string
+	TargetPrefix string
 }
 
 type S3 struct {
-	configFileName string
-	output         string
+	yamlParser *config.YAML
+	output     string
 }
 
-func NewS3(configFileName, output string) *S3 {
-	return &S3{configFileName: configFileName, output: output}
+func NewS3(yamlParser *config.YAML, output string) *S3 {
+	return &S3{yamlParser: yamlParser, output: output}
 }
 
 func (s *S3) Build() error {
-	yamlParser := config.NewYAML(s.configFileName)
-
-	yamlConfig, err := yamlParser.Parse()
+	yamlConfig, err := s.yamlParser.Parse()
 	if err != nil {
 		return fmt.Errorf("%w: %w", generatorserrs.ErrYAMLParser, err)
 	}
@@ -44,16 +85,23 @@ func (s *S3) Build() error {
 	templates := utils.MergeStringMap(defaultTfTemplateFiles,
 		generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.S3Bucket))
 
-	tg := generators.NewGenerator()
+	tg := generators.NewGenerator(yamlConfig)
 
 	for i := range yamlConfig.Buckets {
 		conf := yamlConfig.Buckets[i]
 
-		data := Data{
-			Name:           conf.Name,
-			ExpirationDays: conf.ExpirationDays,
+		tags, err := generators.BuildTags(yamlConfig, conf.Name, conf.Tags)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		data, err := buildData(&conf, yamlConfig)
+		if err != nil {
+			return fmt.Errorf("%w", err)
 		}
 
+		data.Tags = tags
+
 		if len(conf.Files) > 0 {
 			filesConf := generators.CreateFilesMap(conf.Files)
 
@@ -82,3 +130,122 @@ func (s *S3) Build() error {
 
 	return nil
 }
+
+func buildData(conf *config.S3, yamlConfig *config.Config) (Data, error) {
+	naming := yamlConfig.Naming
+
+	data := Data{
+		Name:              conf.Name,
+		ExpirationDays:    conf.ExpirationDays,
+		Versioning:        conf.Versioning,
+		BlockPublicAccess: conf.HasPublicAccessBlock(),
+		ObjectOwnership:   conf.ObjectOwnership,
+		CORSRules:         buildCORSRules(conf.CORS),
+		LifecycleRules:    buildLifecycleRules(conf),
+	}
+
+	if data.ObjectOwnership == "" {
+		data.ObjectOwnership = defaultObjectOwnership
+	}
+
+	if conf.Encryption != nil {
+		switch strings.ToLower(conf.Encryption.Type) {
+		case config.S3EncryptionKMS:
+			data.SSEAlgorithm = "aws:kms"
+			data.KMSKeyID = conf.Encryption.KMSKeyID
+			data.BucketKeyEnabled = conf.Encryption.BucketKeyEnabled
+		case config.S3EncryptionS3:
+			data.SSEAlgorithm = "AES256"
+		}
+	}
+
+	if conf.Replication != nil {
+		if err := validateReplication(conf, yamlConfig.Buckets); err != nil {
+			return Data{}, err
+		}
+
+		// Replication requires versioning on the source bucket.
+		data.Versioning = true
+		data.Replication = &ReplicationData{
+			DestinationBucketARN: fmt.Sprintf("aws_s3_bucket.%s.arn",
+				naming.Label(awsresources.S3Type, conf.Replication.DestinationBucket)),
+			StorageClass: conf.Replication.StorageClass,
+		}
+	}
+
+	if conf.Logging != nil {
+		data.Logging = &LoggingData{
+			TargetBucket: fmt.Sprintf("aws_s3_bucket.%s.id", naming.Label(awsresources.S3Type, conf.Logging.TargetBucket)),
+			TargetPrefix: conf.Logging.TargetPrefix,
+		}
+	}
+
+	return data, nil
+}
+
+// validateReplication checks that the destination bucket of the replication is configured and versioned, as AWS
+// rejects the replication otherwise. A replicated destination bucket is versioned as well.
+func validateReplication(conf *config.S3, buckets []config.S3) error {
+	destination := conf.Replication.DestinationBucket
+
+	for i := range buckets {
+		if buckets[i].Name != destination {
+			continue
+		}
+
+		if !buckets[i].Versioning && buckets[i].Replication == nil {
+			return fmt.Errorf("%w: the destination bucket %s of %s is not versioned", generatorserrs.ErrInvalidReplication,
+				destination, conf.Name)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: the destination bucket %s of %s is not configured", generatorserrs.ErrInvalidReplication,
+		destination, conf.Name)
+}
+
+func buildCORSRules(rules []config.S3CORSRule) []CORSRuleData {
+	result := make([]CORSRuleData, 0, len(rules))
+
+	for i := range rules {
+		result = append(result, CORSRuleData{
+			AllowedHeaders: generators.HCLStringList(rules[i].AllowedHeaders),
+			AllowedMethods: generators.HCLStringList(rules[i].AllowedMethods),
+			AllowedOrigins: generators.HCLStringList(rules[i].AllowedOrigins),
+			ExposeHeaders:  generators.HCLStringList(rules[i].ExposeHeaders),
+			MaxAgeSeconds:  rules[i].MaxAgeSeconds,
+		})
+	}
+
+	return result
+}
+
+// buildLifecycleRules returns the lifecycle rules of the bucket. The legacy expiration-days setting becomes the
+// "expiration" rule.
+func buildLifecycleRules(conf *config.S3) []LifecycleRuleData {
+	result := make([]LifecycleRuleData, 0, len(conf.LifecycleRules)+1)
+
+	if conf.ExpirationDays > 0 {
+		result = append(result, LifecycleRuleData{ID: expirationRuleID, ExpirationDays: conf.ExpirationDays})
+	}
+
+	for i := range conf.LifecycleRules {
+		rule := conf.LifecycleRules[i]
+
+		transitions := make([]TransitionData, 0, len(rule.Transitions))
+		for _, transition := range rule.Transitions {
+			transitions = append(transitions, TransitionData{Days: transition.Days, StorageClass: transition.StorageClass})
+		}
+
+		result = append(result, LifecycleRuleData{
+			ID:                              rule.ID,
+			Prefix:                          rule.Prefix,
+			ExpirationDays:                  rule.ExpirationDays,
+			NoncurrentVersionExpirationDays: rule.NoncurrentVersionExpirationDays,
+			Transitions:                     transitions,
+		})
+	}
+
+	return result
+}
@@ -6,6 +6,7 @@ import (
 	"path"
 	"testing"
 
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
 
 	"github.com/stretchr/testify/require"
@@ -90,6 +91,71 @@ func TestS3_Build(t *testing.T) {
 				require.FileExists(tb, path.Join(modPath, "my-third-bucket-s3.tf"))
 			},
 		},
+		{
+			name: "hardening and feature options",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "s3.config.hardening.yaml"),
+				output:         path.Join(testOutput, "hardening"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				s3TfData, err := os.ReadFile(path.Join(output, "mod", "s3.tf"))
+				require.NoError(tb, err)
+
+				content := string(s3TfData)
+				require.NotContains(tb, content, "aws_s3_bucket_acl")
+				require.Contains(tb, content, `resource "aws_s3_bucket_versioning" "my_data_bucket_versioning" {`)
+				require.Contains(tb, content, `sse_algorithm     = "aws:kms"`)
+				require.Contains(tb, content, `sse_algorithm     = "AES256"`)
+				require.Contains(tb, content, `resource "aws_s3_bucket_public_access_block" "my_data_bucket_public_access_block" {`)
+				require.NotContains(tb, content,
+					`resource "aws_s3_bucket_public_access_block" "my_logs_bucket_public_access_block" {`)
+				require.Contains(tb, content, `object_ownership = "BucketOwnerPreferred"`)
+				require.Contains(tb, content, `allowed_origins = ["https://www.example.com"]`)
+				require.Contains(tb, content, `id = "expiration"`)
+				require.Contains(tb, content, `storage_class = "GLACIER"`)
+				require.Contains(tb, content, "bucket = aws_s3_bucket.my_data_replica_bucket.arn")
+				require.Contains(tb, content, "target_bucket = aws_s3_bucket.my_logs_bucket.id")
+			},
+		},
+		{
+			name: "tags merged with the tags of the configuration",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "s3.config.tags.yaml"),
+				output:         path.Join(testOutput, "tags"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				s3TfData, err := os.ReadFile(path.Join(output, "mod", "s3.tf"))
+				require.NoError(tb, err)
+
+				content := string(s3TfData)
+				require.Contains(tb, content, `DataClassification = "internal"`)
+				require.Contains(tb, content, `Team               = "payments"`)
+			},
+		},
+		{
+			name: "when the destination bucket of the replication is not configured should return an error",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "s3.config.replication.unknown.yaml"),
+				output:         path.Join(testOutput, "replicationunknown"),
+			},
+			targetErr: generatorserrs.ErrInvalidReplication,
+		},
+		{
+			name: "when the destination bucket of the replication is not versioned should return an error",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "s3.config.replication.unversioned.yaml"),
+				output:         path.Join(testOutput, "replicationunversioned"),
+			},
+			targetErr: generatorserrs.ErrInvalidReplication,
+		},
 		{
 			name: "when yaml parser fails should return an error",
 			fields: fields{
@@ -108,7 +174,7 @@ func TestS3_Build(t *testing.T) {
 		tc := tests[i]
 
 		t.Run(tc.name, func(t *testing.T) {
-			err := NewS3(tc.fields.configFileName, tc.fields.output).Build()
+			err := NewS3(config.NewYAML(tc.fields.configFileName), tc.fields.output).Build()
 
 			require.ErrorIs(t, err, tc.targetErr)
 
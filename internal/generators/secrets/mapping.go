@@ -0,0 +1,24 @@
+package secrets
+
+import (
+	_ "embed"
+	"maps"
+)
+
+const filenameSecretsTf = "secrets.tf"
+
+// defaultParameterValue is the placeholder of the SSM parameters. Terraform ignores later changes to the value, so the
+// real one is set out of band.
+const defaultParameterValue = "change-me"
+
+//go:embed tmpls/secrets.tf.tmpl
+var tmplSecretsTf []byte
+
+var defaultTfTemplateFiles = map[string]string{
+	filenameSecretsTf: string(tmplSecretsTf),
+}
+
+// DefaultTemplates returns the built-in templates of the generator by file name.
+func DefaultTemplates() map[string]string {
+	return maps.Clone(defaultTfTemplateFiles)
+}
@@ -0,0 +1,111 @@
+package secrets
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+	"github.com/joselitofilho/aws-terraform-generator/internal/utils"
+)
+
+type Data struct {
+	Name                 string
+	Label                string
+	SSM                  bool
+	Description          string
+	KMSKeyID             string
+	RecoveryWindowInDays *int
+	Value                string
+	Tags                 string
+}
+
+type Secrets struct {
+	yamlParser *config.YAML
+	output     string
+}
+
+func NewSecrets(yamlParser *config.YAML, output string) *Secrets {
+	return &Secrets{yamlParser: yamlParser, output: output}
+}
+
+func (s *Secrets) Build() error {
+	yamlConfig, err := s.yamlParser.Parse()
+	if err != nil {
+		return fmt.Errorf("%w: %w", generatorserrs.ErrYAMLParser, err)
+	}
+
+	modPath := path.Join(s.output, "mod")
+	_ = os.MkdirAll(modPath, os.ModePerm)
+
+	result := make([]string, 0, len(yamlConfig.Secrets))
+
+	templates := utils.MergeStringMap(defaultTfTemplateFiles,
+		generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.Secrets))
+
+	tg := generators.NewGenerator(yamlConfig)
+
+	for i := range yamlConfig.Secrets {
+		conf := yamlConfig.Secrets[i]
+
+		tags, err := generators.BuildTags(yamlConfig, conf.Name)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		data := buildData(&conf)
+		data.Tags = tags
+
+		if len(conf.Files) > 0 {
+			filesConf := generators.CreateFilesMap(conf.Files)
+
+			generators.MustGenerateFiles(tg, nil, filesConf, data, modPath)
+
+			fmtcolor.White.Printf("Secret '%s' has been generated successfully\n", conf.Name)
+
+			continue
+		}
+
+		output, err := tg.Build(data, "secrets-tf-template", templates[filenameSecretsTf])
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		result = append(result, output)
+	}
+
+	if len(result) > 0 {
+		outputFile := path.Join(modPath, filenameSecretsTf)
+
+		generators.MustGenerateFile(tg, nil, filenameSecretsTf, strings.Join(result, "\n"), outputFile, Data{})
+
+		fmtcolor.White.Println("Secrets has been generated successfully")
+	}
+
+	return nil
+}
+
+func buildData(conf *config.Secret) Data {
+	data := Data{
+		Name:                 conf.Name,
+		Label:                generators.SecretLabel(conf),
+		SSM:                  conf.IsSSM(),
+		KMSKeyID:             conf.KMSKeyID,
+		RecoveryWindowInDays: conf.RecoveryWindowInDays,
+	}
+
+	if data.SSM {
+		data.Value = defaultParameterValue
+	}
+
+	if conf.Description != "" {
+		data.Description = fmt.Sprintf("%q", conf.Description)
+	}
+
+	return data
+}
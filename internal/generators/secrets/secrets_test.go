@@ -0,0 +1,118 @@
+package secrets
+
+import (
+	_ "embed"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testdataFolder = "../testdata"
+	testOutput     = "./testoutput"
+)
+
+func TestSecrets_Build(t *testing.T) {
+	type fields struct {
+		configFileName string
+		output         string
+	}
+
+	tests := []struct {
+		name             string
+		fields           fields
+		extraValidations func(testing.TB, string, error)
+		targetErr        error
+	}{
+		{
+			name: "secrets manager secrets and ssm parameters",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "secrets.config.yaml"),
+				output:         path.Join(testOutput, "default"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				secretsTfData, err := os.ReadFile(path.Join(output, "mod", "secrets.tf"))
+				require.NoError(tb, err)
+
+				content := string(secretsTfData)
+				require.Contains(tb, content, `resource "aws_secretsmanager_secret" "orders_db_password_secret" {`)
+				require.Contains(tb, content, `name = "ordersDbPassword"`)
+				require.Contains(tb, content, `description = "Password of the orders database"`)
+				require.Contains(tb, content, "kms_key_id = aws_kms_key.secrets.arn")
+				require.Contains(tb, content, "recovery_window_in_days = 0")
+				require.Contains(tb, content, `resource "aws_ssm_parameter" "reports_api_key_parameter" {`)
+				require.Contains(tb, content, `type  = "SecureString"`)
+				require.Contains(tb, content, `value = "change-me"`)
+				require.Contains(tb, content, "ignore_changes = [value]")
+				require.Contains(tb, content, `resource "aws_secretsmanager_secret" "webhook_token_secret" {`)
+			},
+		},
+		{
+			name: "override default template",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "secrets.config.override.default.tmpls.yaml"),
+				output:         path.Join(testOutput, "override"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				secretsTfData, err := os.ReadFile(path.Join(output, "mod", "secrets.tf"))
+				require.NoError(tb, err)
+				require.Equal(tb, `resource "aws_secretsmanager_secret" "orders_db_password_secret" {}`, string(secretsTfData))
+			},
+		},
+		{
+			name: "at least one secret customising",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "secrets.config.custom.yaml"),
+				output:         path.Join(testOutput, "one"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				modPath := path.Join(output, "mod")
+				require.FileExists(tb, path.Join(modPath, "secrets.tf"))
+				require.FileExists(tb, path.Join(modPath, "orders_db_password.tf"))
+			},
+		},
+		{
+			name: "when yaml parser fails should return an error",
+			fields: fields{
+				configFileName: "",
+				output:         "",
+			},
+			targetErr: generatorserrs.ErrYAMLParser,
+		},
+	}
+
+	defer func() {
+		_ = os.RemoveAll(testOutput)
+	}()
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewSecrets(config.NewYAML(tc.fields.configFileName), tc.fields.output).Build()
+
+			require.ErrorIs(t, err, tc.targetErr)
+
+			if tc.extraValidations != nil {
+				tc.extraValidations(t, tc.fields.output, err)
+			}
+		})
+	}
+}
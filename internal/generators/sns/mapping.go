@@ -2,6 +2,7 @@ package sns
 
 import (
 	_ "embed"
+	"maps"
 )
 
 const filenameSNStf = "sns.tf"
@@ -12,3 +13,8 @@ var tmplSNStf []byte
 var defaultTfTemplateFiles = map[string]string{
 	filenameSNStf: string(tmplSNStf),
 }
+
+// DefaultTemplates returns the built-in templates of the generator by file name.
+func DefaultTemplates() map[string]string {
+	return maps.Clone(defaultTfTemplateFiles)
+}
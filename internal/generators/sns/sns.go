@@ -19,6 +19,7 @@ type Data struct {
 	BucketName string
 	Lambdas    []ResourceData
 	SQSs       []ResourceData
+	Tags       string
 }
 
 type ResourceData struct {
@@ -29,18 +30,16 @@ type ResourceData struct {
 }
 
 type SNS struct {
-	configFileName string
-	output         string
+	yamlParser *config.YAML
+	output     string
 }
 
-func NewSNS(configFileName, output string) *SNS {
-	return &SNS{configFileName: configFileName, output: output}
+func NewSNS(yamlParser *config.YAML, output string) *SNS {
+	return &SNS{yamlParser: yamlParser, output: output}
 }
 
 func (s *SNS) Build() error {
-	yamlParser := config.NewYAML(s.configFileName)
-
-	yamlConfig, err := yamlParser.Parse()
+	yamlConfig, err := s.yamlParser.Parse()
 	if err != nil {
 		return fmt.Errorf("%w: %w", generatorserrs.ErrYAMLParser, err)
 	}
@@ -53,14 +52,20 @@ func (s *SNS) Build() error {
 	templates := utils.MergeStringMap(defaultTfTemplateFiles,
 		generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.SNS))
 
-	tg := generators.NewGenerator()
+	tg := generators.NewGenerator(yamlConfig)
 
 	for i := range yamlConfig.SNSs {
 		conf := yamlConfig.SNSs[i]
 
+		tags, err := generators.BuildTags(yamlConfig, conf.Name)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
 		data := Data{
 			Name:       conf.Name,
 			BucketName: conf.BucketName,
+			Tags:       tags,
 		}
 
 		data.Lambdas = buildLambdaResources(&conf)
@@ -6,6 +6,7 @@ import (
 	"path"
 	"testing"
 
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
 
 	"github.com/stretchr/testify/require"
@@ -39,7 +40,17 @@ func TestSNS_Build(t *testing.T) {
 					return
 				}
 
-				require.FileExists(tb, path.Join(output, "mod", "sns.tf"))
+				snsTfData, err := os.ReadFile(path.Join(output, "mod", "sns.tf"))
+				require.NoError(tb, err)
+
+				content := string(snsTfData)
+				require.Contains(tb, content, `resource "aws_s3_bucket_notification" "sns_lambda_notification" {`)
+				require.Contains(tb, content, "bucket = aws_s3_bucket.my_bucket_bucket.id")
+				require.Contains(tb, content,
+					"aws_lambda_permission.sns_lambda_example_receiver_lambda_permission,")
+				require.Contains(tb, content,
+					`resource "aws_lambda_permission" "sns_lambda_example_receiver_lambda_permission" {`)
+				require.Contains(tb, content, `name = "${var.client}-${var.environment}-sns-sqs-s3-to-sqs"`)
 			},
 		},
 		{
@@ -107,7 +118,7 @@ func TestSNS_Build(t *testing.T) {
 		tc := tests[i]
 
 		t.Run(tc.name, func(t *testing.T) {
-			err := NewSNS(tc.fields.configFileName, tc.fields.output).Build()
+			err := NewSNS(config.NewYAML(tc.fields.configFileName), tc.fields.output).Build()
 
 			require.ErrorIs(t, err, tc.targetErr)
 
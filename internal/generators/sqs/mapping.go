@@ -2,13 +2,26 @@ package sqs
 
 import (
 	_ "embed"
+	"maps"
 )
 
 const filenameSQStf = "sqs.tf"
 
+const (
+	defaultVisibilityTimeoutSeconds = 720
+
+	principalEvents = "events.amazonaws.com"
+	principalS3     = "s3.amazonaws.com"
+)
+
 //go:embed tmpls/sqs.tf.tmpl
 var tmplSQStf []byte
 
 var defaultTfTemplateFiles = map[string]string{
 	filenameSQStf: string(tmplSQStf),
 }
+
+// DefaultTemplates returns the built-in templates of the generator by file name.
+func DefaultTemplates() map[string]string {
+	return maps.Clone(defaultTfTemplateFiles)
+}
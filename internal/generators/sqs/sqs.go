@@ -5,33 +5,85 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 
 	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
 	"github.com/joselitofilho/aws-terraform-generator/internal/utils"
 )
 
 type Data struct {
-	Name            string
-	MaxReceiveCount int32
+	Name                         string
+	MaxReceiveCount              int32
+	MaxReceiveCountVar           string
+	FIFO                         bool
+	ContentBasedDeduplication    bool
+	SQSManagedSSE                bool
+	KMSKeyID                     string
+	KMSDataKeyReusePeriodSeconds int
+	MessageRetentionSeconds      int
+	MessageRetentionSecondsVar   string
+	DelaySeconds                 int
+	DelaySecondsVar              string
+	VisibilityTimeoutSeconds     int
+	VisibilityTimeoutSecondsVar  string
+	ReceiveWaitTimeSeconds       int
+	ReceiveWaitTimeSecondsVar    string
+	DLQ                          bool
+	PolicySources                []PolicySourceData
+	Tags                         string
+}
+
+// PolicySourceData represents a service allowed to send messages to the queue through the queue policy.
+type PolicySourceData struct {
+	Principal string
+	SourceARN string
+}
+
+// environmentSettings are the settings of a queue that may differ between the environments.
+var environmentSettings = []generators.EnvironmentSetting[Data]{
+	{
+		Name: "max_receive_count", Type: "number",
+		Value: func(d *Data) string { return strconv.Itoa(int(d.MaxReceiveCount)) },
+		Var:   func(d *Data) *string { return &d.MaxReceiveCountVar },
+	},
+	{
+		Name: "message_retention_seconds", Type: "number",
+		Value: func(d *Data) string { return generators.OptionalNumber(d.MessageRetentionSeconds) },
+		Var:   func(d *Data) *string { return &d.MessageRetentionSecondsVar },
+	},
+	{
+		Name: "delay_seconds", Type: "number",
+		Value: func(d *Data) string { return generators.OptionalNumber(d.DelaySeconds) },
+		Var:   func(d *Data) *string { return &d.DelaySecondsVar },
+	},
+	{
+		Name: "visibility_timeout_seconds", Type: "number",
+		Value: func(d *Data) string { return generators.OptionalNumber(d.VisibilityTimeoutSeconds) },
+		Var:   func(d *Data) *string { return &d.VisibilityTimeoutSecondsVar },
+	},
+	{
+		Name: "receive_wait_time_seconds", Type: "number",
+		Value: func(d *Data) string { return generators.OptionalNumber(d.ReceiveWaitTimeSeconds) },
+		Var:   func(d *Data) *string { return &d.ReceiveWaitTimeSecondsVar },
+	},
 }
 
 type SQS struct {
-	configFileName string
-	output         string
+	yamlParser *config.YAML
+	output     string
 }
 
-func NewSQS(configFileName, output string) *SQS {
-	return &SQS{configFileName: configFileName, output: output}
+func NewSQS(yamlParser *config.YAML, output string) *SQS {
+	return &SQS{yamlParser: yamlParser, output: output}
 }
 
 func (s *SQS) Build() error {
-	yamlParser := config.NewYAML(s.configFileName)
-
-	yamlConfig, err := yamlParser.Parse()
+	yamlConfig, err := s.yamlParser.Parse()
 	if err != nil {
 		return fmt.Errorf("%w: %w", generatorserrs.ErrYAMLParser, err)
 	}
@@ -44,16 +96,39 @@ func (s *SQS) Build() error {
 	templates := utils.MergeStringMap(defaultTfTemplateFiles,
 		generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.SQS))
 
-	tg := generators.NewGenerator()
+	tg := generators.NewGenerator(yamlConfig)
+
+	envVars, err := generators.NewEnvironmentVariables(yamlConfig)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
 
 	for i := range yamlConfig.SQSs {
 		conf := yamlConfig.SQSs[i]
 
-		data := Data{
-			Name:            conf.Name,
-			MaxReceiveCount: conf.MaxReceiveCount,
+		if err := validateSources(&conf, yamlConfig.SNSs); err != nil {
+			return err
 		}
 
+		tags, err := generators.BuildTags(yamlConfig, conf.Name, conf.Tags)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		data := buildData(&conf, yamlConfig.Naming, yamlConfig.SNSs, yamlConfig.EventBuses)
+		data.Tags = tags
+
+		generators.SetEnvironmentVariables(envVars, &data, yamlConfig.Naming.Label(awsresources.SQSType, conf.Name),
+			func(envConfig *config.Config) (Data, bool) {
+				for j := range envConfig.SQSs {
+					if envConfig.SQSs[j].Name == conf.Name {
+						return buildData(&envConfig.SQSs[j], envConfig.Naming, envConfig.SNSs, envConfig.EventBuses), true
+					}
+				}
+
+				return Data{}, false
+			}, environmentSettings)
+
 		if len(conf.Files) > 0 {
 			filesConf := generators.CreateFilesMap(conf.Files)
 
@@ -80,5 +155,117 @@ func (s *SQS) Build() error {
 		fmtcolor.White.Println("SQS has been generated successfully")
 	}
 
+	if err := envVars.Write(tg, s.output, "sqs"); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+func buildData(conf *config.SQS, naming config.Naming, snsConfs []config.SNS, eventBusConfs []config.EventBus) Data {
+	data := Data{
+		Name:                      conf.Name,
+		MaxReceiveCount:           conf.MaxReceiveCount,
+		FIFO:                      conf.FIFO,
+		ContentBasedDeduplication: conf.ContentBasedDeduplication,
+		MessageRetentionSeconds:   conf.MessageRetentionSeconds,
+		DelaySeconds:              conf.DelaySeconds,
+		VisibilityTimeoutSeconds:  conf.VisibilityTimeoutSeconds,
+		ReceiveWaitTimeSeconds:    conf.ReceiveWaitTimeSeconds,
+		DLQ:                       conf.HasDLQ(),
+		PolicySources:             buildPolicySources(conf, naming, snsConfs, eventBusConfs),
+	}
+
+	if data.VisibilityTimeoutSeconds == 0 {
+		data.VisibilityTimeoutSeconds = defaultVisibilityTimeoutSeconds
+	}
+
+	if conf.Encryption != nil {
+		switch strings.ToLower(conf.Encryption.Type) {
+		case config.SQSEncryptionKMS:
+			data.KMSKeyID = conf.Encryption.KMSKeyID
+			data.KMSDataKeyReusePeriodSeconds = conf.Encryption.KMSDataKeyReusePeriodSeconds
+		case config.SQSEncryptionSQS:
+			data.SQSManagedSSE = true
+		}
+	}
+
+	return data
+}
+
+// validateSources rejects the sources wired to the queue that cannot send to it: S3 cannot send notifications to
+// FIFO queues.
+func validateSources(conf *config.SQS, snsConfs []config.SNS) error {
+	if !conf.FIFO {
+		return nil
+	}
+
+	for i := range snsConfs {
+		for _, sqsConf := range snsConfs[i].SQSs {
+			if sqsConf.Name == conf.Name {
+				return fmt.Errorf("%w: S3 notification '%s' sends to the FIFO queue '%s'",
+					generatorserrs.ErrInvalidQueueSource, snsConfs[i].Name, conf.Name)
+			}
+		}
+	}
+
 	return nil
 }
+
+// buildPolicySources returns the sources wired to the queue in the configuration: the S3 buckets of the SNS
+// notifications that send to the queue and the EventBridge rules that target the queue or use it as dead-letter queue.
+func buildPolicySources(
+	conf *config.SQS, naming config.Naming, snsConfs []config.SNS, eventBusConfs []config.EventBus,
+) []PolicySourceData {
+	sources := []PolicySourceData{}
+
+	for i := range snsConfs {
+		for _, sqsConf := range snsConfs[i].SQSs {
+			if sqsConf.Name != conf.Name {
+				continue
+			}
+
+			sources = append(sources, PolicySourceData{
+				Principal: principalS3,
+				SourceARN: fmt.Sprintf("aws_s3_bucket.%s.arn", naming.Label(awsresources.S3Type, snsConfs[i].BucketName)),
+			})
+		}
+	}
+
+	for i := range eventBusConfs {
+		for j := range eventBusConfs[i].Rules {
+			rule := &eventBusConfs[i].Rules[j]
+
+			if !eventRuleSendsToQueue(rule, conf.Name) {
+				continue
+			}
+
+			sources = append(sources, PolicySourceData{
+				Principal: principalEvents,
+				SourceARN: fmt.Sprintf("aws_cloudwatch_event_rule.%s.arn",
+					generators.EventRuleLabel(eventBusConfs[i].Name, rule.Name)),
+			})
+		}
+	}
+
+	return sources
+}
+
+// eventRuleSendsToQueue reports whether the rule has the queue as target or as dead-letter queue of any target.
+func eventRuleSendsToQueue(rule *config.EventRule, queueName string) bool {
+	for _, target := range rule.SQSs {
+		if target.Name == queueName {
+			return true
+		}
+	}
+
+	for _, targets := range [][]config.EventTarget{rule.Lambdas, rule.SQSs, rule.Kinesis, rule.StepFunctions} {
+		for _, target := range targets {
+			if target.DeadLetterQueue == queueName {
+				return true
+			}
+		}
+	}
+
+	return false
+}
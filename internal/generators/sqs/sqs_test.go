@@ -4,8 +4,10 @@ import (
 	_ "embed"
 	"os"
 	"path"
+	"strings"
 	"testing"
 
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
 
 	"github.com/stretchr/testify/require"
@@ -42,6 +44,22 @@ func TestSQS_Build(t *testing.T) {
 				require.FileExists(tb, path.Join(output, "mod", "sqs.tf"))
 			},
 		},
+		{
+			name: "template pack",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "sqs.config.templatepack.yaml"),
+				output:         path.Join(testOutput, "templatepack"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				data, err := os.ReadFile(path.Join(output, "mod", "sqs.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(data), `resource "aws_sqs_queue" "orders_pack" {}`)
+			},
+		},
 		{
 			name: "override default template for multiple sqs",
 			fields: fields{
@@ -89,6 +107,153 @@ func TestSQS_Build(t *testing.T) {
 				require.FileExists(tb, path.Join(modPath, "source-sqs.tf"))
 			},
 		},
+		{
+			name: "fifo, encryption, attributes and queue policy",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "sqs.config.fifo.yaml"),
+				output:         path.Join(testOutput, "fifo"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				sqsTfData, err := os.ReadFile(path.Join(output, "mod", "sqs.tf"))
+				require.NoError(tb, err)
+
+				content := string(sqsTfData)
+				require.Contains(tb, content, `name                       = "${var.client}-${var.environment}-orders.fifo"`)
+				require.Contains(tb, content, "content_based_deduplication = true")
+				require.Contains(tb, content, `kms_master_key_id = "alias/orders"`)
+				require.Contains(tb, content, "visibility_timeout_seconds = 60")
+				require.Contains(tb, content, "receive_wait_time_seconds  = 20")
+				require.Contains(tb, content, `"${var.client}-${var.environment}-orders-dlq.fifo"`)
+				require.Contains(tb, content, "sqs_managed_sse_enabled = true")
+				require.NotContains(tb, content, `resource "aws_sqs_queue" "target_sqs_dlq"`)
+				require.Contains(tb, content, `Principal = { Service = "s3.amazonaws.com" }`)
+				require.Contains(tb, content, `"aws:SourceArn" = aws_s3_bucket.my_bucket_bucket.arn`)
+			},
+		},
+		{
+			name: "queue policy for eventbridge rules",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "sqs.config.eventbridge.yaml"),
+				output:         path.Join(testOutput, "eventbridge"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				sqsTfData, err := os.ReadFile(path.Join(output, "mod", "sqs.tf"))
+				require.NoError(tb, err)
+
+				content := string(sqsTfData)
+				require.Contains(tb, content, `resource "aws_sqs_queue_policy" "order_queue_sqs_policy" {`)
+				require.Contains(tb, content, `resource "aws_sqs_queue_policy" "order_events_dlq_sqs_policy" {`)
+				require.Contains(tb, content, `Principal = { Service = "events.amazonaws.com" }`)
+				require.Contains(tb, content, `"aws:SourceArn" = aws_cloudwatch_event_rule.orders_order_created_rule.arn`)
+			},
+		},
+		{
+			name: "tags merged with the tags of the configuration",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "sqs.config.tags.yaml"),
+				output:         path.Join(testOutput, "tags"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				sqsTfData, err := os.ReadFile(path.Join(output, "mod", "sqs.tf"))
+				require.NoError(tb, err)
+
+				content := string(sqsTfData)
+				require.Equal(tb, 2, strings.Count(content, "  tags = {\n    Queue = \"orders\"\n    Team  = \"payments\"\n  }"))
+			},
+		},
+		{
+			name: "naming conventions of the configuration",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "sqs.config.naming.yaml"),
+				output:         path.Join(testOutput, "naming"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				sqsTfData, err := os.ReadFile(path.Join(output, "mod", "sqs.tf"))
+				require.NoError(tb, err)
+
+				content := string(sqsTfData)
+				require.Contains(tb, content, `resource "aws_sqs_queue" "queue_orders" {`)
+				require.Contains(tb, content, `name                       = "acme-orders-queue"`)
+				require.Contains(tb, content, `name                       = "acme-orders-queue-dlq"`)
+				require.Contains(tb, content, "deadLetterTargetArn = aws_sqs_queue.queue_orders_dlq.arn")
+				require.Contains(tb, content, `"aws:SourceArn" = aws_s3_bucket.uploads_storage.arn`)
+			},
+		},
+		{
+			name: "when the queue name is too long should return an error",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "sqs.config.naming.too.long.yaml"),
+				output:         path.Join(testOutput, "naming-too-long"),
+			},
+			targetErr: config.ErrNameTooLong,
+		},
+		{
+			name: "settings that differ between the environments",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "sqs.config.environments.yaml"),
+				output:         path.Join(testOutput, "environments"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				sqsTfData, err := os.ReadFile(path.Join(output, "mod", "sqs.tf"))
+				require.NoError(tb, err)
+
+				content := string(sqsTfData)
+				require.Contains(tb, content, "message_retention_seconds  = var.orders_sqs_message_retention_seconds")
+				require.Equal(tb, 2, strings.Count(content,
+					"visibility_timeout_seconds = var.orders_sqs_visibility_timeout_seconds"))
+				require.Equal(tb, 2, strings.Count(content, "visibility_timeout_seconds = 720"))
+
+				varsTfData, err := os.ReadFile(path.Join(output, "mod", "sqs_environments.tf"))
+				require.NoError(tb, err)
+				require.Contains(tb, string(varsTfData), "variable \"orders_sqs_message_retention_seconds\" {\n"+
+					"  type    = number\n"+
+					"  default = null\n"+
+					"}")
+
+				tfvars := map[string]string{
+					"dev": "orders_sqs_message_retention_seconds  = null\n" +
+						"orders_sqs_visibility_timeout_seconds = 720\n",
+					"uat": "orders_sqs_message_retention_seconds  = 345600\n" +
+						"orders_sqs_visibility_timeout_seconds = 720\n",
+					"prd": "orders_sqs_message_retention_seconds  = 1209600\n" +
+						"orders_sqs_visibility_timeout_seconds = 900\n",
+				}
+
+				for env, want := range tfvars {
+					tfvarsData, err := os.ReadFile(path.Join(output, env, "sqs.auto.tfvars"))
+					require.NoError(tb, err)
+					require.Equal(tb, want, string(tfvarsData))
+				}
+			},
+		},
+		{
+			name: "when a fifo queue receives s3 notifications should return an error",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "sqs.config.fifo.s3.yaml"),
+				output:         path.Join(testOutput, "fifo-s3"),
+			},
+			targetErr: generatorserrs.ErrInvalidQueueSource,
+		},
 		{
 			name: "when yaml parser fails should return an error",
 			fields: fields{
@@ -107,7 +272,7 @@ func TestSQS_Build(t *testing.T) {
 		tc := tests[i]
 
 		t.Run(tc.name, func(t *testing.T) {
-			err := NewSQS(tc.fields.configFileName, tc.fields.output).Build()
+			err := NewSQS(config.NewYAML(tc.fields.configFileName), tc.fields.output).Build()
 
 			require.ErrorIs(t, err, tc.targetErr)
 
@@ -0,0 +1,33 @@
+package stepfunctions
+
+import (
+	_ "embed"
+	"maps"
+)
+
+const filenameStepFunctionsTf = "stepfunctions.tf"
+
+const (
+	defaultStateMachineType = "STANDARD"
+	defaultStateType        = "Task"
+	defaultLoggingLevel     = "ERROR"
+	defaultRetryError       = "States.ALL"
+	loggingLevelOff         = "OFF"
+)
+
+const (
+	resourceLambdaInvoke   = "arn:aws:states:::lambda:invoke"
+	resourceSQSSendMessage = "arn:aws:states:::sqs:sendMessage"
+)
+
+//go:embed tmpls/stepfunctions.tf.tmpl
+var tmplStepFunctionsTf []byte
+
+var defaultTfTemplateFiles = map[string]string{
+	filenameStepFunctionsTf: string(tmplStepFunctionsTf),
+}
+
+// DefaultTemplates returns the built-in templates of the generator by file name.
+func DefaultTemplates() map[string]string {
+	return maps.Clone(defaultTfTemplateFiles)
+}
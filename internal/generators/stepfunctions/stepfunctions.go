@@ -0,0 +1,330 @@
+package stepfunctions
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
+	"github.com/joselitofilho/aws-terraform-generator/internal/utils"
+)
+
+var (
+	// ErrUnknownState represents a state machine that refers to a state it does not define.
+	ErrUnknownState = errors.New("unknown state")
+
+	// ErrUnknownTask represents a state invoking a Lambda function or sending to an SQS queue that is not configured.
+	ErrUnknownTask = errors.New("unknown task")
+)
+
+type Data struct {
+	Name    string
+	Label   string
+	Type    string
+	Comment string
+	StartAt string
+	States  []StateData
+	// RolePolicies lists what the state machine role is allowed to do besides logging.
+	RolePolicies []RolePolicyData
+	Logging      *LoggingData
+	Tags         string
+}
+
+// StateData represents a state of the definition. The string values are quoted HCL strings, and the references are
+// HCL expressions.
+type StateData struct {
+	Name       string
+	Type       string
+	Resource   string
+	Parameters []ParameterData
+	Next       string
+	End        bool
+	Seconds    int
+	Error      string
+	Cause      string
+	Retry      *RetryData
+}
+
+type ParameterData struct {
+	Key   string
+	Value string
+}
+
+type RetryData struct {
+	Errors          string
+	IntervalSeconds int
+	MaxAttempts     int
+	BackoffRate     float64
+}
+
+type RolePolicyData struct {
+	Actions   string
+	Resources string
+}
+
+type LoggingData struct {
+	Level                string
+	IncludeExecutionData bool
+	RetentionInDays      int
+}
+
+type StepFunctions struct {
+	yamlParser *config.YAML
+	output     string
+}
+
+func NewStepFunctions(yamlParser *config.YAML, output string) *StepFunctions {
+	return &StepFunctions{yamlParser: yamlParser, output: output}
+}
+
+func (s *StepFunctions) Build() error {
+	yamlConfig, err := s.yamlParser.Parse()
+	if err != nil {
+		return fmt.Errorf("%w: %w", generatorserrs.ErrYAMLParser, err)
+	}
+
+	modPath := path.Join(s.output, "mod")
+	_ = os.MkdirAll(modPath, os.ModePerm)
+
+	result := make([]string, 0, len(yamlConfig.StepFunctions))
+
+	templates := utils.MergeStringMap(defaultTfTemplateFiles,
+		generators.CreateTemplatesMap(yamlConfig.OverrideDefaultTemplates.StepFunctions))
+
+	tg := generators.NewGenerator(yamlConfig)
+
+	for i := range yamlConfig.StepFunctions {
+		conf := yamlConfig.StepFunctions[i]
+
+		data, err := buildData(&conf, yamlConfig)
+		if err != nil {
+			return err
+		}
+
+		data.Tags, err = generators.BuildTags(yamlConfig, conf.Name)
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		if len(conf.Files) > 0 {
+			filesConf := generators.CreateFilesMap(conf.Files)
+
+			generators.MustGenerateFiles(tg, nil, filesConf, data, modPath)
+
+			fmtcolor.White.Printf("Step Functions '%s' has been generated successfully\n", conf.Name)
+
+			continue
+		}
+
+		output, err := tg.Build(data, "stepfunctions-tf-template", templates[filenameStepFunctionsTf])
+		if err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		result = append(result, output)
+	}
+
+	if len(result) > 0 {
+		outputFile := path.Join(modPath, filenameStepFunctionsTf)
+
+		generators.MustGenerateFile(tg, nil, filenameStepFunctionsTf, strings.Join(result, "\n"), outputFile, Data{})
+
+		fmtcolor.White.Println("Step Functions has been generated successfully")
+	}
+
+	return nil
+}
+
+func buildData(conf *config.StepFunction, yamlConfig *config.Config) (Data, error) {
+	naming := yamlConfig.Naming
+
+	data := Data{
+		Name:    conf.Name,
+		Label:   generators.StateMachineLabel(conf.Name),
+		Type:    utils.FirstNonEmpty(conf.Type, defaultStateMachineType),
+		Comment: generators.HCLString(conf.Comment),
+		States:  make([]StateData, 0, len(conf.States)),
+	}
+
+	stateNames := make(map[string]struct{}, len(conf.States))
+	for i := range conf.States {
+		stateNames[conf.States[i].Name] = struct{}{}
+	}
+
+	startAt := conf.StartAt
+	if startAt == "" && len(conf.States) > 0 {
+		startAt = conf.States[0].Name
+	}
+
+	if _, ok := stateNames[startAt]; !ok {
+		return Data{}, fmt.Errorf("%w: state machine '%s' starts at '%s'", ErrUnknownState, conf.Name, startAt)
+	}
+
+	data.StartAt = generators.HCLString(startAt)
+
+	lambdaARNs := []string{}
+	queueARNs := []string{}
+
+	for i := range conf.States {
+		stateConf := conf.States[i]
+
+		if _, ok := stateNames[stateConf.Next]; stateConf.Next != "" && !ok {
+			return Data{}, fmt.Errorf("%w: state '%s' of '%s' goes to '%s'",
+				ErrUnknownState, stateConf.Name, conf.Name, stateConf.Next)
+		}
+
+		if err := validateTask(&stateConf, conf.Name, yamlConfig); err != nil {
+			return Data{}, err
+		}
+
+		state := buildState(&stateConf, naming)
+
+		switch {
+		case stateConf.Lambda != "":
+			arn := fmt.Sprintf("aws_lambda_function.%s.arn", naming.Label(awsresources.LambdaType, stateConf.Lambda))
+			lambdaARNs = appendUnique(lambdaARNs, arn, fmt.Sprintf(`"${%s}:*"`, arn))
+		case stateConf.SQS != "":
+			queueARNs = appendUnique(queueARNs,
+				fmt.Sprintf("aws_sqs_queue.%s.arn", naming.Label(awsresources.SQSType, stateConf.SQS)))
+		}
+
+		data.States = append(data.States, state)
+	}
+
+	if len(lambdaARNs) > 0 {
+		data.RolePolicies = append(data.RolePolicies, RolePolicyData{
+			Actions:   `"lambda:InvokeFunction"`,
+			Resources: strings.Join(lambdaARNs, ", "),
+		})
+	}
+
+	if len(queueARNs) > 0 {
+		data.RolePolicies = append(data.RolePolicies, RolePolicyData{
+			Actions:   `"sqs:SendMessage"`,
+			Resources: strings.Join(queueARNs, ", "),
+		})
+	}
+
+	if conf.Logging != nil {
+		data.Logging = &LoggingData{
+			Level:                utils.FirstNonEmpty(conf.Logging.Level, defaultLoggingLevel),
+			IncludeExecutionData: conf.Logging.IncludeExecutionData,
+			RetentionInDays:      conf.Logging.RetentionInDays,
+		}
+
+		if data.Logging.Level == loggingLevelOff {
+			data.Logging = nil
+		}
+	}
+
+	return data, nil
+}
+
+// validateTask checks that the Lambda function or the SQS queue of the state is configured.
+func validateTask(conf *config.StepFunctionState, stateMachineName string, yamlConfig *config.Config) error {
+	switch {
+	case conf.Lambda != "" && !hasLambda(yamlConfig, conf.Lambda):
+		return fmt.Errorf("%w: state '%s' of '%s' invokes the lambda '%s'", ErrUnknownTask, conf.Name,
+			stateMachineName, conf.Lambda)
+	case conf.SQS != "" && !slices.ContainsFunc(yamlConfig.SQSs, func(q config.SQS) bool { return q.Name == conf.SQS }):
+		return fmt.Errorf("%w: state '%s' of '%s' sends to the queue '%s'", ErrUnknownTask, conf.Name,
+			stateMachineName, conf.SQS)
+	}
+
+	return nil
+}
+
+// hasLambda reports whether the Lambda function is configured, on its own or behind an API Gateway.
+func hasLambda(yamlConfig *config.Config, name string) bool {
+	for i := range yamlConfig.Lambdas {
+		if yamlConfig.Lambdas[i].Name == name {
+			return true
+		}
+	}
+
+	for i := range yamlConfig.APIGateways {
+		for j := range yamlConfig.APIGateways[i].Lambdas {
+			if yamlConfig.APIGateways[i].Lambdas[j].Name == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func buildState(conf *config.StepFunctionState, naming config.Naming) StateData {
+	state := StateData{
+		Name:    generators.HCLString(conf.Name),
+		Type:    generators.HCLString(utils.FirstNonEmpty(conf.Type, defaultStateType)),
+		Seconds: conf.Seconds,
+	}
+
+	switch {
+	case conf.Lambda != "":
+		state.Resource = generators.HCLString(resourceLambdaInvoke)
+		state.Parameters = []ParameterData{
+			{Key: `"FunctionName"`, Value: fmt.Sprintf("aws_lambda_function.%s.arn",
+				naming.Label(awsresources.LambdaType, conf.Lambda))},
+			{Key: `"Payload.$"`, Value: `"$"`},
+		}
+	case conf.SQS != "":
+		state.Resource = generators.HCLString(resourceSQSSendMessage)
+		state.Parameters = []ParameterData{
+			{Key: `"QueueUrl"`, Value: fmt.Sprintf("aws_sqs_queue.%s.url", naming.Label(awsresources.SQSType, conf.SQS))},
+			{Key: `"MessageBody.$"`, Value: `"$"`},
+		}
+	case conf.Resource != "":
+		state.Resource = generators.HCLString(conf.Resource)
+	}
+
+	if conf.Error != "" {
+		state.Error = generators.HCLString(conf.Error)
+	}
+
+	if conf.Cause != "" {
+		state.Cause = generators.HCLString(conf.Cause)
+	}
+
+	// Succeed and Fail states are terminal by themselves and do not accept the End field.
+	stateType := utils.FirstNonEmpty(conf.Type, defaultStateType)
+	if conf.Next != "" {
+		state.Next = generators.HCLString(conf.Next)
+	} else if stateType != "Succeed" && stateType != "Fail" {
+		state.End = true
+	}
+
+	if conf.Retry != nil {
+		errs := conf.Retry.Errors
+		if len(errs) == 0 {
+			errs = []string{defaultRetryError}
+		}
+
+		state.Retry = &RetryData{
+			Errors:          generators.HCLStringList(errs),
+			IntervalSeconds: conf.Retry.IntervalSeconds,
+			MaxAttempts:     conf.Retry.MaxAttempts,
+			BackoffRate:     conf.Retry.BackoffRate,
+		}
+	}
+
+	return state
+}
+
+func appendUnique(values []string, newValues ...string) []string {
+	for _, newValue := range newValues {
+		if !slices.Contains(values, newValue) {
+			values = append(values, newValue)
+		}
+	}
+
+	return values
+}
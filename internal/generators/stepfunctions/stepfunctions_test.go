@@ -0,0 +1,148 @@
+package stepfunctions
+
+import (
+	_ "embed"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	generatorserrs "github.com/joselitofilho/aws-terraform-generator/internal/generators/errors"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testdataFolder = "../testdata"
+	testOutput     = "./testoutput"
+)
+
+func TestStepFunctions_Build(t *testing.T) {
+	type fields struct {
+		configFileName string
+		output         string
+	}
+
+	tests := []struct {
+		name             string
+		fields           fields
+		extraValidations func(testing.TB, string, error)
+		targetErr        error
+	}{
+		{
+			name: "state machines invoking lambdas, queues and other tasks",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "stepfunctions.config.yaml"),
+				output:         path.Join(testOutput, "default"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				stepFunctionsTfData, err := os.ReadFile(path.Join(output, "mod", "stepfunctions.tf"))
+				require.NoError(tb, err)
+
+				content := string(stepFunctionsTfData)
+				require.Contains(tb, content, `resource "aws_sfn_state_machine" "order_workflow_state_machine" {`)
+				require.Contains(tb, content, `type     = "STANDARD"`)
+				require.Contains(tb, content, "role_arn = aws_iam_role.order_workflow_state_machine_role.arn")
+				require.Contains(tb, content, `name = "${var.client}-${var.environment}-order-workflow-state-machine"`)
+				require.Contains(tb, content, "definition = jsonencode({")
+				require.Contains(tb, content, `Comment = "Processes a new order of $${customer}"`)
+				require.Contains(tb, content, `StartAt = "ValidateOrder"`)
+				require.Contains(tb, content, `Resource = "arn:aws:states:::lambda:invoke"`)
+				require.Contains(tb, content, `"FunctionName" = aws_lambda_function.order_validator_lambda.arn`)
+				require.Contains(tb, content, `ErrorEquals = ["States.ALL"]`)
+				require.Contains(tb, content, "BackoffRate = 2")
+				require.Contains(tb, content, "Seconds = 60")
+				require.Contains(tb, content, `"QueueUrl" = aws_sqs_queue.order_queue_sqs.url`)
+				require.Contains(tb, content, "Type = \"Succeed\"\n      }")
+				require.Contains(tb, content,
+					`log_destination        = "${aws_cloudwatch_log_group.order_workflow_state_machine_logs.arn}:*"`)
+				require.Contains(tb, content, `level                  = "ALL"`)
+				require.Contains(tb, content, `name = "/aws/vendedlogs/states/orderWorkflow"`)
+				require.Contains(tb, content, "retention_in_days = 14")
+				require.Contains(tb, content, `Resource = [aws_lambda_function.order_validator_lambda.arn, `+
+					`"${aws_lambda_function.order_validator_lambda.arn}:*", aws_lambda_function.order_charger_lambda.arn, `+
+					`"${aws_lambda_function.order_charger_lambda.arn}:*"]`)
+				require.Contains(tb, content, "Resource = [aws_sqs_queue.order_queue_sqs.arn]")
+				require.Contains(tb, content, `type     = "EXPRESS"`)
+				require.Contains(tb, content, `Resource = "arn:aws:states:::dynamodb:putItem"`)
+				require.NotContains(tb, content, `resource "aws_iam_role_policy" "audit_workflow_state_machine_role_policy" {`)
+			},
+		},
+		{
+			name: "override default template",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "stepfunctions.config.override.default.tmpls.yaml"),
+				output:         path.Join(testOutput, "override"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				require.FileExists(tb, path.Join(output, "mod", "stepfunctions.tf"))
+			},
+		},
+		{
+			name: "at least one state machine customising",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "stepfunctions.config.custom.yaml"),
+				output:         path.Join(testOutput, "one"),
+			},
+			extraValidations: func(tb testing.TB, output string, err error) {
+				if err != nil {
+					return
+				}
+
+				modPath := path.Join(output, "mod")
+				require.FileExists(tb, path.Join(modPath, "stepfunctions.tf"))
+				require.FileExists(tb, path.Join(modPath, "order_workflow.tf"))
+			},
+		},
+		{
+			name: "when a state goes to an unknown state should return an error",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "stepfunctions.config.unknown.state.yaml"),
+				output:         path.Join(testOutput, "unknown"),
+			},
+			targetErr: ErrUnknownState,
+		},
+		{
+			name: "when a state sends to an unknown queue should return an error",
+			fields: fields{
+				configFileName: path.Join(testdataFolder, "stepfunctions.config.unknown.task.yaml"),
+				output:         path.Join(testOutput, "unknowntask"),
+			},
+			targetErr: ErrUnknownTask,
+		},
+		{
+			name: "when yaml parser fails should return an error",
+			fields: fields{
+				configFileName: "",
+				output:         "",
+			},
+			targetErr: generatorserrs.ErrYAMLParser,
+		},
+	}
+
+	defer func() {
+		_ = os.RemoveAll(testOutput)
+	}()
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewStepFunctions(config.NewYAML(tc.fields.configFileName), tc.fields.output).Build()
+
+			require.ErrorIs(t, err, tc.targetErr)
+
+			if tc.extraValidations != nil {
+				tc.extraValidations(t, tc.fields.output, err)
+			}
+		})
+	}
+}
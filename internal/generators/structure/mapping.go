@@ -0,0 +1,7 @@
+package structure
+
+// DefaultTemplates returns the built-in templates of the generator by file name. There are none: the templates of the
+// files of the stacks come from the default templates of the structure, which a template pack may provide.
+func DefaultTemplates() map[string]string {
+	return map[string]string{}
+}
@@ -13,25 +13,23 @@ import (
 )
 
 type Structure struct {
-	configFileName string
-	output         string
+	yamlParser *config.YAML
+	output     string
 }
 
-func NewStructure(configFileName, output string) *Structure {
-	return &Structure{configFileName: configFileName, output: output}
+func NewStructure(yamlParser *config.YAML, output string) *Structure {
+	return &Structure{yamlParser: yamlParser, output: output}
 }
 
 func (s *Structure) Build() error {
-	yamlParser := config.NewYAML(s.configFileName)
-
-	yamlConfig, err := yamlParser.Parse()
+	yamlConfig, err := s.yamlParser.Parse()
 	if err != nil {
 		return fmt.Errorf("%w: %w", generatorserrs.ErrYAMLParser, err)
 	}
 
 	defaultTemplatesMap := generators.CreateTemplatesMap(yamlConfig.Structure.DefaultTemplates)
 
-	tg := generators.NewGenerator()
+	tg := generators.NewGenerator(yamlConfig)
 
 	for i := range yamlConfig.Structure.Stacks {
 		conf := yamlConfig.Structure.Stacks[i]
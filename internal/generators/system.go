@@ -0,0 +1,175 @@
+package generators
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/diagram-code-generator/resources/pkg/resources"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
+	"github.com/joselitofilho/aws-terraform-generator/internal/transformers/yamltoresources"
+)
+
+// System is the read-only view of the whole system that every template gets with the system function: the
+// configuration and the graph of its resources and their relationships.
+type System struct {
+	config *config.Config
+
+	// The graph is built on first use, so the templates that do not query it do not pay for it.
+	once  sync.Once
+	graph *resources.ResourceCollection
+	err   error
+}
+
+// SystemResource is a resource of the graph of the system.
+type SystemResource struct {
+	ID string
+	// Type is the resource type as the configuration names it, such as sqs or lambda.
+	Type string
+	Name string
+	// Label is the Terraform label of the resource, following the naming conventions.
+	Label string
+}
+
+func newSystem(yamlConfig *config.Config) *System {
+	return &System{config: yamlConfig}
+}
+
+// Config returns a deep copy of the configuration, so the templates cannot change the configuration of the others.
+func (s *System) Config() config.Config {
+	return *s.config.Clone()
+}
+
+// Graph returns a copy of the graph of the resources and their relationships, as built by yamltoresources.
+func (s *System) Graph() (*resources.ResourceCollection, error) {
+	graph, err := s.resourceGraph()
+	if err != nil {
+		return nil, err
+	}
+
+	return &resources.ResourceCollection{
+		Resources:     slices.Clone(graph.Resources),
+		Relationships: slices.Clone(graph.Relationships),
+	}, nil
+}
+
+// Resources returns the resources of the graph, only of the types when any is given.
+func (s *System) Resources(resourceTypes ...string) ([]SystemResource, error) {
+	graph, err := s.resourceGraph()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []SystemResource{}
+
+	for _, resource := range graph.Resources {
+		if isOfType(resource, resourceTypes) {
+			result = append(result, s.systemResource(resource))
+		}
+	}
+
+	return result, nil
+}
+
+// Incoming returns the resources with a relationship to the resource of the type and name, such as the queues that
+// trigger a lambda, only of the types when any is given.
+func (s *System) Incoming(resourceType, name string, fromTypes ...string) ([]SystemResource, error) {
+	return s.edges(resourceType, name, fromTypes, true)
+}
+
+// Outgoing returns the resources the resource of the type and name has a relationship to, such as the buckets a
+// lambda writes to, only of the types when any is given.
+func (s *System) Outgoing(resourceType, name string, toTypes ...string) ([]SystemResource, error) {
+	return s.edges(resourceType, name, toTypes, false)
+}
+
+// edges returns the other ends of the relationships to the resource of the type and name, when incoming, or from it
+// otherwise. They are sorted by type and name, because the order of the relationships of the graph is not stable.
+func (s *System) edges(resourceType, name string, otherTypes []string, incoming bool) ([]SystemResource, error) {
+	graph, err := s.resourceGraph()
+	if err != nil {
+		return nil, err
+	}
+
+	others := []resources.Resource{}
+
+	for _, rel := range graph.Relationships {
+		end, other := rel.Source, rel.Target
+		if incoming {
+			end, other = rel.Target, rel.Source
+		}
+
+		if end == nil || other == nil || !s.matches(end, resourceType, name) {
+			continue
+		}
+
+		if isOfType(other, otherTypes) && !slices.Contains(others, other) {
+			others = append(others, other)
+		}
+	}
+
+	result := make([]SystemResource, 0, len(others))
+	for _, other := range others {
+		result = append(result, s.systemResource(other))
+	}
+
+	slices.SortFunc(result, func(a, b SystemResource) int {
+		if a.Type != b.Type {
+			return strings.Compare(a.Type, b.Type)
+		}
+
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return result, nil
+}
+
+// matches reports whether the resource is the resource of the type and name. The names of the graph follow the casing
+// of the naming conventions, so the name matches either as given or cased.
+func (s *System) matches(resource resources.Resource, resourceType, name string) bool {
+	if !isOfType(resource, []string{resourceType}) {
+		return false
+	}
+
+	return resource.Value() == name ||
+		resource.Value() == s.config.Naming.Case(awsresources.ParseResourceType(resourceType), name)
+}
+
+func (s *System) systemResource(resource resources.Resource) SystemResource {
+	resourceType := awsresources.ParseResourceType(resource.ResourceType())
+
+	return SystemResource{
+		ID:    resource.ID(),
+		Type:  string(resourceType),
+		Name:  resource.Value(),
+		Label: s.config.Naming.Label(resourceType, resource.Value()),
+	}
+}
+
+// isOfType reports whether the resource is of one of the types, or of any type when none is given. The types of the
+// graph are the display names of the types, such as SQS, so they are compared as parsed.
+func isOfType(resource resources.Resource, resourceTypes []string) bool {
+	if len(resourceTypes) == 0 {
+		return true
+	}
+
+	resourceType := awsresources.ParseResourceType(resource.ResourceType())
+
+	return slices.ContainsFunc(resourceTypes, func(t string) bool {
+		return awsresources.ParseResourceType(t) == resourceType
+	})
+}
+
+func (s *System) resourceGraph() (*resources.ResourceCollection, error) {
+	s.once.Do(func() {
+		s.graph, s.err = yamltoresources.NewTransformer(s.config).Transform()
+		if s.err != nil {
+			s.err = fmt.Errorf("%w", s.err)
+		}
+	})
+
+	return s.graph, s.err
+}
@@ -0,0 +1,95 @@
+package generators
+
+import (
+	"testing"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystem_Query(t *testing.T) {
+	system := newSystem(&config.Config{
+		SQSs:    []config.SQS{{Name: "orders"}, {Name: "payments"}},
+		Buckets: []config.S3{{Name: "receipts"}},
+		Lambdas: []config.Lambda{{
+			Name: "worker",
+			Envars: map[string]string{
+				"RECEIPTS_S3_BUCKET":     "aws_s3_bucket.receipts_bucket.id",
+				"PAYMENTS_SQS_QUEUE_URL": "aws_sqs_queue.payments_sqs.url",
+			},
+			SQSTriggers: []config.SQSTrigger{{SourceARN: "aws_sqs_queue.orders_sqs.arn"}},
+		}},
+	})
+
+	orders := SystemResource{Type: "sqs", Name: "orders", Label: "orders_sqs"}
+	payments := SystemResource{Type: "sqs", Name: "payments", Label: "payments_sqs"}
+	receipts := SystemResource{Type: "s3", Name: "receipts", Label: "receipts_bucket"}
+	worker := SystemResource{Type: "lambda", Name: "worker", Label: "worker_lambda"}
+
+	tests := []struct {
+		name  string
+		query func() ([]SystemResource, error)
+		want  []SystemResource
+	}{
+		{
+			name:  "incoming",
+			query: func() ([]SystemResource, error) { return system.Incoming("lambda", "worker") },
+			want:  []SystemResource{orders},
+		},
+		{
+			name:  "outgoing",
+			query: func() ([]SystemResource, error) { return system.Outgoing("lambda", "worker") },
+			want:  []SystemResource{receipts, payments},
+		},
+		{
+			name:  "outgoing of a type",
+			query: func() ([]SystemResource, error) { return system.Outgoing("lambda", "worker", "sqs") },
+			want:  []SystemResource{payments},
+		},
+		{
+			name:  "outgoing of a queue",
+			query: func() ([]SystemResource, error) { return system.Outgoing("sqs", "orders") },
+			want:  []SystemResource{worker},
+		},
+		{
+			name:  "resources of a type",
+			query: func() ([]SystemResource, error) { return system.Resources("sqs") },
+			want:  []SystemResource{orders, payments},
+		},
+		{
+			name:  "unknown resource",
+			query: func() ([]SystemResource, error) { return system.Incoming("lambda", "reader") },
+			want:  []SystemResource{},
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.query()
+			require.NoError(t, err)
+
+			for i := range got {
+				got[i].ID = ""
+			}
+
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestSystem_Config(t *testing.T) {
+	yamlConfig := &config.Config{
+		SQSs: []config.SQS{{Name: "orders"}},
+		Tags: map[string]string{"Team": "payments"},
+	}
+
+	got := newSystem(yamlConfig).Config()
+	got.SQSs[0].Name = "payments"
+	got.Tags["Team"] = "orders"
+
+	require.Equal(t, "orders", yamlConfig.SQSs[0].Name)
+	require.Equal(t, "payments", yamlConfig.Tags["Team"])
+}
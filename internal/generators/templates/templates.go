@@ -0,0 +1,91 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/apigateway"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/eventbridge"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/firehose"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/kinesis"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/lambda"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/observability"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/rds"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/s3"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/secrets"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/sns"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/sqs"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/stepfunctions"
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/structure"
+)
+
+var ErrTemplateExists = errors.New("template already exists")
+
+// defaultTemplates are the built-in templates of the generators by the name of their package, which is the name of
+// their folder in a template pack.
+var defaultTemplates = map[string]func() map[string]string{
+	"apigateway":    apigateway.DefaultTemplates,
+	"eventbridge":   eventbridge.DefaultTemplates,
+	"firehose":      firehose.DefaultTemplates,
+	"kinesis":       kinesis.DefaultTemplates,
+	"lambda":        lambda.DefaultTemplates,
+	"observability": observability.DefaultTemplates,
+	"rds":           rds.DefaultTemplates,
+	"s3":            s3.DefaultTemplates,
+	"secrets":       secrets.DefaultTemplates,
+	"sns":           sns.DefaultTemplates,
+	"sqs":           sqs.DefaultTemplates,
+	"stepfunctions": stepfunctions.DefaultTemplates,
+	"structure":     structure.DefaultTemplates,
+}
+
+type Templates struct {
+	output string
+	force  bool
+}
+
+// NewTemplates creates the exporter of the built-in templates. Unless force is set, it does not overwrite the
+// templates that already exist in the output.
+func NewTemplates(output string, force bool) *Templates {
+	return &Templates{output: output, force: force}
+}
+
+// Export writes the built-in templates as a template pack, in <output>/<generator>/tmpls/<file name>.tmpl as in the
+// source tree, as a starting point to customise them.
+func (t *Templates) Export() error {
+	files := map[string]string{}
+
+	for generator, templates := range defaultTemplates {
+		generatorPath := path.Join(t.output, generator, config.TemplatesFolder)
+		if err := os.MkdirAll(generatorPath, os.ModePerm); err != nil {
+			return fmt.Errorf("%w", err)
+		}
+
+		for filename, tmpl := range templates() {
+			files[path.Join(generatorPath, filename+config.TemplateExtension)] = tmpl
+		}
+	}
+
+	if !t.force {
+		for fileName := range files {
+			if _, err := os.Stat(fileName); !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("%w: '%s'", ErrTemplateExists, fileName)
+			}
+		}
+	}
+
+	for fileName, tmpl := range files {
+		if err := os.WriteFile(fileName, []byte(tmpl), os.ModePerm); err != nil {
+			return fmt.Errorf("%w", err)
+		}
+	}
+
+	fmtcolor.White.Printf("Templates have been exported successfully to '%s'\n", t.output)
+
+	return nil
+}
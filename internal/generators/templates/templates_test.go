@@ -0,0 +1,100 @@
+package templates
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/sqs"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testOutput = "./testoutput"
+
+func TestTemplates_Export(t *testing.T) {
+	defer func() {
+		_ = os.RemoveAll(testOutput)
+	}()
+
+	err := NewTemplates(testOutput, false).Export()
+	require.NoError(t, err)
+
+	// The pack mirrors the tmpls folders of the generators.
+	for generator := range defaultTemplates {
+		want := []string{}
+
+		entries, err := os.ReadDir(path.Join("..", generator, "tmpls"))
+		if !os.IsNotExist(err) {
+			require.NoError(t, err)
+		}
+
+		for _, entry := range entries {
+			want = append(want, entry.Name())
+		}
+
+		got := []string{}
+
+		entries, err = os.ReadDir(path.Join(testOutput, generator, "tmpls"))
+		require.NoError(t, err)
+
+		for _, entry := range entries {
+			got = append(got, entry.Name())
+		}
+
+		require.Equal(t, want, got, generator)
+	}
+
+	data, err := os.ReadFile(path.Join(testOutput, "sqs", "tmpls", "sqs.tf.tmpl"))
+	require.NoError(t, err)
+	require.Equal(t, sqs.DefaultTemplates()["sqs.tf"], string(data))
+}
+
+func TestTemplates_ExportExisting(t *testing.T) {
+	defer func() {
+		_ = os.RemoveAll(testOutput)
+	}()
+
+	customised := "# customised"
+	fileName := path.Join(testOutput, "sqs", "tmpls", "sqs.tf.tmpl")
+
+	require.NoError(t, os.MkdirAll(path.Dir(fileName), os.ModePerm))
+	require.NoError(t, os.WriteFile(fileName, []byte(customised), os.ModePerm))
+
+	tests := []struct {
+		name      string
+		force     bool
+		want      string
+		targetErr error
+	}{
+		{
+			name:      "without force should keep the template and return an error",
+			want:      customised,
+			targetErr: ErrTemplateExists,
+		},
+		{
+			name:  "with force should overwrite the template",
+			force: true,
+			want:  sqs.DefaultTemplates()["sqs.tf"],
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewTemplates(testOutput, tc.force).Export()
+			require.ErrorIs(t, err, tc.targetErr)
+
+			data, err := os.ReadFile(fileName)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, string(data))
+
+			// No template is written when one already exists.
+			if tc.targetErr != nil {
+				_, err := os.Stat(path.Join(testOutput, "kinesis", "tmpls", "kinesis.tf.tmpl"))
+				require.True(t, os.IsNotExist(err))
+			}
+		})
+	}
+}
@@ -1,7 +1,5 @@
 package resources
 
-import "github.com/ettle/strcase"
-
 const (
 	EnvarSuffixDBHost           = "DB_HOST"
 	EnvarSuffixGoogleBQ         = "BQ_PROJECT_ID"
@@ -12,19 +10,9 @@ const (
 	EnvarSuffixRestfulAPI       = "API_BASE_URL"
 )
 
-var (
-	ToDatabaseCase   = strcase.ToKebab
-	ToGoogleBQCase   = strcase.ToKebab
-	ToKinesisCase    = strcase.ToPascal
-	ToLambdaCase     = strcase.ToCamel
-	ToS3BucketCase   = strcase.ToKebab
-	ToSQSCase        = strcase.ToKebab
-	ToSNSCase        = strcase.ToKebab
-	ToRestfulAPICase = strcase.ToPascal
-)
-
-var SuffixByResource = map[ResourceType]string{
-	KinesisType: "kinesis",
-	S3Type:      "bucket",
-	SQSType:     "sqs",
+// IsWebSocketRouteKey reports whether the value is one of the predefined route keys only WebSocket APIs have:
+// "$connect" or "$disconnect". HTTP APIs have a "$default" route too, so it is a WebSocket route key only on an API
+// using the WebSocket protocol.
+func IsWebSocketRouteKey(value string) bool {
+	return value == "$connect" || value == "$disconnect"
 }
@@ -7,11 +7,19 @@ const (
 	LabelAWSAPIGatewayIntegration    = "aws_apigatewayv2_integration"
 	LabelAWSCloudwatchEventTarget    = "aws_cloudwatch_event_target"
 	LabelAWSCron                     = "aws_cloudwatch_event_rule"
+	LabelAWSDBInstance               = "aws_db_instance"
+	LabelAWSDBProxy                  = "aws_db_proxy"
 	LabelAWSEndpoint                 = "aws_apigatewayv2_domain_name"
+	LabelAWSEventBus                 = "aws_cloudwatch_event_bus"
+	LabelAWSKinesisFirehose          = "aws_kinesis_firehose_delivery_stream"
 	LabelAWSKinesisStream            = "aws_kinesis_stream"
+	LabelAWSKinesisStreamConsumer    = "aws_kinesis_stream_consumer"
 	LabelAWSLambdaFunction           = "aws_lambda_function"
 	LabelAWSLambdaEventSourceMapping = "aws_lambda_event_source_mapping"
+	LabelAWSRDSCluster               = "aws_rds_cluster"
 	LabelAWSS3Bucket                 = "aws_s3_bucket"
+	LabelAWSSchedulerSchedule        = "aws_scheduler_schedule"
 	LabelAWSSQSQueue                 = "aws_sqs_queue"
+	LabelAWSSFNStateMachine          = "aws_sfn_state_machine"
 	LabelAWSSNSTopic                 = "aws_sns_topic"
 )
@@ -0,0 +1,228 @@
+package resources
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var ErrInvalidResourceType = errors.New("invalid resource type")
+
+// ResourceTypeDefinition declares a resource type beyond the built-in ones, such as a company-specific resource. Once
+// registered, the type is recognised in the diagrams, the Terraform files and the configuration like the built-in
+// types.
+type ResourceTypeDefinition struct {
+	// Type is the resource type as the configuration names it, such as ledger.
+	Type ResourceType
+	// DisplayName is the name of the type in the diagrams, such as Ledger. It defaults to the type.
+	DisplayName string
+	// StylePattern is the regular expression matching the draw.io styles of the resource.
+	StylePattern string
+	// Labels are the Terraform resource types of the resource, such as acme_ledger.
+	Labels []string
+	// NameAttribute is the attribute of the Terraform resource holding the name of the resource. It defaults to name.
+	NameAttribute string
+	// EnvarSuffixes are the suffixes of the lambda environment variables referring to the resource, such as
+	// LEDGER_NAME.
+	EnvarSuffixes []string
+	// Image is the image of the resource in the drawn diagrams.
+	Image string
+	// Schema is the JSON Schema of the configuration of each resource.
+	Schema map[string]any
+	// Templates are the templates generating the files of the resources, by file name.
+	Templates map[string]string
+}
+
+type registeredType struct {
+	ResourceTypeDefinition
+
+	style *regexp.Regexp
+}
+
+var (
+	// registeredTypes are the resource types registered by RegisterResourceType, guarded by registeredTypesMutex.
+	registeredTypes      = map[ResourceType]*registeredType{}
+	registeredTypesMutex sync.RWMutex
+)
+
+// RegisterResourceType registers the resource type. Registering a type again replaces its definition, but the
+// built-in types cannot be redefined, and the display name and the labels cannot be the ones of another type.
+func RegisterResourceType(def ResourceTypeDefinition) error {
+	def.Type = ResourceType(strings.ToLower(string(def.Type)))
+
+	if def.Type == "" {
+		return fmt.Errorf("%w: the type is required", ErrInvalidResourceType)
+	}
+
+	if def.DisplayName == "" {
+		def.DisplayName = string(def.Type)
+	}
+
+	if def.NameAttribute == "" {
+		def.NameAttribute = "name"
+	}
+
+	for _, name := range []string{string(def.Type), def.DisplayName} {
+		if isBuiltInType(name) {
+			return fmt.Errorf("%w: '%s' is a built-in type", ErrInvalidResourceType, name)
+		}
+	}
+
+	registered := &registeredType{ResourceTypeDefinition: def}
+
+	if def.StylePattern != "" {
+		style, err := regexp.Compile(def.StylePattern)
+		if err != nil {
+			return fmt.Errorf("%w: style of '%s': %w", ErrInvalidResourceType, def.Type, err)
+		}
+
+		registered.style = style
+	}
+
+	registeredTypesMutex.Lock()
+	defer registeredTypesMutex.Unlock()
+
+	// The types are formatted as strings, because ResourceType.String takes the lock to look up the registered types.
+	for _, other := range registeredTypes {
+		if other.Type == def.Type {
+			continue
+		}
+
+		for _, name := range []string{string(def.Type), def.DisplayName} {
+			if strings.EqualFold(string(other.Type), name) || strings.EqualFold(other.DisplayName, name) {
+				return fmt.Errorf("%w: '%s' is the type '%s'", ErrInvalidResourceType, name, string(other.Type))
+			}
+		}
+
+		for _, label := range def.Labels {
+			if slices.Contains(other.Labels, label) {
+				return fmt.Errorf("%w: the label '%s' is of the type '%s'", ErrInvalidResourceType, label,
+					string(other.Type))
+			}
+		}
+	}
+
+	registeredTypes[def.Type] = registered
+
+	return nil
+}
+
+// ResetResourceTypes unregisters every registered resource type.
+func ResetResourceTypes() {
+	registeredTypesMutex.Lock()
+	defer registeredTypesMutex.Unlock()
+
+	registeredTypes = map[ResourceType]*registeredType{}
+}
+
+// RegisteredResourceTypes returns the definitions of the registered resource types, sorted by type.
+func RegisteredResourceTypes() []ResourceTypeDefinition {
+	registeredTypesMutex.RLock()
+	defer registeredTypesMutex.RUnlock()
+
+	defs := make([]ResourceTypeDefinition, 0, len(registeredTypes))
+	for _, registered := range sortedRegisteredTypes() {
+		defs = append(defs, registered.ResourceTypeDefinition)
+	}
+
+	return defs
+}
+
+// sortedRegisteredTypes returns the registered resource types sorted by type, so the lookups do not depend on the
+// order of the map. The callers hold registeredTypesMutex.
+func sortedRegisteredTypes() []*registeredType {
+	sorted := make([]*registeredType, 0, len(registeredTypes))
+	for _, registered := range registeredTypes {
+		sorted = append(sorted, registered)
+	}
+
+	slices.SortFunc(sorted, func(a, b *registeredType) int {
+		return strings.Compare(string(a.Type), string(b.Type))
+	})
+
+	return sorted
+}
+
+// LookupResourceType returns the definition of the registered resource type.
+func LookupResourceType(resourceType ResourceType) (ResourceTypeDefinition, bool) {
+	registeredTypesMutex.RLock()
+	defer registeredTypesMutex.RUnlock()
+
+	registered, ok := registeredTypes[resourceType]
+	if !ok {
+		return ResourceTypeDefinition{}, false
+	}
+
+	return registered.ResourceTypeDefinition, true
+}
+
+// ResourceTypeOfLabel returns the registered resource type of the Terraform resource type.
+func ResourceTypeOfLabel(label string) (ResourceType, bool) {
+	for _, def := range RegisteredResourceTypes() {
+		if slices.Contains(def.Labels, label) {
+			return def.Type, true
+		}
+	}
+
+	return UnknownType, false
+}
+
+// ResourceTypeOfEnvar returns the registered resource type the lambda environment variable refers to, and the suffix
+// of the variable.
+func ResourceTypeOfEnvar(envar string) (resourceType ResourceType, suffix string, ok bool) {
+	for _, def := range RegisteredResourceTypes() {
+		for _, suffix := range def.EnvarSuffixes {
+			if strings.HasSuffix(envar, suffix) {
+				return def.Type, suffix, true
+			}
+		}
+	}
+
+	return UnknownType, "", false
+}
+
+// Types returns the display names of the built-in and the registered resource types.
+func Types() []string {
+	types := slices.Clone(AvailableTypes)
+	for _, def := range RegisteredResourceTypes() {
+		types = append(types, def.DisplayName)
+	}
+
+	return types
+}
+
+func isRegisteredLabel(label string) bool {
+	_, ok := ResourceTypeOfLabel(label)
+	return ok
+}
+
+func registeredTypeOfStyle(style string) (ResourceType, bool) {
+	registeredTypesMutex.RLock()
+	defer registeredTypesMutex.RUnlock()
+
+	for _, registered := range sortedRegisteredTypes() {
+		if registered.style != nil && registered.style.MatchString(style) {
+			return registered.Type, true
+		}
+	}
+
+	return UnknownType, false
+}
+
+func registeredTypeOfName(name string) (ResourceType, bool) {
+	for _, def := range RegisteredResourceTypes() {
+		if strings.EqualFold(string(def.Type), name) || strings.EqualFold(def.DisplayName, name) {
+			return def.Type, true
+		}
+	}
+
+	return UnknownType, false
+}
+
+func isBuiltInType(name string) bool {
+	return strings.EqualFold(name, string(UnknownType)) ||
+		slices.ContainsFunc(AvailableTypes, func(t string) bool { return strings.EqualFold(t, name) })
+}
@@ -0,0 +1,141 @@
+package resources
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterResourceType(t *testing.T) {
+	t.Cleanup(ResetResourceTypes)
+
+	require.NoError(t, RegisterResourceType(ResourceTypeDefinition{
+		Type: "ledger", DisplayName: "Ledger", Labels: []string{"acme_ledger"},
+	}))
+
+	tests := []struct {
+		name      string
+		def       ResourceTypeDefinition
+		targetErr error
+	}{
+		{
+			name: "company-specific type",
+			def: ResourceTypeDefinition{
+				Type: "Vault", DisplayName: "AcmeVault", StylePattern: `mxgraph\.acme\.vault`,
+				Labels: []string{"acme_vault"}, EnvarSuffixes: []string{"VAULT_NAME"},
+			},
+		},
+		{name: "missing type", def: ResourceTypeDefinition{}, targetErr: ErrInvalidResourceType},
+		{name: "built-in type", def: ResourceTypeDefinition{Type: "sqs"}, targetErr: ErrInvalidResourceType},
+		{
+			name:      "built-in display name",
+			def:       ResourceTypeDefinition{Type: "queue", DisplayName: "SQS"},
+			targetErr: ErrInvalidResourceType,
+		},
+		{
+			name:      "invalid style",
+			def:       ResourceTypeDefinition{Type: "queue", StylePattern: "("},
+			targetErr: ErrInvalidResourceType,
+		},
+		{
+			name: "type registered again",
+			def:  ResourceTypeDefinition{Type: "ledger", DisplayName: "Ledger", Labels: []string{"acme_ledger"}},
+		},
+		{
+			name:      "display name of another type",
+			def:       ResourceTypeDefinition{Type: "journal", DisplayName: "ledger"},
+			targetErr: ErrInvalidResourceType,
+		},
+		{
+			name:      "label of another type",
+			def:       ResourceTypeDefinition{Type: "journal", Labels: []string{"acme_journal", "acme_ledger"}},
+			targetErr: ErrInvalidResourceType,
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			err := RegisterResourceType(tc.def)
+
+			require.ErrorIs(t, err, tc.targetErr)
+		})
+	}
+}
+
+func TestRegisteredResourceType(t *testing.T) {
+	t.Cleanup(ResetResourceTypes)
+
+	require.NoError(t, RegisterResourceType(ResourceTypeDefinition{
+		Type: "vault", DisplayName: "AcmeVault", StylePattern: `mxgraph\.acme\.vault`,
+		Labels: []string{"acme_vault"}, EnvarSuffixes: []string{"VAULT_NAME"},
+	}))
+
+	vault := ResourceType("vault")
+
+	require.Equal(t, "AcmeVault", vault.String())
+	require.Equal(t, vault, ParseResourceType("AcmeVault"))
+	require.Equal(t, vault, ParseResourceType("vault"))
+	require.Contains(t, Types(), "AcmeVault")
+
+	def, ok := LookupResourceType(vault)
+	require.True(t, ok)
+	require.Equal(t, "name", def.NameAttribute)
+
+	resource := (&AWSResourceFactory{}).CreateResource("1", "secrets", "shape=mxgraph.acme.vault")
+	require.NotNil(t, resource)
+	require.Equal(t, "AcmeVault", resource.ResourceType())
+
+	resourceType, suffix, ok := ResourceTypeOfEnvar("PAYMENTS_VAULT_NAME")
+	require.True(t, ok)
+	require.Equal(t, vault, resourceType)
+	require.Equal(t, "VAULT_NAME", suffix)
+
+	require.Equal(t, ResourceARN{Type: "acme_vault", Label: "payments"},
+		ParseResourceARN("acme_vault.payments.name", UnknownType))
+	require.Equal(t, ResourceARN{Type: "acme_vault", Name: "payments"}, ParseResourceARN("payments", vault))
+}
+
+func TestResetResourceTypes(t *testing.T) {
+	require.NoError(t, RegisterResourceType(ResourceTypeDefinition{Type: "vault", Labels: []string{"acme_vault"}}))
+
+	ResetResourceTypes()
+
+	_, ok := LookupResourceType("vault")
+	require.False(t, ok)
+
+	_, ok = ResourceTypeOfLabel("acme_vault")
+	require.False(t, ok)
+	require.Equal(t, UnknownType, ParseResourceType("vault"))
+}
+
+func TestRegisteredResourceTypeConcurrentRegistration(t *testing.T) {
+	t.Cleanup(ResetResourceTypes)
+
+	vault := ResourceType("vault")
+	def := ResourceTypeDefinition{Type: vault, DisplayName: "AcmeVault", Labels: []string{"acme_vault"}}
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < 100; i++ {
+			_ = RegisterResourceType(def)
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		_ = vault.String()
+		_ = labelOf(vault)
+	}
+
+	wg.Wait()
+
+	require.Equal(t, "AcmeVault", vault.String())
+	require.Equal(t, "acme_vault", labelOf(vault))
+}
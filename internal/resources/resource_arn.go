@@ -22,15 +22,19 @@ var arnKeySuffix = map[string]string{
 }
 
 var labelByResourceType = map[ResourceType]string{
-	APIGatewayType: LabelAWSAPIGatewayRoute,
-	CronType:       LabelAWSCron,
-	EndpointType:   LabelAWSEndpoint,
-	KinesisType:    LabelAWSKinesisStream,
-	LambdaType:     LabelAWSLambdaFunction,
-	S3Type:         LabelAWSS3Bucket,
-	SQSType:        LabelAWSSQSQueue,
-	SNSType:        LabelAWSSNSTopic,
-	UnknownType:    "",
+	APIGatewayType:   LabelAWSAPIGatewayRoute,
+	CronType:         LabelAWSCron,
+	EndpointType:     LabelAWSEndpoint,
+	EventBusType:     LabelAWSEventBus,
+	FirehoseType:     LabelAWSKinesisFirehose,
+	KinesisType:      LabelAWSKinesisStream,
+	LambdaType:       LabelAWSLambdaFunction,
+	S3Type:           LabelAWSS3Bucket,
+	SQSType:          LabelAWSSQSQueue,
+	SNSType:          LabelAWSSNSTopic,
+	StepFunctionType: LabelAWSSFNStateMachine,
+	WebSocketType:    LabelAWSAPIGatewayRoute,
+	UnknownType:      "",
 }
 
 type ResourceARN struct {
@@ -65,7 +69,7 @@ func ParseResourceARN(arn string, suggestedResType ResourceType) ResourceARN {
 	}
 
 	if arnType == "" {
-		arnType = labelByResourceType[suggestedResType]
+		arnType = labelOf(suggestedResType)
 	}
 
 	return ResourceARN{Type: arnType, Name: name, Label: label}
@@ -103,7 +107,7 @@ func parseARNTypeAndName(arn string) (arnType, name, label string) {
 		// TODO: Implement other modules.
 		arnType = LabelAWSLambdaFunction
 		label = parts[1]
-	} else if len(parts) > 1 && strings.HasPrefix(parts[0], "aws_") {
+	} else if len(parts) > 1 && (strings.HasPrefix(parts[0], "aws_") || isRegisteredLabel(parts[0])) {
 		arnType = parts[0]
 		label = parts[1]
 	} else {
@@ -115,17 +119,37 @@ func parseARNTypeAndName(arn string) (arnType, name, label string) {
 
 func inferResourceType(arnType string) ResourceType {
 	switch arnType {
+	case LabelAWSEventBus:
+		return EventBusType
+	case LabelAWSKinesisFirehose:
+		return FirehoseType
 	case LabelAWSKinesisStream:
 		return KinesisType
 	case LabelAWSLambdaFunction:
 		return LambdaType
 	case LabelAWSS3Bucket:
 		return S3Type
+	case LabelAWSSFNStateMachine:
+		return StepFunctionType
 	case LabelAWSSNSTopic:
 		return SNSType
 	case LabelAWSSQSQueue:
 		return SQSType
 	default:
-		return UnknownType
+		resourceType, _ := ResourceTypeOfLabel(arnType)
+		return resourceType
 	}
 }
+
+// labelOf returns the Terraform resource type of the resource type.
+func labelOf(resourceType ResourceType) string {
+	if label, ok := labelByResourceType[resourceType]; ok {
+		return label
+	}
+
+	if def, ok := LookupResourceType(resourceType); ok && len(def.Labels) > 0 {
+		return def.Labels[0]
+	}
+
+	return ""
+}
@@ -14,6 +14,8 @@ func (f *AWSResourceFactory) CreateResource(id, value, style string) resources.R
 	reAPIGateway := regexp.MustCompile("mxgraph.aws3.api_gateway|mxgraph.aws4.api_gateway")
 	reDatabase := regexp.MustCompile(`mxgraph.flowchart.database|mxgraph.aws3.dynamo_db|mxgraph.aws4.database|` +
 		`mxgraph.aws4.documentdb_with_mongodb_compatibility`)
+	reEventBus := regexp.MustCompile(`mxgraph.aws4.eventbridge|mxgraph.aws4.event_bus`)
+	reFirehose := regexp.MustCompile(`mxgraph.aws3.kinesis_firehose|mxgraph.aws4.kinesis_data_firehose`)
 	reGoogleBQ := regexp.MustCompile("mxgraph.gcp2.big_query|google_bigquery")
 	reKinesis := regexp.MustCompile(`mxgraph.aws3.kinesis|mxgraph.aws4.kinesis`)
 	resLambda := regexp.MustCompile(`mxgraph.aws3.lambda|mxgraph.aws4.lambda`)
@@ -21,8 +23,11 @@ func (f *AWSResourceFactory) CreateResource(id, value, style string) resources.R
 	reS3 := regexp.MustCompile(`mxgraph.aws3.s3|mxgraph.aws4.s3`)
 	reSQS := regexp.MustCompile(`mxgraph.aws3.sqs|mxgraph.aws4.sqs`)
 	reSNS := regexp.MustCompile(`mxgraph.aws3.sns|mxgraph.aws4.sns`)
+	reStepFunction := regexp.MustCompile(`mxgraph.aws3.step_functions|mxgraph.aws4.step_functions`)
 
 	switch {
+	case reAPIGateway.MatchString(style) && (IsWebSocketRouteKey(value) || strings.Contains(style, "websocket")):
+		return resources.NewGenericResource(id, value, WebSocketType.String())
 	case reAPIGateway.MatchString(style):
 		return resources.NewGenericResource(id, value, APIGatewayType.String())
 	case strings.Contains(style, "mxgraph.aws4.event_time_based"):
@@ -31,6 +36,10 @@ func (f *AWSResourceFactory) CreateResource(id, value, style string) resources.R
 		return resources.NewGenericResource(id, value, DatabaseType.String())
 	case strings.Contains(style, "mxgraph.aws4.endpoint"):
 		return resources.NewGenericResource(id, value, EndpointType.String())
+	case reEventBus.MatchString(style):
+		return resources.NewGenericResource(id, value, EventBusType.String())
+	case reFirehose.MatchString(style):
+		return resources.NewGenericResource(id, value, FirehoseType.String())
 	case reGoogleBQ.MatchString(style):
 		return resources.NewGenericResource(id, value, GoogleBQType.String())
 	case reKinesis.MatchString(style):
@@ -45,7 +54,13 @@ func (f *AWSResourceFactory) CreateResource(id, value, style string) resources.R
 		return resources.NewGenericResource(id, value, SQSType.String())
 	case reSNS.MatchString(style):
 		return resources.NewGenericResource(id, value, SNSType.String())
+	case reStepFunction.MatchString(style):
+		return resources.NewGenericResource(id, value, StepFunctionType.String())
 	default:
+		if resourceType, ok := registeredTypeOfStyle(style); ok {
+			return resources.NewGenericResource(id, value, resourceType.String())
+		}
+
 		return nil
 	}
 }
@@ -55,6 +55,24 @@ func TestAWSResourceFactory_CreateResource(t *testing.T) {
 			},
 			want: resources.NewGenericResource("ENDPOINT_ID", "myEndpoint", EndpointType.String()),
 		},
+		{
+			name: "EventBus Resource",
+			args: args{
+				id:    "EVENT_BUS_ID",
+				value: "myEventBus",
+				style: "mxgraph.aws4.eventbridge_custom_event_bus_resource",
+			},
+			want: resources.NewGenericResource("EVENT_BUS_ID", "myEventBus", EventBusType.String()),
+		},
+		{
+			name: "Firehose Resource",
+			args: args{
+				id:    "FIREHOSE_ID",
+				value: "myFirehose",
+				style: "mxgraph.aws4.kinesis_data_firehose",
+			},
+			want: resources.NewGenericResource("FIREHOSE_ID", "myFirehose", FirehoseType.String()),
+		},
 		{
 			name: "GoogleBQ Resource",
 			args: args{
@@ -118,6 +136,51 @@ func TestAWSResourceFactory_CreateResource(t *testing.T) {
 			},
 			want: resources.NewGenericResource("SNS_ID", "my-sns", SNSType.String()),
 		},
+		{
+			name: "StepFunction Resource",
+			args: args{
+				id:    "STEP_FUNCTION_ID",
+				value: "myStateMachine",
+				style: "mxgraph.aws4.step_functions",
+			},
+			want: resources.NewGenericResource("STEP_FUNCTION_ID", "myStateMachine", StepFunctionType.String()),
+		},
+		{
+			name: "WebSocket Resource",
+			args: args{
+				id:    "WEBSOCKET_ID",
+				value: "$connect",
+				style: "mxgraph.aws4.api_gateway",
+			},
+			want: resources.NewGenericResource("WEBSOCKET_ID", "$connect", WebSocketType.String()),
+		},
+		{
+			name: "WebSocket Resource with custom route key",
+			args: args{
+				id:    "WEBSOCKET_ID",
+				value: "sendMessage",
+				style: "mxgraph.aws4.api_gateway;websocket",
+			},
+			want: resources.NewGenericResource("WEBSOCKET_ID", "sendMessage", WebSocketType.String()),
+		},
+		{
+			name: "HTTP API default route",
+			args: args{
+				id:    "APIG_ID",
+				value: "$default",
+				style: "mxgraph.aws4.api_gateway",
+			},
+			want: resources.NewGenericResource("APIG_ID", "$default", APIGatewayType.String()),
+		},
+		{
+			name: "WebSocket default route",
+			args: args{
+				id:    "WEBSOCKET_ID",
+				value: "$default",
+				style: "mxgraph.aws4.api_gateway;websocket",
+			},
+			want: resources.NewGenericResource("WEBSOCKET_ID", "$default", WebSocketType.String()),
+		},
 		{
 			name: "Unknown",
 			args: args{
@@ -19,6 +19,12 @@ const (
 	// EndpointType represents the Endpoint resource type.
 	EndpointType ResourceType = "endpoint"
 
+	// EventBusType represents the EventBridge event bus resource type.
+	EventBusType ResourceType = "eventbus"
+
+	// FirehoseType represents the Kinesis Data Firehose resource type.
+	FirehoseType ResourceType = "firehose"
+
 	// GoogleBQType represents the Google BigQuery resource type.
 	GoogleBQType ResourceType = "googlebq"
 
@@ -34,12 +40,18 @@ const (
 	// S3Type represents the S3 resource type.
 	S3Type ResourceType = "s3"
 
+	// StepFunctionType represents the Step Functions state machine resource type.
+	StepFunctionType ResourceType = "stepfunction"
+
 	// SNSType represents the SNS resource type.
 	SNSType ResourceType = "sns"
 
 	// SQSType represents the SQS resource type.
 	SQSType ResourceType = "sqs"
 
+	// WebSocketType represents the WebSocket API route resource type.
+	WebSocketType ResourceType = "websocket"
+
 	// UnknownType represents an unknown resource type.
 	UnknownType ResourceType = "unknown"
 )
@@ -49,13 +61,17 @@ var AvailableTypes = []string{
 	CronType.String(),
 	DatabaseType.String(),
 	EndpointType.String(),
+	EventBusType.String(),
+	FirehoseType.String(),
 	GoogleBQType.String(),
 	KinesisType.String(),
 	LambdaType.String(),
 	RestfulAPIType.String(),
 	S3Type.String(),
 	SQSType.String(),
+	StepFunctionType.String(),
 	SNSType.String(),
+	WebSocketType.String(),
 }
 
 // String returns the string representation of a ResourceType.
@@ -69,6 +85,10 @@ func (rt ResourceType) String() string {
 		return "Database"
 	case EndpointType:
 		return "Endpoint"
+	case EventBusType:
+		return "EventBus"
+	case FirehoseType:
+		return "Firehose"
 	case GoogleBQType:
 		return "GoogleBQ"
 	case KinesisType:
@@ -79,11 +99,19 @@ func (rt ResourceType) String() string {
 		return "RestfulAPI"
 	case S3Type:
 		return "S3"
+	case StepFunctionType:
+		return "StepFunction"
 	case SNSType:
 		return "SNS"
 	case SQSType:
 		return "SQS"
+	case WebSocketType:
+		return "WebSocket"
 	default:
+		if def, ok := LookupResourceType(rt); ok {
+			return def.DisplayName
+		}
+
 		return "Unknown"
 	}
 }
@@ -99,6 +127,10 @@ func ParseResourceType(s string) ResourceType {
 		return DatabaseType
 	case "endpoint":
 		return EndpointType
+	case "eventbus":
+		return EventBusType
+	case "firehose":
+		return FirehoseType
 	case "googlebq":
 		return GoogleBQType
 	case "kinesis":
@@ -109,11 +141,19 @@ func ParseResourceType(s string) ResourceType {
 		return RestfulAPIType
 	case "s3":
 		return S3Type
+	case "stepfunction":
+		return StepFunctionType
 	case "sns":
 		return SNSType
 	case "sqs":
 		return SQSType
+	case "websocket":
+		return WebSocketType
 	default:
+		if resourceType, ok := registeredTypeOfName(s); ok {
+			return resourceType
+		}
+
 		return UnknownType
 	}
 }
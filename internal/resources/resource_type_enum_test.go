@@ -16,6 +16,8 @@ func TestResourceType_String(t *testing.T) {
 		{name: "Cron", rt: CronType, want: "Cron"},
 		{name: "Database", rt: DatabaseType, want: "Database"},
 		{name: "Endpoint", rt: EndpointType, want: "Endpoint"},
+		{name: "EventBus", rt: EventBusType, want: "EventBus"},
+		{name: "Firehose", rt: FirehoseType, want: "Firehose"},
 		{name: "GoogleBQ", rt: GoogleBQType, want: "GoogleBQ"},
 		{name: "Kinesis", rt: KinesisType, want: "Kinesis"},
 		{name: "Lambda", rt: LambdaType, want: "Lambda"},
@@ -23,6 +25,8 @@ func TestResourceType_String(t *testing.T) {
 		{name: "S3", rt: S3Type, want: "S3"},
 		{name: "SQS", rt: SQSType, want: "SQS"},
 		{name: "SNS", rt: SNSType, want: "SNS"},
+		{name: "StepFunction", rt: StepFunctionType, want: "StepFunction"},
+		{name: "WebSocket", rt: WebSocketType, want: "WebSocket"},
 		{name: "Unknown", rt: "", want: "Unknown"},
 	}
 
@@ -47,6 +51,8 @@ func TestParseResourceType(t *testing.T) {
 		{name: "Parse Cron", input: "Cron", output: CronType},
 		{name: "Parse Database", input: "Database", output: DatabaseType},
 		{name: "Parse Endpoint", input: "Endpoint", output: EndpointType},
+		{name: "Parse EventBus", input: "EventBus", output: EventBusType},
+		{name: "Parse Firehose", input: "Firehose", output: FirehoseType},
 		{name: "Parse GoogleBQ", input: "GoogleBQ", output: GoogleBQType},
 		{name: "Parse Kinesis", input: "Kinesis", output: KinesisType},
 		{name: "Parse Lambda", input: "Lambda", output: LambdaType},
@@ -54,6 +60,8 @@ func TestParseResourceType(t *testing.T) {
 		{name: "Parse S3", input: "S3", output: S3Type},
 		{name: "Parse SQS", input: "SQS", output: SQSType},
 		{name: "Parse SNS", input: "SNS", output: SNSType},
+		{name: "Parse StepFunction", input: "StepFunction", output: StepFunctionType},
+		{name: "Parse WebSocket", input: "WebSocket", output: WebSocketType},
 		{name: "Parse Unknown", input: "Unknown", output: UnknownType},
 		{name: "Parse lowercase", input: "sqs", output: SQSType},
 		{name: "Parse uppercase", input: "SNS", output: SNSType},
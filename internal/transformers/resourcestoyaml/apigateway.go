@@ -1,6 +1,10 @@
 package resourcestoyaml
 
 import (
+	"fmt"
+	"net/url"
+	"strings"
+
 	"github.com/diagram-code-generator/resources/pkg/resources"
 
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
@@ -32,5 +36,70 @@ func (t *Transformer) buildAPIGateways(
 		})
 	}
 
+	apiGateways = append(apiGateways, t.buildWebSocketAPIs(apiGatewayLambdasByAPIGatewayID)...)
+
 	return apiGateways
 }
+
+// buildWebSocketAPIs groups the WebSocket routes of the diagram by their parent API, which is the endpoint linked to
+// the routes. The routes without an endpoint are grouped into one more API. When a stack has several APIs, the ones
+// with an endpoint are named after it, so that each one is generated in its own file.
+func (t *Transformer) buildWebSocketAPIs(
+	apiGatewayLambdasByAPIGatewayID map[string][]config.APIGatewayLambda,
+) []config.APIGateway {
+	webSocketAPIs := []config.APIGateway{}
+	indexByParentID := map[string]int{}
+
+	for _, route := range t.resourcesByTypeMap[awsresources.WebSocketType] {
+		var parentID, apiDomainValue string
+		if rsc, ok := t.endpointsByAPIGatewayID[route.ID()]; ok {
+			parentID, apiDomainValue = rsc.ID(), rsc.Value()
+		}
+
+		i, ok := indexByParentID[parentID]
+		if !ok {
+			i = len(webSocketAPIs)
+			indexByParentID[parentID] = i
+
+			webSocketAPIs = append(webSocketAPIs, config.APIGateway{
+				StackName: t.yamlConfig.Diagram.StackName,
+				APIG:      true,
+				APIDomain: apiDomainValue,
+				Protocol:  config.ProtocolWebSocket,
+			})
+		}
+
+		webSocketAPIs[i].Lambdas = append(webSocketAPIs[i].Lambdas, apiGatewayLambdasByAPIGatewayID[route.ID()]...)
+	}
+
+	if len(webSocketAPIs) > 1 {
+		nameWebSocketAPIs(webSocketAPIs)
+	}
+
+	return webSocketAPIs
+}
+
+// nameWebSocketAPIs names the APIs with a domain after its first label, such as chat for wss://chat.example.com. The
+// names already taken get the position of the API as a suffix.
+func nameWebSocketAPIs(webSocketAPIs []config.APIGateway) {
+	names := map[string]struct{}{}
+
+	for i := range webSocketAPIs {
+		if webSocketAPIs[i].APIDomain == "" {
+			continue
+		}
+
+		domain := webSocketAPIs[i].APIDomain
+		if u, err := url.Parse(domain); err == nil && u.Host != "" {
+			domain = u.Host
+		}
+
+		name := strings.Split(domain, ".")[0]
+		if _, ok := names[name]; ok || name == "" {
+			name = fmt.Sprintf("%s%d", name, i+1)
+		}
+
+		names[name] = struct{}{}
+		webSocketAPIs[i].Name = name
+	}
+}
@@ -0,0 +1,50 @@
+package resourcestoyaml
+
+import (
+	"fmt"
+
+	"github.com/ettle/strcase"
+
+	"github.com/diagram-code-generator/resources/pkg/resources"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
+)
+
+func (t *Transformer) buildCustomRelationships(source, target resources.Resource) {
+	if awsresources.ParseResourceType(source.ResourceType()) == awsresources.LambdaType {
+		t.buildLambdaToCustom(source, target)
+	}
+}
+
+// buildLambdaToCustom refers the lambda to the resource of a registered type with the first envar suffix of the type.
+func (t *Transformer) buildLambdaToCustom(lambda, target resources.Resource) {
+	resourceType := awsresources.ParseResourceType(target.ResourceType())
+
+	def, _ := awsresources.LookupResourceType(resourceType)
+	if len(def.EnvarSuffixes) == 0 || len(def.Labels) == 0 {
+		return
+	}
+
+	targetName := t.initLambdaEnvarsAndGetTargetName(lambda, target)
+
+	t.envars[lambda.ID()][fmt.Sprintf("%s_%s", strcase.ToSNAKE(targetName), def.EnvarSuffixes[0])] =
+		fmt.Sprintf("%s.%s.%s", def.Labels[0], t.yamlConfig.Naming.Label(resourceType, targetName), def.NameAttribute)
+}
+
+func (t *Transformer) buildCustomResources() map[string][]config.CustomResource {
+	var customResources map[string][]config.CustomResource
+
+	for _, def := range awsresources.RegisteredResourceTypes() {
+		for _, resource := range t.resourcesByTypeMap[def.Type] {
+			if customResources == nil {
+				customResources = map[string][]config.CustomResource{}
+			}
+
+			customResources[string(def.Type)] = append(customResources[string(def.Type)],
+				config.CustomResource{"name": resource.Value()})
+		}
+	}
+
+	return customResources
+}
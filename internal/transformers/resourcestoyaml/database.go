@@ -3,6 +3,7 @@ package resourcestoyaml
 import (
 	"github.com/diagram-code-generator/resources/pkg/resources"
 
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
 )
 
@@ -11,3 +12,13 @@ func (t *Transformer) buildDatabaseRelationship(source, target resources.Resourc
 		t.buildLambdaToDatabase(source, target)
 	}
 }
+
+func (t *Transformer) buildRDS() []config.RDS {
+	var databases []config.RDS
+
+	for _, database := range t.resourcesByTypeMap[awsresources.DatabaseType] {
+		databases = append(databases, config.RDS{Name: database.Value()})
+	}
+
+	return databases
+}
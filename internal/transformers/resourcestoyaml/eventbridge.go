@@ -0,0 +1,42 @@
+package resourcestoyaml
+
+import (
+	"fmt"
+
+	"github.com/ettle/strcase"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
+)
+
+// buildEventBuses creates one rule per event bus. The diagram does not describe which events each target receives,
+// so the rule matches the events whose source is the bus name.
+func (t *Transformer) buildEventBuses() []config.EventBus {
+	var eventBuses []config.EventBus
+
+	for _, eventBus := range t.resourcesByTypeMap[awsresources.EventBusType] {
+		rule := config.EventRule{
+			Name:         strcase.ToCamel(eventBus.Value()) + "Rule",
+			EventPattern: fmt.Sprintf(`{"source": [%q]}`, eventBus.Value()),
+		}
+
+		for _, target := range t.targetsByEventBusID[eventBus.ID()] {
+			eventTarget := config.EventTarget{Name: target.Value()}
+
+			switch awsresources.ParseResourceType(target.ResourceType()) {
+			case awsresources.KinesisType:
+				rule.Kinesis = append(rule.Kinesis, eventTarget)
+			case awsresources.LambdaType:
+				rule.Lambdas = append(rule.Lambdas, eventTarget)
+			case awsresources.SQSType:
+				rule.SQSs = append(rule.SQSs, eventTarget)
+			case awsresources.StepFunctionType:
+				rule.StepFunctions = append(rule.StepFunctions, eventTarget)
+			}
+		}
+
+		eventBuses = append(eventBuses, config.EventBus{Name: eventBus.Value(), Rules: []config.EventRule{rule}})
+	}
+
+	return eventBuses
+}
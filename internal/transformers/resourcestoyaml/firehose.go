@@ -0,0 +1,38 @@
+package resourcestoyaml
+
+import (
+	"github.com/diagram-code-generator/resources/pkg/resources"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
+)
+
+func (t *Transformer) buildFirehoseRelationship(source, target resources.Resource) {
+	if awsresources.ParseResourceType(source.ResourceType()) == awsresources.KinesisType {
+		t.buildKinesisToFirehose(source, target)
+	}
+}
+
+func (t *Transformer) buildFirehoses() []config.Firehose {
+	var firehoses []config.Firehose
+
+	for _, firehose := range t.resourcesByTypeMap[awsresources.FirehoseType] {
+		conf := config.Firehose{Name: firehose.Value()}
+
+		if kinesis, ok := t.kinesisByFirehoseID[firehose.ID()]; ok {
+			conf.KinesisSource = kinesis.Value()
+		}
+
+		if bucket, ok := t.s3BucketsByFirehoseID[firehose.ID()]; ok {
+			conf.Bucket = bucket.Value()
+		}
+
+		if lambda, ok := t.transformsByFirehoseID[firehose.ID()]; ok {
+			conf.TransformationLambda = lambda.Value()
+		}
+
+		firehoses = append(firehoses, conf)
+	}
+
+	return firehoses
+}
@@ -8,7 +8,10 @@ import (
 )
 
 func (t *Transformer) buildKinesisRelationship(source, target resources.Resource) {
-	if awsresources.ParseResourceType(source.ResourceType()) == awsresources.LambdaType {
+	switch awsresources.ParseResourceType(source.ResourceType()) {
+	case awsresources.EventBusType:
+		t.buildEventBusToTarget(source, target)
+	case awsresources.LambdaType:
 		t.buildLambdaToKinesis(source, target)
 	}
 }
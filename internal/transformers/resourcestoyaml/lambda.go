@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/ettle/strcase"
-
 	"github.com/diagram-code-generator/resources/pkg/resources"
 
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
@@ -16,12 +14,18 @@ func (t *Transformer) buildLambdaRelationships(source, target resources.Resource
 	switch awsresources.ParseResourceType(source.ResourceType()) {
 	case awsresources.CronType:
 		t.buildCronToLambda(source, target)
+	case awsresources.EventBusType:
+		t.buildEventBusToTarget(source, target)
+	case awsresources.FirehoseType:
+		t.buildFirehoseToLambda(source, target)
 	case awsresources.KinesisType:
 		t.buildKinesisToLambda(source, target)
 	case awsresources.SQSType:
 		t.buildSQSToLambda(source, target)
 	case awsresources.SNSType:
 		t.buildSNSToLambda(source, target)
+	case awsresources.StepFunctionType:
+		t.buildStepFunctionToTask(source, target)
 	}
 }
 
@@ -32,24 +36,35 @@ func (t *Transformer) buildLambdas() (
 	apiGatewayLambdaIDs := map[string]struct{}{}
 
 	for _, rel := range t.resc.Relationships {
+		sourceType := awsresources.ParseResourceType(rel.Source.ResourceType())
+
 		isAPIGatewayLambda := awsresources.ParseResourceType(rel.Target.ResourceType()) == awsresources.LambdaType &&
-			awsresources.ParseResourceType(rel.Source.ResourceType()) == awsresources.APIGatewayType
+			(sourceType == awsresources.APIGatewayType || sourceType == awsresources.WebSocketType)
 
 		if isAPIGatewayLambda {
 			lambda := rel.Target
 			apiGatewayID := rel.Source.ID()
 
+			apiGatewayLambda := config.APIGatewayLambda{
+				Name:        lambda.Value(),
+				Source:      t.yamlConfig.Diagram.Lambda.Source,
+				RoleName:    t.yamlConfig.Diagram.Lambda.RoleName,
+				Runtime:     t.yamlConfig.Diagram.Lambda.Runtime,
+				Description: fmt.Sprintf("%s lambda", lambda.Value()),
+				Envars:      t.envars[lambda.ID()],
+				Secrets:     t.secretsByLambdaID[lambda.ID()],
+				RDS:         t.rdsByLambdaID[lambda.ID()],
+			}
+
+			if sourceType == awsresources.WebSocketType {
+				apiGatewayLambda.RouteKey = rel.Source.Value()
+			} else {
+				apiGatewayLambda.Verb = strings.Split(rel.Source.Value(), " ")[0]
+				apiGatewayLambda.Path = strings.Split(rel.Source.Value(), " ")[1]
+			}
+
 			apiGatewayLambdasByAPIGatewayID[apiGatewayID] = append(
-				apiGatewayLambdasByAPIGatewayID[apiGatewayID], config.APIGatewayLambda{
-					Name:        lambda.Value(),
-					Source:      t.yamlConfig.Diagram.Lambda.Source,
-					RoleName:    t.yamlConfig.Diagram.Lambda.RoleName,
-					Runtime:     t.yamlConfig.Diagram.Lambda.Runtime,
-					Description: fmt.Sprintf("%s lambda", lambda.Value()),
-					Envars:      t.envars[lambda.ID()],
-					Verb:        strings.Split(rel.Source.Value(), " ")[0],
-					Path:        strings.Split(rel.Source.Value(), " ")[1],
-				})
+				apiGatewayLambdasByAPIGatewayID[apiGatewayID], apiGatewayLambda)
 
 			apiGatewayLambdaIDs[lambda.ID()] = struct{}{}
 		}
@@ -71,6 +86,8 @@ func (t *Transformer) buildLambdas() (
 			Runtime:         t.yamlConfig.Diagram.Lambda.Runtime,
 			Description:     fmt.Sprintf("%s lambda", lambda.Value()),
 			Envars:          t.envars[lambda.ID()],
+			Secrets:         t.secretsByLambdaID[lambda.ID()],
+			RDS:             t.rdsByLambdaID[lambda.ID()],
 			KinesisTriggers: kinesisTriggers,
 			SQSTriggers:     sqsTriggers,
 			Crons:           crons,
@@ -82,7 +99,7 @@ func (t *Transformer) buildLambdas() (
 
 func (t *Transformer) buildCrons(lambda resources.Resource) []config.Cron {
 	var crons []config.Cron
-	if cron, ok := t.cronsByLambdaID[lambda.ID()]; ok {
+	for _, cron := range t.cronsByLambdaID[lambda.ID()] {
 		crons = append(crons, config.Cron{
 			ScheduleExpression: cron.Value(),
 			IsEnabled:          "true",
@@ -96,7 +113,8 @@ func (t *Transformer) buildKinesisTriggers(lambda resources.Resource) []config.K
 	var kinesisTriggers []config.KinesisTrigger
 	for _, kinesisTrigger := range t.kinesisTriggersByLambdaID[lambda.ID()] {
 		kinesisTriggers = append(kinesisTriggers, config.KinesisTrigger{
-			SourceARN: fmt.Sprintf("aws_kinesis_stream.%s_kinesis.arn", strcase.ToSnake(kinesisTrigger.Value())),
+			SourceARN: fmt.Sprintf("aws_kinesis_stream.%s.arn",
+				t.yamlConfig.Naming.Label(awsresources.KinesisType, kinesisTrigger.Value())),
 		})
 	}
 
@@ -107,7 +125,7 @@ func (t *Transformer) buildSQSTriggers(lambda resources.Resource) []config.SQSTr
 	var sqsTriggers []config.SQSTrigger
 	for _, sqsTrigger := range t.sqsTriggersByLambdaID[lambda.ID()] {
 		sqsTriggers = append(sqsTriggers, config.SQSTrigger{
-			SourceARN: fmt.Sprintf("aws_sqs_queue.%s_sqs.arn", strcase.ToSnake(sqsTrigger.Value())),
+			SourceARN: fmt.Sprintf("aws_sqs_queue.%s.arn", t.yamlConfig.Naming.Label(awsresources.SQSType, sqsTrigger.Value())),
 		})
 	}
 
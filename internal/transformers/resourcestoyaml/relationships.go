@@ -2,15 +2,19 @@ package resourcestoyaml
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/ettle/strcase"
 
 	"github.com/diagram-code-generator/resources/pkg/resources"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
 )
 
 func (t *Transformer) buildCronToLambda(cron, lambda resources.Resource) {
-	t.cronsByLambdaID[lambda.ID()] = cron
+	t.cronsByLambdaID[lambda.ID()] = append(t.cronsByLambdaID[lambda.ID()], cron)
 }
 
 func (t *Transformer) buildEndpointToAPIGateway(endpoint, apiGateway resources.Resource) {
@@ -18,6 +22,22 @@ func (t *Transformer) buildEndpointToAPIGateway(endpoint, apiGateway resources.R
 	t.endpointsByAPIGatewayID[apiGatewayID] = endpoint
 }
 
+func (t *Transformer) buildEventBusToTarget(eventBus, target resources.Resource) {
+	t.targetsByEventBusID[eventBus.ID()] = append(t.targetsByEventBusID[eventBus.ID()], target)
+}
+
+func (t *Transformer) buildFirehoseToLambda(firehose, lambda resources.Resource) {
+	t.transformsByFirehoseID[firehose.ID()] = lambda
+}
+
+func (t *Transformer) buildFirehoseToS3(firehose, bucket resources.Resource) {
+	t.s3BucketsByFirehoseID[firehose.ID()] = bucket
+}
+
+func (t *Transformer) buildKinesisToFirehose(kinesis, firehose resources.Resource) {
+	t.kinesisByFirehoseID[firehose.ID()] = kinesis
+}
+
 func (t *Transformer) buildKinesisToLambda(kinesis, lambda resources.Resource,
 ) {
 	lambdaID := lambda.ID()
@@ -33,20 +53,36 @@ func (t *Transformer) buildLambdaVars(lambda, target resources.Resource, variabl
 	}
 }
 
+func (t *Transformer) buildLambdaSecret(lambda, target resources.Resource, suffix, description string) {
+	name := strcase.ToCamel(fmt.Sprintf("%s%s", target.Value(), suffix))
+
+	if !slices.Contains(t.secretsByLambdaID[lambda.ID()], name) {
+		t.secretsByLambdaID[lambda.ID()] = append(t.secretsByLambdaID[lambda.ID()], name)
+	}
+
+	if _, ok := t.secretByName[name]; !ok {
+		t.secretByName[name] = config.Secret{Name: name, Description: description}
+		t.secretNames = append(t.secretNames, name)
+	}
+}
+
 func (t *Transformer) buildLambdaToDatabase(lambda, database resources.Resource) {
-	t.buildLambdaVars(lambda, database, []string{"DB_HOST", "DB_USER", "DB_PASSWORD_SECRET"})
+	if !slices.Contains(t.rdsByLambdaID[lambda.ID()], database.Value()) {
+		t.rdsByLambdaID[lambda.ID()] = append(t.rdsByLambdaID[lambda.ID()], database.Value())
+	}
 }
 
 func (t *Transformer) buildLambdaToGoogleBQ(lambda, googleBQ resources.Resource) {
-	t.buildLambdaVars(lambda, googleBQ,
-		[]string{"BQ_PROJECT_ID", "BQ_API_KEY_SECRET", "BQ_PARTITION_FIELD", "BQ_CLUSTERING_FIELDS"})
+	t.buildLambdaVars(lambda, googleBQ, []string{"BQ_PROJECT_ID", "BQ_PARTITION_FIELD", "BQ_CLUSTERING_FIELDS"})
+	t.buildLambdaSecret(lambda, googleBQ, "BqApiKey", fmt.Sprintf("API key of the %s BigQuery", googleBQ.Value()))
 }
 
 func (t *Transformer) buildLambdaToKinesis(lambda, kinesis resources.Resource) {
 	kinesisName := t.initLambdaEnvarsAndGetTargetName(lambda, kinesis)
 
 	t.envars[lambda.ID()][fmt.Sprintf("%s_KINESIS_STREAM_URL",
-		strcase.ToSNAKE(kinesisName))] = fmt.Sprintf("aws_kinesis_stream.%s_kinesis.name", strcase.ToSnake(kinesisName))
+		strcase.ToSNAKE(kinesisName))] = fmt.Sprintf("aws_kinesis_stream.%s.name",
+		t.yamlConfig.Naming.Label(awsresources.KinesisType, kinesisName))
 }
 
 func (t *Transformer) buildLambdaToRestfulAPI(lambda, restfulAPI resources.Resource) {
@@ -57,7 +93,8 @@ func (t *Transformer) buildLambdaToS3(lambda, s3Bucket resources.Resource) {
 	bucketName := t.initLambdaEnvarsAndGetTargetName(lambda, s3Bucket)
 
 	t.envars[lambda.ID()][fmt.Sprintf("%s_S3_BUCKET",
-		strcase.ToSNAKE(bucketName))] = fmt.Sprintf("aws_s3_bucket.%s_bucket.bucket", strcase.ToSnake(bucketName))
+		strcase.ToSNAKE(bucketName))] = fmt.Sprintf("aws_s3_bucket.%s.bucket",
+		t.yamlConfig.Naming.Label(awsresources.S3Type, bucketName))
 	t.envars[lambda.ID()][fmt.Sprintf("%s_S3_DIRECTORY",
 		strcase.ToSNAKE(bucketName))] = fmt.Sprintf(`"%s_files"`, strings.ToLower(strcase.ToSnake(lambda.Value())))
 }
@@ -66,7 +103,8 @@ func (t *Transformer) buildLambdaToSQS(lambda, sqs resources.Resource) {
 	sqsName := t.initLambdaEnvarsAndGetTargetName(lambda, sqs)
 
 	t.envars[lambda.ID()][fmt.Sprintf("%s_SQS_QUEUE_URL",
-		strcase.ToSNAKE(sqsName))] = fmt.Sprintf("aws_sqs_queue.%s_sqs.name", strcase.ToSnake(sqsName))
+		strcase.ToSNAKE(sqsName))] = fmt.Sprintf("aws_sqs_queue.%s.name",
+		t.yamlConfig.Naming.Label(awsresources.SQSType, sqsName))
 }
 
 func (t *Transformer) buildS3ToSNS(s3Bucket, sns resources.Resource) {
@@ -86,6 +124,10 @@ func (t *Transformer) buildSQSToLambda(sqs, lambda resources.Resource) {
 	t.sqsTriggersByLambdaID[lambdaID] = append(t.sqsTriggersByLambdaID[lambdaID], sqs)
 }
 
+func (t *Transformer) buildStepFunctionToTask(stepFunction, task resources.Resource) {
+	t.tasksByStepFunctionID[stepFunction.ID()] = append(t.tasksByStepFunctionID[stepFunction.ID()], task)
+}
+
 func (t *Transformer) initEnvarsIfNecessaryByKey(key string) {
 	if _, ok := t.envars[key]; !ok {
 		t.envars[key] = map[string]string{}
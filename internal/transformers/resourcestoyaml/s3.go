@@ -8,7 +8,10 @@ import (
 )
 
 func (t *Transformer) buildS3Relationship(source, target resources.Resource) {
-	if awsresources.ParseResourceType(source.ResourceType()) == awsresources.LambdaType {
+	switch awsresources.ParseResourceType(source.ResourceType()) {
+	case awsresources.FirehoseType:
+		t.buildFirehoseToS3(source, target)
+	case awsresources.LambdaType:
 		t.buildLambdaToS3(source, target)
 	}
 }
@@ -9,10 +9,14 @@ import (
 
 func (t *Transformer) buildSQSRelationships(source, target resources.Resource) {
 	switch awsresources.ParseResourceType(source.ResourceType()) {
+	case awsresources.EventBusType:
+		t.buildEventBusToTarget(source, target)
 	case awsresources.LambdaType:
 		t.buildLambdaToSQS(source, target)
 	case awsresources.SNSType:
 		t.buildSNSToSQS(source, target)
+	case awsresources.StepFunctionType:
+		t.buildStepFunctionToTask(source, target)
 	}
 }
 
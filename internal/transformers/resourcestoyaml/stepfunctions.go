@@ -0,0 +1,53 @@
+package resourcestoyaml
+
+import (
+	"github.com/ettle/strcase"
+
+	"github.com/diagram-code-generator/resources/pkg/resources"
+
+	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
+	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
+)
+
+func (t *Transformer) buildStepFunctionRelationship(source, target resources.Resource) {
+	if awsresources.ParseResourceType(source.ResourceType()) == awsresources.EventBusType {
+		t.buildEventBusToTarget(source, target)
+	}
+}
+
+// buildStepFunctions creates one task state per connected resource. The diagram does not describe the workflow, so
+// the states run one after the other in the order the connections were drawn.
+func (t *Transformer) buildStepFunctions() []config.StepFunction {
+	var stepFunctions []config.StepFunction
+
+	for _, stepFunction := range t.resourcesByTypeMap[awsresources.StepFunctionType] {
+		tasks := t.tasksByStepFunctionID[stepFunction.ID()]
+
+		states := make([]config.StepFunctionState, 0, len(tasks)+1)
+
+		for _, task := range tasks {
+			switch awsresources.ParseResourceType(task.ResourceType()) {
+			case awsresources.LambdaType:
+				states = append(states, config.StepFunctionState{
+					Name:   "Invoke" + strcase.ToPascal(task.Value()),
+					Lambda: task.Value(),
+				})
+			case awsresources.SQSType:
+				states = append(states, config.StepFunctionState{
+					Name: "SendTo" + strcase.ToPascal(task.Value()),
+					SQS:  task.Value(),
+				})
+			}
+		}
+
+		states = append(states, config.StepFunctionState{Name: "Done", Type: "Succeed"})
+
+		for i := 0; i < len(states)-1; i++ {
+			states[i].Next = states[i+1].Name
+		}
+
+		stepFunctions = append(stepFunctions, config.StepFunction{Name: stepFunction.Value(), States: states})
+	}
+
+	return stepFunctions
+}
@@ -11,16 +11,27 @@ type Transformer struct {
 	yamlConfig *config.Config
 	resc       *resources.ResourceCollection
 
-	cronsByLambdaID           map[string]resources.Resource
+	cronsByLambdaID           map[string][]resources.Resource
 	endpointsByAPIGatewayID   map[string]resources.Resource
+	targetsByEventBusID       map[string][]resources.Resource
+	kinesisByFirehoseID       map[string]resources.Resource
 	kinesisTriggersByLambdaID map[string][]resources.Resource
 	lambdasBySNSID            map[string][]resources.Resource
+	s3BucketsByFirehoseID     map[string]resources.Resource
 	s3BucketsBySNSID          map[string]resources.Resource
 	sqssBySNSID               map[string][]resources.Resource
 	sqsTriggersByLambdaID     map[string][]resources.Resource
+	tasksByStepFunctionID     map[string][]resources.Resource
+	transformsByFirehoseID    map[string]resources.Resource
 
 	envars map[string]map[string]string
 
+	secretsByLambdaID map[string][]string
+	secretByName      map[string]config.Secret
+	secretNames       []string
+
+	rdsByLambdaID map[string][]string
+
 	resourcesByTypeMap map[awsresources.ResourceType][]resources.Resource
 }
 
@@ -29,16 +40,26 @@ func NewTransformer(yamlConfig *config.Config, resc *resources.ResourceCollectio
 		yamlConfig: yamlConfig,
 		resc:       resc,
 
-		cronsByLambdaID:           map[string]resources.Resource{},
+		cronsByLambdaID:           map[string][]resources.Resource{},
 		endpointsByAPIGatewayID:   map[string]resources.Resource{},
+		targetsByEventBusID:       map[string][]resources.Resource{},
+		kinesisByFirehoseID:       map[string]resources.Resource{},
 		kinesisTriggersByLambdaID: map[string][]resources.Resource{},
 		lambdasBySNSID:            map[string][]resources.Resource{},
+		s3BucketsByFirehoseID:     map[string]resources.Resource{},
 		s3BucketsBySNSID:          map[string]resources.Resource{},
 		sqsTriggersByLambdaID:     map[string][]resources.Resource{},
 		sqssBySNSID:               map[string][]resources.Resource{},
+		tasksByStepFunctionID:     map[string][]resources.Resource{},
+		transformsByFirehoseID:    map[string]resources.Resource{},
 
 		envars: map[string]map[string]string{},
 
+		secretsByLambdaID: map[string][]string{},
+		secretByName:      map[string]config.Secret{},
+
+		rdsByLambdaID: map[string][]string{},
+
 		resourcesByTypeMap: map[awsresources.ResourceType][]resources.Resource{},
 	}
 }
@@ -51,22 +72,43 @@ func (t *Transformer) Transform() (*config.Config, error) {
 	lambdas, apiGatewayLambdasByAPIGatewayID := t.buildLambdas()
 	apiGateways := t.buildAPIGateways(apiGatewayLambdasByAPIGatewayID)
 	kinesis := t.buildKinesis()
+	firehoses := t.buildFirehoses()
+	stepFunctions := t.buildStepFunctions()
+	eventBuses := t.buildEventBuses()
 	snss := t.buildSNSs()
 	sqss := t.buildSQSs()
 	buckets := t.buildS3Buckets()
 	restfulAPIs := t.buildRestfulAPIs()
+	secrets := t.buildSecrets()
+	databases := t.buildRDS()
+	customResources := t.buildCustomResources()
 
 	return &config.Config{
-		Lambdas:     lambdas,
-		APIGateways: apiGateways,
-		Kinesis:     kinesis,
-		SNSs:        snss,
-		SQSs:        sqss,
-		Buckets:     buckets,
-		RestfulAPIs: restfulAPIs,
+		Lambdas:         lambdas,
+		APIGateways:     apiGateways,
+		Kinesis:         kinesis,
+		Firehoses:       firehoses,
+		EventBuses:      eventBuses,
+		StepFunctions:   stepFunctions,
+		Secrets:         secrets,
+		RDS:             databases,
+		SNSs:            snss,
+		SQSs:            sqss,
+		Buckets:         buckets,
+		RestfulAPIs:     restfulAPIs,
+		CustomResources: customResources,
 	}, nil
 }
 
+func (t *Transformer) buildSecrets() []config.Secret {
+	var secrets []config.Secret
+	for _, name := range t.secretNames {
+		secrets = append(secrets, t.secretByName[name])
+	}
+
+	return secrets
+}
+
 func (t *Transformer) buildResourcesByTypeMap() {
 	for _, resource := range t.resc.Resources {
 		resType := awsresources.ParseResourceType(resource.ResourceType())
@@ -80,8 +122,10 @@ func (t *Transformer) buildResourceRelationships() {
 		source := rel.Source
 
 		switch awsresources.ParseResourceType(target.ResourceType()) {
-		case awsresources.APIGatewayType:
+		case awsresources.APIGatewayType, awsresources.WebSocketType:
 			t.buildAPIGatewayRelationship(source, target)
+		case awsresources.FirehoseType:
+			t.buildFirehoseRelationship(source, target)
 		case awsresources.GoogleBQType:
 			t.buildGoogleBQRelationship(source, target)
 		case awsresources.DatabaseType:
@@ -98,6 +142,10 @@ func (t *Transformer) buildResourceRelationships() {
 			t.buildSNSRelationship(source, target)
 		case awsresources.SQSType:
 			t.buildSQSRelationships(source, target)
+		case awsresources.StepFunctionType:
+			t.buildStepFunctionRelationship(source, target)
+		default:
+			t.buildCustomRelationships(source, target)
 		}
 	}
 }
@@ -30,6 +30,13 @@ func TestTransformDrawIOToYAML_APIGateway(t *testing.T) {
 	endpointResource := resources.NewGenericResource("id1", "https://my-domain.com", awsresources.EndpointType.String())
 	apiGatewayResource := resources.NewGenericResource("id2", "POST /examples", awsresources.APIGatewayType.String())
 	lambdaResource := resources.NewGenericResource("id3", "my-lambda", awsresources.LambdaType.String())
+	webSocketResource := resources.NewGenericResource("id4", "$connect", awsresources.WebSocketType.String())
+	chatEndpoint := resources.NewGenericResource("id5", "wss://chat.my-domain.com", awsresources.EndpointType.String())
+	chatRoute := resources.NewGenericResource("id6", "$connect", awsresources.WebSocketType.String())
+	chatLambda := resources.NewGenericResource("id7", "chat-lambda", awsresources.LambdaType.String())
+	gameEndpoint := resources.NewGenericResource("id8", "wss://game.my-domain.com", awsresources.EndpointType.String())
+	gameRoute := resources.NewGenericResource("id9", "move", awsresources.WebSocketType.String())
+	gameLambda := resources.NewGenericResource("id10", "game-lambda", awsresources.LambdaType.String())
 
 	tests := []struct {
 		name      string
@@ -90,6 +97,92 @@ func TestTransformDrawIOToYAML_APIGateway(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "WebSocket API",
+			args: args{
+				yamlConfig: diagramConfig,
+				resources: &resources.ResourceCollection{
+					Resources: []resources.Resource{
+						webSocketResource,
+						lambdaResource,
+					},
+					Relationships: []resources.Relationship{
+						{Source: webSocketResource, Target: lambdaResource},
+					},
+				},
+			},
+			want: &config.Config{
+				APIGateways: []config.APIGateway{
+					{
+						StackName: "my-stack",
+						APIG:      true,
+						Protocol:  config.ProtocolWebSocket,
+						Lambdas: []config.APIGatewayLambda{
+							{
+								Name:        "my-lambda",
+								Source:      "git@",
+								RoleName:    "execute_lambda",
+								Description: "my-lambda lambda",
+								RouteKey:    "$connect",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "WebSocket APIs grouped by their endpoint",
+			args: args{
+				yamlConfig: diagramConfig,
+				resources: &resources.ResourceCollection{
+					Resources: []resources.Resource{
+						chatEndpoint, chatRoute, chatLambda, gameEndpoint, gameRoute, gameLambda,
+					},
+					Relationships: []resources.Relationship{
+						{Source: chatEndpoint, Target: chatRoute},
+						{Source: chatRoute, Target: chatLambda},
+						{Source: gameEndpoint, Target: gameRoute},
+						{Source: gameRoute, Target: gameLambda},
+					},
+				},
+			},
+			want: &config.Config{
+				APIGateways: []config.APIGateway{
+					{
+						StackName: "my-stack",
+						APIG:      true,
+						Name:      "chat",
+						APIDomain: "wss://chat.my-domain.com",
+						Protocol:  config.ProtocolWebSocket,
+						Lambdas: []config.APIGatewayLambda{
+							{
+								Name:        "chat-lambda",
+								Source:      "git@",
+								RoleName:    "execute_lambda",
+								Description: "chat-lambda lambda",
+								RouteKey:    "$connect",
+							},
+						},
+					},
+					{
+						StackName: "my-stack",
+						APIG:      true,
+						Name:      "game",
+						APIDomain: "wss://game.my-domain.com",
+						Protocol:  config.ProtocolWebSocket,
+						Lambdas: []config.APIGatewayLambda{
+							{
+								Name:        "game-lambda",
+								Source:      "git@",
+								RoleName:    "execute_lambda",
+								Description: "game-lambda lambda",
+								RouteKey:    "move",
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for i := range tests {
@@ -129,7 +222,7 @@ func TestTransformDrawIOToYAML_Database(t *testing.T) {
 				yamlConfig: diagramConfig,
 				resources:  &resources.ResourceCollection{Resources: []resources.Resource{database}},
 			},
-			want: &config.Config{},
+			want: &config.Config{RDS: []config.RDS{{Name: "my-database"}}},
 		},
 		{
 			name: "database receives data from a Lambda",
@@ -147,13 +240,10 @@ func TestTransformDrawIOToYAML_Database(t *testing.T) {
 						Source:      "git@",
 						RoleName:    "execute_lambda",
 						Description: "myReceiver lambda",
-						Envars: map[string]string{
-							"MY_DATABASE_DB_HOST":            "var.my_database_db_host",
-							"MY_DATABASE_DB_USER":            "var.my_database_db_user",
-							"MY_DATABASE_DB_PASSWORD_SECRET": "var.my_database_db_password_secret",
-						},
+						RDS:         []string{"my-database"},
 					},
 				},
+				RDS: []config.RDS{{Name: "my-database"}},
 			},
 		},
 	}
@@ -215,12 +305,15 @@ func TestTransformDrawIOToYAML_GoogleBQ(t *testing.T) {
 						Description: "myReceiver lambda",
 						Envars: map[string]string{
 							"GOOGLE_BQ_PROJECT_ID":        "var.google_bq_project_id",
-							"GOOGLE_BQ_API_KEY_SECRET":    "var.google_bq_api_key_secret",
 							"GOOGLE_BQ_PARTITION_FIELD":   "var.google_bq_partition_field",
 							"GOOGLE_BQ_CLUSTERING_FIELDS": "var.google_bq_clustering_fields",
 						},
+						Secrets: []string{"googleBqApiKey"},
 					},
 				},
+				Secrets: []config.Secret{
+					{Name: "googleBqApiKey", Description: "API key of the google BigQuery"},
+				},
 			},
 		},
 	}
@@ -310,6 +403,275 @@ func TestTransformDrawIOToYAML_Kinesis(t *testing.T) {
 	}
 }
 
+func TestTransformDrawIOToYAML_Firehose(t *testing.T) {
+	type args struct {
+		yamlConfig *config.Config
+		resources  *resources.ResourceCollection
+	}
+
+	firehose := resources.NewGenericResource("id1", "my-firehose", awsresources.FirehoseType.String())
+	kinesis := resources.NewGenericResource("id2", "my-stream", awsresources.KinesisType.String())
+	s3Bucket := resources.NewGenericResource("id3", "my-bucket", awsresources.S3Type.String())
+	lambda := resources.NewGenericResource("id4", "myTransformer", awsresources.LambdaType.String())
+
+	tests := []struct {
+		name      string
+		args      args
+		want      *config.Config
+		targetErr error
+	}{
+		{
+			name: "only Firehose",
+			args: args{
+				yamlConfig: diagramConfig,
+				resources: &resources.ResourceCollection{
+					Resources: []resources.Resource{firehose},
+				},
+			},
+			want: &config.Config{
+				Firehoses: []config.Firehose{{Name: "my-firehose"}},
+			},
+		},
+		{
+			name: "deliver a Kinesis stream to an S3 bucket transformed by a Lambda",
+			args: args{
+				yamlConfig: diagramConfig,
+				resources: &resources.ResourceCollection{
+					Resources: []resources.Resource{firehose, kinesis, s3Bucket, lambda},
+					Relationships: []resources.Relationship{
+						{Source: kinesis, Target: firehose},
+						{Source: firehose, Target: s3Bucket},
+						{Source: firehose, Target: lambda},
+					},
+				},
+			},
+			want: &config.Config{
+				Lambdas: []config.Lambda{
+					{
+						Name:        "myTransformer",
+						Source:      "git@",
+						RoleName:    "execute_lambda",
+						Description: "myTransformer lambda",
+					},
+				},
+				Kinesis: []config.Kinesis{{Name: "my-stream", RetentionPeriod: "24"}},
+				Firehoses: []config.Firehose{
+					{
+						Name:                 "my-firehose",
+						KinesisSource:        "my-stream",
+						Bucket:               "my-bucket",
+						TransformationLambda: "myTransformer",
+					},
+				},
+				Buckets: []config.S3{{Name: "my-bucket", ExpirationDays: 90}},
+			},
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewTransformer(tc.args.yamlConfig, tc.args.resources).Transform()
+
+			if tc.targetErr == nil {
+				require.NoError(t, err)
+				require.Equal(t, tc.want, got)
+			} else {
+				require.ErrorIs(t, err, tc.targetErr)
+			}
+		})
+	}
+}
+
+func TestTransformDrawIOToYAML_EventBus(t *testing.T) {
+	type args struct {
+		yamlConfig *config.Config
+		resources  *resources.ResourceCollection
+	}
+
+	eventBus := resources.NewGenericResource("id1", "orders", awsresources.EventBusType.String())
+	lambda := resources.NewGenericResource("id2", "orderProcessor", awsresources.LambdaType.String())
+	sqs := resources.NewGenericResource("id3", "order-queue", awsresources.SQSType.String())
+	kinesis := resources.NewGenericResource("id4", "order-stream", awsresources.KinesisType.String())
+
+	tests := []struct {
+		name      string
+		args      args
+		want      *config.Config
+		targetErr error
+	}{
+		{
+			name: "only EventBus",
+			args: args{
+				yamlConfig: diagramConfig,
+				resources: &resources.ResourceCollection{
+					Resources: []resources.Resource{eventBus},
+				},
+			},
+			want: &config.Config{
+				EventBuses: []config.EventBus{
+					{
+						Name:  "orders",
+						Rules: []config.EventRule{{Name: "ordersRule", EventPattern: `{"source": ["orders"]}`}},
+					},
+				},
+			},
+		},
+		{
+			name: "route events to a Lambda, an SQS queue and a Kinesis stream",
+			args: args{
+				yamlConfig: diagramConfig,
+				resources: &resources.ResourceCollection{
+					Resources: []resources.Resource{eventBus, lambda, sqs, kinesis},
+					Relationships: []resources.Relationship{
+						{Source: eventBus, Target: lambda},
+						{Source: eventBus, Target: sqs},
+						{Source: eventBus, Target: kinesis},
+					},
+				},
+			},
+			want: &config.Config{
+				Lambdas: []config.Lambda{
+					{
+						Name:        "orderProcessor",
+						Source:      "git@",
+						RoleName:    "execute_lambda",
+						Description: "orderProcessor lambda",
+					},
+				},
+				Kinesis: []config.Kinesis{{Name: "order-stream", RetentionPeriod: "24"}},
+				EventBuses: []config.EventBus{
+					{
+						Name: "orders",
+						Rules: []config.EventRule{
+							{
+								Name:         "ordersRule",
+								EventPattern: `{"source": ["orders"]}`,
+								Lambdas:      []config.EventTarget{{Name: "orderProcessor"}},
+								SQSs:         []config.EventTarget{{Name: "order-queue"}},
+								Kinesis:      []config.EventTarget{{Name: "order-stream"}},
+							},
+						},
+					},
+				},
+				SQSs: []config.SQS{{Name: "order-queue", MaxReceiveCount: 10}},
+			},
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewTransformer(tc.args.yamlConfig, tc.args.resources).Transform()
+
+			if tc.targetErr == nil {
+				require.NoError(t, err)
+				require.Equal(t, tc.want, got)
+			} else {
+				require.ErrorIs(t, err, tc.targetErr)
+			}
+		})
+	}
+}
+
+func TestTransformDrawIOToYAML_StepFunction(t *testing.T) {
+	type args struct {
+		yamlConfig *config.Config
+		resources  *resources.ResourceCollection
+	}
+
+	stepFunction := resources.NewGenericResource("id1", "orderWorkflow", awsresources.StepFunctionType.String())
+	lambda := resources.NewGenericResource("id2", "orderValidator", awsresources.LambdaType.String())
+	sqs := resources.NewGenericResource("id3", "order-queue", awsresources.SQSType.String())
+	eventBus := resources.NewGenericResource("id4", "orders", awsresources.EventBusType.String())
+
+	tests := []struct {
+		name      string
+		args      args
+		want      *config.Config
+		targetErr error
+	}{
+		{
+			name: "only StepFunction",
+			args: args{
+				yamlConfig: diagramConfig,
+				resources: &resources.ResourceCollection{
+					Resources: []resources.Resource{stepFunction},
+				},
+			},
+			want: &config.Config{
+				StepFunctions: []config.StepFunction{
+					{Name: "orderWorkflow", States: []config.StepFunctionState{{Name: "Done", Type: "Succeed"}}},
+				},
+			},
+		},
+		{
+			name: "chain a Lambda and an SQS queue started by an EventBus",
+			args: args{
+				yamlConfig: diagramConfig,
+				resources: &resources.ResourceCollection{
+					Resources: []resources.Resource{stepFunction, lambda, sqs, eventBus},
+					Relationships: []resources.Relationship{
+						{Source: stepFunction, Target: lambda},
+						{Source: stepFunction, Target: sqs},
+						{Source: eventBus, Target: stepFunction},
+					},
+				},
+			},
+			want: &config.Config{
+				Lambdas: []config.Lambda{
+					{
+						Name:        "orderValidator",
+						Source:      "git@",
+						RoleName:    "execute_lambda",
+						Description: "orderValidator lambda",
+					},
+				},
+				StepFunctions: []config.StepFunction{
+					{
+						Name: "orderWorkflow",
+						States: []config.StepFunctionState{
+							{Name: "InvokeOrderValidator", Lambda: "orderValidator", Next: "SendToOrderQueue"},
+							{Name: "SendToOrderQueue", SQS: "order-queue", Next: "Done"},
+							{Name: "Done", Type: "Succeed"},
+						},
+					},
+				},
+				EventBuses: []config.EventBus{
+					{
+						Name: "orders",
+						Rules: []config.EventRule{
+							{
+								Name:          "ordersRule",
+								EventPattern:  `{"source": ["orders"]}`,
+								StepFunctions: []config.EventTarget{{Name: "orderWorkflow"}},
+							},
+						},
+					},
+				},
+				SQSs: []config.SQS{{Name: "order-queue", MaxReceiveCount: 10}},
+			},
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewTransformer(tc.args.yamlConfig, tc.args.resources).Transform()
+
+			if tc.targetErr == nil {
+				require.NoError(t, err)
+				require.Equal(t, tc.want, got)
+			} else {
+				require.ErrorIs(t, err, tc.targetErr)
+			}
+		})
+	}
+}
+
 func TestTransformDrawIOToYAML_Lambda(t *testing.T) {
 	type args struct {
 		yamlConfig *config.Config
@@ -318,6 +680,7 @@ func TestTransformDrawIOToYAML_Lambda(t *testing.T) {
 
 	lambda := resources.NewGenericResource("id1", "myReceiver", awsresources.LambdaType.String())
 	cron := resources.NewGenericResource("id2", "cron(0 2 * * ? *)", awsresources.CronType.String())
+	hourlyCron := resources.NewGenericResource("id7", "rate(1 hour)", awsresources.CronType.String())
 	sqs := resources.NewGenericResource("id3", "my-queue", awsresources.SQSType.String())
 	sns := resources.NewGenericResource("id4", "my-notification", awsresources.SNSType.String())
 	s3Bucket := resources.NewGenericResource("id5", "my-bucket", awsresources.S3Type.String())
@@ -397,6 +760,33 @@ func TestTransformDrawIOToYAML_Lambda(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "invoke a Lambda on multiple schedules",
+			args: args{
+				yamlConfig: diagramConfig,
+				resources: &resources.ResourceCollection{
+					Resources: []resources.Resource{lambda, cron, hourlyCron},
+					Relationships: []resources.Relationship{
+						{Source: cron, Target: lambda},
+						{Source: hourlyCron, Target: lambda},
+					},
+				},
+			},
+			want: &config.Config{
+				Lambdas: []config.Lambda{
+					{
+						Name:        "myReceiver",
+						Source:      "git@",
+						RoleName:    "execute_lambda",
+						Description: "myReceiver lambda",
+						Crons: []config.Cron{
+							{ScheduleExpression: "cron(0 2 * * ? *)", IsEnabled: "true"},
+							{ScheduleExpression: "rate(1 hour)", IsEnabled: "true"},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "invoke a Lambda to receive messages from an Kinesis stream",
 			args: args{
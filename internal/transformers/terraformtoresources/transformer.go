@@ -13,7 +13,8 @@ import (
 	awsresources "github.com/joselitofilho/aws-terraform-generator/internal/resources"
 )
 
-const suffixLambda = "_lambda"
+// reStepFunctionTask matches the lambdas and queues referenced in a state machine definition.
+var reStepFunctionTask = regexp.MustCompile(`(aws_lambda_function|aws_sqs_queue)\.([\w-]+)\.`)
 
 type Transformer struct {
 	yamlConfig *config.Config
@@ -22,27 +23,43 @@ type Transformer struct {
 	resources     []resources.Resource
 	relationships []resources.Relationship
 
-	apiGatewayResourcesByName map[string]resources.Resource
-	dbResourcesByName         map[string]resources.Resource
-	googleBQResourcesByName   map[string]resources.Resource
-	kinesisResourcesByName    map[string]resources.Resource
-	lambdaResourcesByName     map[string]resources.Resource
-	restfulAPIResourcesByName map[string]resources.Resource
-	s3BucketResourcesByName   map[string]resources.Resource
-	sqsResourcesByName        map[string]resources.Resource
-
-	cronResourcesByLabel     map[string]resources.Resource
-	endpointResourcesByLabel map[string]resources.Resource
-	kinesisResourcesByLabel  map[string]resources.Resource
-	lambdaResourcesByLabel   map[string]resources.Resource
-	s3BucketResourcesByLabel map[string]resources.Resource
-	sqsResourcesByLabel      map[string]resources.Resource
+	apiGatewayResourcesByRoute  map[string]resources.Resource
+	dbResourcesByName           map[string]resources.Resource
+	eventBusResourcesByName     map[string]resources.Resource
+	firehoseResourcesByName     map[string]resources.Resource
+	googleBQResourcesByName     map[string]resources.Resource
+	kinesisResourcesByName      map[string]resources.Resource
+	lambdaResourcesByName       map[string]resources.Resource
+	restfulAPIResourcesByName   map[string]resources.Resource
+	s3BucketResourcesByName     map[string]resources.Resource
+	sqsResourcesByName          map[string]resources.Resource
+	stepFunctionResourcesByName map[string]resources.Resource
+
+	apiGatewayResourcesByLabel   map[string]resources.Resource
+	cronResourcesByLabel         map[string]resources.Resource
+	dbResourcesByLabel           map[string]resources.Resource
+	endpointResourcesByLabel     map[string]resources.Resource
+	eventBusResourcesByLabel     map[string]resources.Resource
+	firehoseResourcesByLabel     map[string]resources.Resource
+	kinesisResourcesByLabel      map[string]resources.Resource
+	lambdaResourcesByLabel       map[string]resources.Resource
+	s3BucketResourcesByLabel     map[string]resources.Resource
+	sqsResourcesByLabel          map[string]resources.Resource
+	stepFunctionResourcesByLabel map[string]resources.Resource
+
+	// customResourcesByName and customResourcesByLabel are the resources of the registered resource types, by type.
+	customResourcesByName  map[awsresources.ResourceType]map[string]resources.Resource
+	customResourcesByLabel map[awsresources.ResourceType]map[string]resources.Resource
 
 	apigIntegrationRouteMap map[awsresources.ResourceARN][]awsresources.ResourceARN
 	resourceAPIGIntegration map[awsresources.ResourceARN]awsresources.ResourceARN
 
 	relationshipsMap map[awsresources.ResourceARN][]awsresources.ResourceARN
 
+	webSocketAPILabels      map[string]struct{}
+	kinesisStreamByConsumer map[string]awsresources.ResourceARN
+	eventBusByRule          map[string]awsresources.ResourceARN
+
 	id int
 }
 
@@ -54,27 +71,42 @@ func NewTransformer(yamlConfig *config.Config, tfConfig *hcl.Config) *Transforme
 		resources:     []resources.Resource{},
 		relationships: []resources.Relationship{},
 
-		apiGatewayResourcesByName: map[string]resources.Resource{},
-		dbResourcesByName:         map[string]resources.Resource{},
-		googleBQResourcesByName:   map[string]resources.Resource{},
-		kinesisResourcesByName:    map[string]resources.Resource{},
-		lambdaResourcesByName:     map[string]resources.Resource{},
-		restfulAPIResourcesByName: map[string]resources.Resource{},
-		s3BucketResourcesByName:   map[string]resources.Resource{},
-		sqsResourcesByName:        map[string]resources.Resource{},
-
-		cronResourcesByLabel:     map[string]resources.Resource{},
-		endpointResourcesByLabel: map[string]resources.Resource{},
-		kinesisResourcesByLabel:  map[string]resources.Resource{},
-		lambdaResourcesByLabel:   map[string]resources.Resource{},
-		s3BucketResourcesByLabel: map[string]resources.Resource{},
-		sqsResourcesByLabel:      map[string]resources.Resource{},
+		apiGatewayResourcesByRoute:  map[string]resources.Resource{},
+		dbResourcesByName:           map[string]resources.Resource{},
+		eventBusResourcesByName:     map[string]resources.Resource{},
+		firehoseResourcesByName:     map[string]resources.Resource{},
+		googleBQResourcesByName:     map[string]resources.Resource{},
+		kinesisResourcesByName:      map[string]resources.Resource{},
+		lambdaResourcesByName:       map[string]resources.Resource{},
+		restfulAPIResourcesByName:   map[string]resources.Resource{},
+		s3BucketResourcesByName:     map[string]resources.Resource{},
+		sqsResourcesByName:          map[string]resources.Resource{},
+		stepFunctionResourcesByName: map[string]resources.Resource{},
+
+		apiGatewayResourcesByLabel:   map[string]resources.Resource{},
+		cronResourcesByLabel:         map[string]resources.Resource{},
+		dbResourcesByLabel:           map[string]resources.Resource{},
+		endpointResourcesByLabel:     map[string]resources.Resource{},
+		eventBusResourcesByLabel:     map[string]resources.Resource{},
+		firehoseResourcesByLabel:     map[string]resources.Resource{},
+		kinesisResourcesByLabel:      map[string]resources.Resource{},
+		lambdaResourcesByLabel:       map[string]resources.Resource{},
+		s3BucketResourcesByLabel:     map[string]resources.Resource{},
+		sqsResourcesByLabel:          map[string]resources.Resource{},
+		stepFunctionResourcesByLabel: map[string]resources.Resource{},
+
+		customResourcesByName:  map[awsresources.ResourceType]map[string]resources.Resource{},
+		customResourcesByLabel: map[awsresources.ResourceType]map[string]resources.Resource{},
 
 		apigIntegrationRouteMap: map[awsresources.ResourceARN][]awsresources.ResourceARN{},
 		resourceAPIGIntegration: map[awsresources.ResourceARN]awsresources.ResourceARN{},
 
 		relationshipsMap: map[awsresources.ResourceARN][]awsresources.ResourceARN{},
 
+		webSocketAPILabels:      map[string]struct{}{},
+		kinesisStreamByConsumer: map[string]awsresources.ResourceARN{},
+		eventBusByRule:          map[string]awsresources.ResourceARN{},
+
 		id: 1,
 	}
 }
@@ -148,17 +180,38 @@ func (t *Transformer) getResourceByARN(arn awsresources.ResourceARN) (resource r
 	case awsresources.LabelAWSAPIGatewayAPI:
 		resource = t.endpointResourcesByLabel[arn.Label]
 	case awsresources.LabelAWSAPIGatewayRoute:
-		resource = t.apiGatewayResourcesByName[arn.Name]
+		resource = t.apiGatewayResourcesByLabel[arn.Label]
 	case awsresources.LabelAWSCron:
-		resource = t.cronResourcesByLabel[arn.Label]
+		// Rules that match an event pattern are drawn as the event bus they belong to.
+		if eventBusARN, ok := t.eventBusByRule[arn.Label]; ok {
+			resource = t.getResourceByARN(eventBusARN)
+		} else {
+			resource = t.cronResourcesByLabel[arn.Label]
+		}
 	case awsresources.LabelAWSEndpoint:
 		resource = t.endpointResourcesByLabel[arn.Label]
+	case awsresources.LabelAWSEventBus:
+		if arn.Label == "" {
+			resource = t.eventBusResourcesByName[arn.Name]
+		} else {
+			resource = t.eventBusResourcesByLabel[arn.Label]
+		}
+	case awsresources.LabelAWSKinesisFirehose:
+		if arn.Label == "" {
+			resource = t.firehoseResourcesByName[arn.Name]
+		} else {
+			resource = t.firehoseResourcesByLabel[arn.Label]
+		}
 	case awsresources.LabelAWSKinesisStream:
 		if arn.Label == "" {
 			resource = t.kinesisResourcesByName[arn.Name]
 		} else {
 			resource = t.kinesisResourcesByLabel[arn.Label]
 		}
+	case awsresources.LabelAWSKinesisStreamConsumer:
+		if streamARN, ok := t.kinesisStreamByConsumer[arn.Label]; ok {
+			resource = t.getResourceByARN(streamARN)
+		}
 	case awsresources.LabelAWSLambdaFunction:
 		if arn.Label == "" {
 			resource = t.lambdaResourcesByName[arn.Name]
@@ -177,6 +230,20 @@ func (t *Transformer) getResourceByARN(arn awsresources.ResourceARN) (resource r
 		} else {
 			resource = t.sqsResourcesByLabel[arn.Label]
 		}
+	case awsresources.LabelAWSSFNStateMachine:
+		if arn.Label == "" {
+			resource = t.stepFunctionResourcesByName[arn.Name]
+		} else {
+			resource = t.stepFunctionResourcesByLabel[arn.Label]
+		}
+	default:
+		if resourceType, ok := awsresources.ResourceTypeOfLabel(arn.Type); ok {
+			if arn.Label == "" {
+				resource = t.customResourcesByName[resourceType][arn.Name]
+			} else {
+				resource = t.customResourcesByLabel[resourceType][arn.Label]
+			}
+		}
 	}
 
 	return resource
@@ -228,7 +295,7 @@ func (t *Transformer) processTerraformModules() {
 		if len(tfModule.Labels) == 1 {
 			l := tfModule.Labels[0]
 
-			if strings.HasSuffix(strings.ToLower(l), suffixLambda) {
+			if t.yamlConfig.Naming.MatchLabel(awsresources.LambdaType, l) {
 				t.processLambdaModule(tfModule)
 			}
 		}
@@ -236,6 +303,9 @@ func (t *Transformer) processTerraformModules() {
 }
 
 func (t *Transformer) processTerraformResources() {
+	t.collectWebSocketAPILabels()
+	t.collectDatabases()
+
 	for _, tfResourceConf := range t.tfConfig.Resources {
 		if len(tfResourceConf.Labels) == 2 {
 			switch tfResourceConf.Labels[0] {
@@ -246,45 +316,113 @@ func (t *Transformer) processTerraformResources() {
 			case awsresources.LabelAWSCloudwatchEventTarget:
 				t.processCloudwatchEventTarget(tfResourceConf)
 			case awsresources.LabelAWSCron:
-				t.processCronResource(tfResourceConf)
+				if _, ok := tfResourceConf.Attributes["event_pattern"]; ok {
+					t.processEventRuleResource(tfResourceConf)
+				} else {
+					t.processCronResource(tfResourceConf)
+				}
 			case awsresources.LabelAWSEndpoint:
 				t.processEndpointResource(tfResourceConf)
+			case awsresources.LabelAWSEventBus:
+				t.processEventBusResource(tfResourceConf)
+			case awsresources.LabelAWSKinesisFirehose:
+				t.processFirehoseResource(tfResourceConf)
 			case awsresources.LabelAWSKinesisStream:
 				t.processKinesisResource(tfResourceConf)
+			case awsresources.LabelAWSKinesisStreamConsumer:
+				t.processKinesisStreamConsumer(tfResourceConf)
 			case awsresources.LabelAWSLambdaEventSourceMapping:
 				t.processEventSourceMapping(tfResourceConf)
 			case awsresources.LabelAWSLambdaFunction:
 				t.processLambdaResource(tfResourceConf)
 			case awsresources.LabelAWSS3Bucket:
 				t.processS3BucketResource(tfResourceConf)
+			case awsresources.LabelAWSSchedulerSchedule:
+				t.processSchedulerScheduleResource(tfResourceConf)
+			case awsresources.LabelAWSSFNStateMachine:
+				t.processStepFunctionResource(tfResourceConf)
 			case awsresources.LabelAWSSQSQueue:
 				t.processSQSResource(tfResourceConf)
+			default:
+				if resourceType, ok := awsresources.ResourceTypeOfLabel(tfResourceConf.Labels[0]); ok {
+					t.processCustomResource(tfResourceConf, resourceType)
+				}
 			}
 		}
 	}
 }
 
+// collectWebSocketAPILabels collects the labels of the APIs using the WebSocket protocol, so that their routes can be
+// identified.
+func (t *Transformer) collectWebSocketAPILabels() {
+	for _, tfResourceConf := range t.tfConfig.Resources {
+		if len(tfResourceConf.Labels) != 2 || tfResourceConf.Labels[0] != awsresources.LabelAWSAPIGatewayAPI {
+			continue
+		}
+
+		if protocolType, ok := tfResourceConf.Attributes["protocol_type"].(string); ok &&
+			strings.EqualFold(protocolType, config.ProtocolWebSocket) {
+			t.webSocketAPILabels[tfResourceConf.Labels[1]] = struct{}{}
+		}
+	}
+}
+
+// collectDatabases adds the Aurora clusters and RDS instances before the lambdas are processed, so that the database
+// envars of the lambdas can be resolved by label.
+func (t *Transformer) collectDatabases() {
+	for _, tfResourceConf := range t.tfConfig.Resources {
+		if len(tfResourceConf.Labels) != 2 {
+			continue
+		}
+
+		switch tfResourceConf.Labels[0] {
+		case awsresources.LabelAWSRDSCluster:
+			t.processDBResource(tfResourceConf, "cluster_identifier")
+		case awsresources.LabelAWSDBInstance:
+			t.processDBResource(tfResourceConf, "identifier")
+		}
+	}
+}
+
 func (t *Transformer) processAPIGatewayRoute(conf *hcl.Resource) {
-	routeKeyValue := replaceVars(conf.Attributes["route_key"].(string), t.tfConfig.Variables, t.tfConfig.Locals,
+	routeKey, _ := conf.Attributes["route_key"].(string)
+	if routeKey == "" {
+		fmtcolor.Yellow.Printf("terraform to resource: %s.%s misses the route_key attribute\n", conf.Labels[0],
+			conf.Labels[1])
+
+		return
+	}
+
+	routeKeyValue := replaceVars(routeKey, t.tfConfig.Variables, t.tfConfig.Locals,
 		t.yamlConfig.Draw.ReplaceableTexts)
 
-	routeKeyARN := awsresources.ParseResourceARN(routeKeyValue, awsresources.APIGatewayType)
+	apiIDValue := replaceVars(conf.Attributes["api_id"].(string), t.tfConfig.Variables, t.tfConfig.Locals,
+		t.yamlConfig.Draw.ReplaceableTexts)
+	apiIDARN := awsresources.ParseResourceARN(apiIDValue, awsresources.EndpointType)
+
+	resType := awsresources.APIGatewayType
+	if _, ok := t.webSocketAPILabels[apiIDARN.Label]; ok || awsresources.IsWebSocketRouteKey(routeKeyValue) {
+		resType = awsresources.WebSocketType
+	}
+
+	routeKeyARN := awsresources.ParseResourceARN(routeKeyValue, resType)
 	routeKeyARN.Label = conf.Labels[1]
 
 	routeKeyValue = routeKeyARN.Name
 
-	_, ok := t.apiGatewayResourcesByName[routeKeyValue]
+	// The routes are keyed by their API, as the APIs of a stack may have the same route keys, such as $connect.
+	apiRouteKey := fmt.Sprintf("%s %s", apiIDARN.Label, routeKeyValue)
+
+	resource, ok := t.apiGatewayResourcesByRoute[apiRouteKey]
 	if !ok {
-		resource := resources.NewGenericResource(fmt.Sprintf("%d", t.id), routeKeyValue, awsresources.APIGatewayType.String())
+		resource = resources.NewGenericResource(fmt.Sprintf("%d", t.id), routeKeyValue, resType.String())
 		t.id++
 
 		t.resources = append(t.resources, resource)
-		t.apiGatewayResourcesByName[routeKeyValue] = resource
+		t.apiGatewayResourcesByRoute[apiRouteKey] = resource
 	}
 
-	apiIDValue := replaceVars(conf.Attributes["api_id"].(string), t.tfConfig.Variables, t.tfConfig.Locals,
-		t.yamlConfig.Draw.ReplaceableTexts)
-	apiIDARN := awsresources.ParseResourceARN(apiIDValue, awsresources.EndpointType)
+	t.apiGatewayResourcesByLabel[routeKeyARN.Label] = resource
 
 	targetValue := replaceVars(conf.Attributes["target"].(string), t.tfConfig.Variables, t.tfConfig.Locals,
 		t.yamlConfig.Draw.ReplaceableTexts)
@@ -334,9 +472,31 @@ func (t *Transformer) processCronResource(conf *hcl.Resource) {
 	}
 }
 
+// processSchedulerScheduleResource only adds the cron node. The target lambda lives in a nested block, which the
+// Terraform parser does not expose.
+func (t *Transformer) processSchedulerScheduleResource(conf *hcl.Resource) {
+	t.processCronResource(conf)
+}
+
+func (t *Transformer) processDBResource(conf *hcl.Resource, attributeName string) {
+	if _, ok := conf.Attributes[attributeName].(string); !ok {
+		return
+	}
+
+	t.processResource(conf, awsresources.DatabaseType, attributeName, t.dbResourcesByName, t.dbResourcesByLabel)
+}
+
+// processDBResourceFromEnvar resolves the envars referencing a cluster, instance or proxy attribute to the database
+// sharing the same label.
 func (t *Transformer) processDBResourceFromEnvar(
 	v string, resourcesByName map[string]resources.Resource,
 ) resources.Resource {
+	if label := awsresources.ParseResourceARN(v, awsresources.DatabaseType).Label; label != "" {
+		if resource, ok := t.dbResourcesByLabel[label]; ok {
+			return resource
+		}
+	}
+
 	return t.processResourceFromEnvar(v, awsresources.DatabaseType, resourcesByName)
 }
 
@@ -355,11 +515,60 @@ func (t *Transformer) processEndpointResource(conf *hcl.Resource) {
 	}
 }
 
+// processKinesisStreamConsumer keeps the stream of an enhanced fan-out consumer, so that the event source mappings
+// reading from the consumer are drawn from the stream.
+func (t *Transformer) processKinesisStreamConsumer(conf *hcl.Resource) {
+	streamARNValue, ok := conf.Attributes["stream_arn"].(string)
+	if !ok {
+		return
+	}
+
+	streamARNValue = replaceVars(streamARNValue, t.tfConfig.Variables, t.tfConfig.Locals,
+		t.yamlConfig.Draw.ReplaceableTexts)
+
+	t.kinesisStreamByConsumer[conf.Labels[1]] = awsresources.ParseResourceARN(streamARNValue, awsresources.KinesisType)
+}
+
 func (t *Transformer) processEventSourceMapping(conf *hcl.Resource) {
 	t.processResourceRelationships(conf, "event_source_arn", "function_name",
 		awsresources.UnknownType, awsresources.LambdaType)
 }
 
+func (t *Transformer) processEventBusResource(conf *hcl.Resource) {
+	t.processResource(conf, awsresources.EventBusType, "name", t.eventBusResourcesByName, t.eventBusResourcesByLabel)
+}
+
+// processEventRuleResource links a rule that matches an event pattern to its event bus, so the targets of the rule
+// are drawn as targets of the bus. Rules without a bus belong to the default event bus.
+func (t *Transformer) processEventRuleResource(conf *hcl.Resource) {
+	eventBusARN := awsresources.ResourceARN{Type: awsresources.LabelAWSEventBus, Name: config.DefaultEventBusName}
+
+	if value, ok := conf.Attributes["event_bus_name"]; ok {
+		value := replaceVars(value.(string), t.tfConfig.Variables, t.tfConfig.Locals,
+			t.yamlConfig.Draw.ReplaceableTexts)
+		eventBusARN = awsresources.ParseResourceARN(value, awsresources.EventBusType)
+	}
+
+	if eventBusARN.Name == config.DefaultEventBusName {
+		if _, ok := t.eventBusResourcesByName[eventBusARN.Name]; !ok {
+			resource := resources.NewGenericResource(fmt.Sprintf("%d", t.id), eventBusARN.Name,
+				awsresources.EventBusType.String())
+			t.id++
+
+			t.resources = append(t.resources, resource)
+			t.eventBusResourcesByName[eventBusARN.Name] = resource
+		}
+	}
+
+	t.eventBusByRule[conf.Labels[1]] = eventBusARN
+}
+
+// processFirehoseResource only adds the delivery stream node. Its source, destination and transformation lambda
+// live in nested blocks, which the Terraform parser does not expose.
+func (t *Transformer) processFirehoseResource(conf *hcl.Resource) {
+	t.processResource(conf, awsresources.FirehoseType, "name", t.firehoseResourcesByName, t.firehoseResourcesByLabel)
+}
+
 func (t *Transformer) processGoogleBQResourceFromEnvar(
 	v string, resourcesByName map[string]resources.Resource,
 ) resources.Resource {
@@ -429,6 +638,11 @@ func (t *Transformer) processLambda(attributes, envars map[string]any, label str
 		case strings.HasSuffix(k, awsresources.EnvarSuffixSQSQueueURL):
 			targetArn := t.processResourceARNFromEnvar(v.(string), awsresources.SQSType)
 			t.relationshipsMap[lambdaARN] = append(t.relationshipsMap[lambdaARN], targetArn)
+		default:
+			if resourceType, _, ok := awsresources.ResourceTypeOfEnvar(k); ok {
+				targetArn := t.processResourceARNFromEnvar(v.(string), resourceType)
+				t.relationshipsMap[lambdaARN] = append(t.relationshipsMap[lambdaARN], targetArn)
+			}
 		}
 	}
 }
@@ -478,6 +692,26 @@ func (t *Transformer) processResource(
 	}
 }
 
+// processCustomResource adds the resource of a registered resource type, named by the name attribute of the type.
+func (t *Transformer) processCustomResource(conf *hcl.Resource, resourceType awsresources.ResourceType) {
+	def, _ := awsresources.LookupResourceType(resourceType)
+
+	if _, ok := conf.Attributes[def.NameAttribute].(string); !ok {
+		fmtcolor.Yellow.Printf("terraform to resource: %s.%s misses the %s attribute\n", conf.Labels[0], conf.Labels[1],
+			def.NameAttribute)
+
+		return
+	}
+
+	if t.customResourcesByName[resourceType] == nil {
+		t.customResourcesByName[resourceType] = map[string]resources.Resource{}
+		t.customResourcesByLabel[resourceType] = map[string]resources.Resource{}
+	}
+
+	t.processResource(conf, resourceType, def.NameAttribute, t.customResourcesByName[resourceType],
+		t.customResourcesByLabel[resourceType])
+}
+
 func (t *Transformer) processResourceRelationships(
 	conf *hcl.Resource, sourceAttribute string, targetAttribute string,
 	sourceType awsresources.ResourceType, targetType awsresources.ResourceType,
@@ -501,6 +735,33 @@ func (t *Transformer) processSQSResource(conf *hcl.Resource) {
 	t.processResource(conf, awsresources.SQSType, "name", t.sqsResourcesByName, t.sqsResourcesByLabel)
 }
 
+// processStepFunctionResource links the state machine to the lambdas and queues referenced by the tasks of its
+// definition. Definitions built with jsonencode are not exposed by the Terraform parser, so only the nodes are drawn
+// for them.
+func (t *Transformer) processStepFunctionResource(conf *hcl.Resource) {
+	t.processResource(conf, awsresources.StepFunctionType, "name", t.stepFunctionResourcesByName,
+		t.stepFunctionResourcesByLabel)
+
+	definition, ok := conf.Attributes["definition"].(string)
+	if !ok {
+		return
+	}
+
+	stepFunctionARN := awsresources.ResourceARN{Type: awsresources.LabelAWSSFNStateMachine, Label: conf.Labels[1]}
+
+	linked := map[awsresources.ResourceARN]struct{}{}
+
+	for _, match := range reStepFunctionTask.FindAllStringSubmatch(definition, -1) {
+		taskARN := awsresources.ResourceARN{Type: match[1], Label: match[2]}
+
+		if _, ok := linked[taskARN]; !ok {
+			linked[taskARN] = struct{}{}
+
+			t.relationshipsMap[stepFunctionARN] = append(t.relationshipsMap[stepFunctionARN], taskARN)
+		}
+	}
+}
+
 func (t *Transformer) processRestfulAPIResourceFromEnvar(
 	v string, resourcesByName map[string]resources.Resource,
 ) resources.Resource {
@@ -61,6 +61,133 @@ func TestTransformer_Transform(t *testing.T) {
 				Relationships: []resources.Relationship{},
 			},
 		},
+		{
+			name: "WebSocket API route",
+			fields: fields{
+				yamlConfig: &config.Config{},
+				tfConfig: &hcl.Config{
+					Resources: []*hcl.Resource{
+						{
+							Type:   "aws_apigatewayv2_api",
+							Name:   "mystack_websocket_api",
+							Labels: []string{"aws_apigatewayv2_api", "mystack_websocket_api"},
+							Attributes: map[string]any{
+								"protocol_type": "WEBSOCKET",
+							},
+						},
+						{
+							Type:   "aws_apigatewayv2_route",
+							Name:   "apigw_route_message_handler",
+							Labels: []string{"aws_apigatewayv2_route", "apigw_route_message_handler"},
+							Attributes: map[string]any{
+								"api_id":    "aws_apigatewayv2_api.mystack_websocket_api.id",
+								"route_key": "sendMessage",
+								"target":    "integrations/${aws_apigatewayv2_integration.message_handler.id}",
+							},
+						},
+					},
+				},
+			},
+			want: &resources.ResourceCollection{
+				Resources: []resources.Resource{
+					resources.NewGenericResource("1", "sendMessage", awsresources.WebSocketType.String())},
+				Relationships: []resources.Relationship{},
+			},
+		},
+		{
+			name: "WebSocket APIs with the same route key",
+			fields: fields{
+				yamlConfig: &config.Config{},
+				tfConfig: &hcl.Config{
+					Resources: []*hcl.Resource{
+						{
+							Type:   "aws_apigatewayv2_route",
+							Name:   "apigw_route_chat_connect",
+							Labels: []string{"aws_apigatewayv2_route", "apigw_route_chat_connect"},
+							Attributes: map[string]any{
+								"api_id":    "aws_apigatewayv2_api.mystack_chat_websocket_api.id",
+								"route_key": "$connect",
+								"target":    "integrations/${aws_apigatewayv2_integration.chat_connect.id}",
+							},
+						},
+						{
+							Type:   "aws_apigatewayv2_route",
+							Name:   "apigw_route_game_connect",
+							Labels: []string{"aws_apigatewayv2_route", "apigw_route_game_connect"},
+							Attributes: map[string]any{
+								"api_id":    "aws_apigatewayv2_api.mystack_game_websocket_api.id",
+								"route_key": "$connect",
+								"target":    "integrations/${aws_apigatewayv2_integration.game_connect.id}",
+							},
+						},
+					},
+				},
+			},
+			want: &resources.ResourceCollection{
+				Resources: []resources.Resource{
+					resources.NewGenericResource("1", "$connect", awsresources.WebSocketType.String()),
+					resources.NewGenericResource("2", "$connect", awsresources.WebSocketType.String()),
+				},
+				Relationships: []resources.Relationship{},
+			},
+		},
+		{
+			name: "HTTP API default route",
+			fields: fields{
+				yamlConfig: &config.Config{},
+				tfConfig: &hcl.Config{
+					Resources: []*hcl.Resource{
+						{
+							Type:   "aws_apigatewayv2_api",
+							Name:   "mystack_api",
+							Labels: []string{"aws_apigatewayv2_api", "mystack_api"},
+							Attributes: map[string]any{
+								"protocol_type": "HTTP",
+							},
+						},
+						{
+							Type:   "aws_apigatewayv2_route",
+							Name:   "apigw_route_default",
+							Labels: []string{"aws_apigatewayv2_route", "apigw_route_default"},
+							Attributes: map[string]any{
+								"api_id":    "aws_apigatewayv2_api.mystack_api.id",
+								"route_key": "$default",
+								"target":    "integrations/${aws_apigatewayv2_integration.default_handler.id}",
+							},
+						},
+					},
+				},
+			},
+			want: &resources.ResourceCollection{
+				Resources: []resources.Resource{
+					resources.NewGenericResource("1", "$default", awsresources.APIGatewayType.String())},
+				Relationships: []resources.Relationship{},
+			},
+		},
+		{
+			name: "API Gateway route without route key",
+			fields: fields{
+				yamlConfig: &config.Config{},
+				tfConfig: &hcl.Config{
+					Resources: []*hcl.Resource{
+						{
+							Type:   "aws_apigatewayv2_route",
+							Name:   "apigw_route_empty",
+							Labels: []string{"aws_apigatewayv2_route", "apigw_route_empty"},
+							Attributes: map[string]any{
+								"api_id":    "aws_apigatewayv2_api.mystack_api.id",
+								"route_key": "",
+								"target":    "integrations/${aws_apigatewayv2_integration.empty.id}",
+							},
+						},
+					},
+				},
+			},
+			want: &resources.ResourceCollection{
+				Resources:     []resources.Resource{},
+				Relationships: []resources.Relationship{},
+			},
+		},
 		{
 			name: "API Gateway integration",
 			fields: fields{
@@ -107,6 +234,29 @@ func TestTransformer_Transform(t *testing.T) {
 				Relationships: []resources.Relationship{},
 			},
 		},
+		{
+			name: "scheduler schedule",
+			fields: fields{
+				yamlConfig: &config.Config{},
+				tfConfig: &hcl.Config{
+					Resources: []*hcl.Resource{
+						{
+							Type:   "aws_scheduler_schedule",
+							Name:   "example_receiver_schedule",
+							Labels: []string{"aws_scheduler_schedule", "example_receiver_schedule"},
+							Attributes: map[string]any{
+								"schedule_expression": "rate(1 hour)",
+							},
+						},
+					},
+				},
+			},
+			want: &resources.ResourceCollection{
+				Resources: []resources.Resource{resources.NewGenericResource("1", "rate(1 hour)",
+					awsresources.CronType.String())},
+				Relationships: []resources.Relationship{},
+			},
+		},
 		{
 			name: "cron",
 			fields: fields{
@@ -153,6 +303,30 @@ func TestTransformer_Transform(t *testing.T) {
 				Relationships: []resources.Relationship{},
 			},
 		},
+		{
+			name: "firehose",
+			fields: fields{
+				yamlConfig: &config.Config{},
+				tfConfig: &hcl.Config{
+					Resources: []*hcl.Resource{
+						{
+							Type:   "aws_kinesis_firehose_delivery_stream",
+							Name:   "my_firehose",
+							Labels: []string{"aws_kinesis_firehose_delivery_stream", "my_firehose"},
+							Attributes: map[string]any{
+								"name":        "MyFirehose",
+								"destination": "extended_s3",
+							},
+						},
+					},
+				},
+			},
+			want: &resources.ResourceCollection{
+				Resources: []resources.Resource{
+					resources.NewGenericResource("1", "MyFirehose", awsresources.FirehoseType.String())},
+				Relationships: []resources.Relationship{},
+			},
+		},
 		{
 			name: "kinesis",
 			fields: fields{
@@ -208,6 +382,62 @@ func TestTransformer_Transform(t *testing.T) {
 				Relationships: []resources.Relationship{},
 			},
 		},
+		{
+			name: "lambda event source mapping with kinesis consumer",
+			fields: fields{
+				yamlConfig: &config.Config{},
+				tfConfig: &hcl.Config{
+					Resources: []*hcl.Resource{
+						{
+							Type:   "aws_kinesis_stream",
+							Name:   "my_stream_kinesis",
+							Labels: []string{"aws_kinesis_stream", "my_stream_kinesis"},
+							Attributes: map[string]any{
+								"name": "MyStream",
+							},
+						},
+						{
+							Type:   "aws_kinesis_stream_consumer",
+							Name:   "my_stream_kinesis_my_consumer_consumer",
+							Labels: []string{"aws_kinesis_stream_consumer", "my_stream_kinesis_my_consumer_consumer"},
+							Attributes: map[string]any{
+								"name":       "myConsumer",
+								"stream_arn": "aws_kinesis_stream.my_stream_kinesis.arn",
+							},
+						},
+						{
+							Type:   "aws_lambda_function",
+							Name:   "example_receiver_lambda",
+							Labels: []string{"aws_lambda_function", "example_receiver_lambda"},
+							Attributes: map[string]any{
+								"function_name": "exampleReceiver",
+							},
+						},
+						{
+							Type:   "aws_lambda_event_source_mapping",
+							Name:   "example_receiver_kinesis_mapping",
+							Labels: []string{"aws_lambda_event_source_mapping", "example_receiver_kinesis_mapping"},
+							Attributes: map[string]any{
+								"event_source_arn": "aws_kinesis_stream_consumer.my_stream_kinesis_my_consumer_consumer.arn",
+								"function_name":    "aws_lambda_function.example_receiver_lambda.function_name",
+							},
+						},
+					},
+				},
+			},
+			want: &resources.ResourceCollection{
+				Resources: []resources.Resource{
+					resources.NewGenericResource("1", "MyStream", awsresources.KinesisType.String()),
+					resources.NewGenericResource("2", "exampleReceiver", awsresources.LambdaType.String()),
+				},
+				Relationships: []resources.Relationship{
+					{
+						Source: resources.NewGenericResource("1", "MyStream", awsresources.KinesisType.String()),
+						Target: resources.NewGenericResource("2", "exampleReceiver", awsresources.LambdaType.String()),
+					},
+				},
+			},
+		},
 		{
 			name: "lambda as resource",
 			fields: fields{
@@ -317,6 +547,8 @@ func TestTransformer_TransformFromLambdaToResourceFromEnvar(t *testing.T) {
 	lambdaResource := resources.NewGenericResource("1", "myReceiver", awsresources.LambdaType.String())
 	bqResource := resources.NewGenericResource("2", "google", awsresources.GoogleBQType.String())
 	dbResource := resources.NewGenericResource("2", "var.doc_db_host", awsresources.DatabaseType.String())
+	clusterResource := resources.NewGenericResource("1", "orders-db", awsresources.DatabaseType.String())
+	clusterLambdaResource := resources.NewGenericResource("2", "myReceiver", awsresources.LambdaType.String())
 	kinesisResource := resources.NewGenericResource("2", "MyStream", awsresources.KinesisType.String())
 	restfulAPIResource := resources.NewGenericResource("2", "MyRestful", awsresources.RestfulAPIType.String())
 	s3BucketResource := resources.NewGenericResource("2", "my-bucket", awsresources.S3Type.String())
@@ -405,6 +637,41 @@ func TestTransformer_TransformFromLambdaToResourceFromEnvar(t *testing.T) {
 				Relationships: []resources.Relationship{{Source: lambdaResource, Target: bqResource}},
 			},
 		},
+		{
+			name: "lambda as resource with aurora cluster behind a proxy",
+			fields: fields{
+				yamlConfig: &config.Config{},
+				tfConfig: &hcl.Config{
+					Resources: []*hcl.Resource{
+						{
+							Type:   "aws_lambda_function",
+							Name:   "my_receiver_lambda",
+							Labels: []string{"aws_lambda_function", "my_receiver_lambda"},
+							Attributes: map[string]any{
+								"function_name": "myReceiver",
+								"environment": map[string]map[string]any{
+									"variables": {
+										"ORDERS_DB_HOST": "aws_db_proxy.orders_db_rds.endpoint",
+									},
+								},
+							},
+						},
+						{
+							Type:   "aws_rds_cluster",
+							Name:   "orders_db_rds",
+							Labels: []string{"aws_rds_cluster", "orders_db_rds"},
+							Attributes: map[string]any{
+								"cluster_identifier": "orders-db",
+							},
+						},
+					},
+				},
+			},
+			want: &resources.ResourceCollection{
+				Resources:     []resources.Resource{clusterResource, clusterLambdaResource},
+				Relationships: []resources.Relationship{{Source: clusterLambdaResource, Target: clusterResource}},
+			},
+		},
 		{
 			name: "lambda as resource with database",
 			fields: fields{
@@ -750,6 +1017,228 @@ func TestTransformer_TransformFromCronToResource(t *testing.T) {
 	}
 }
 
+func TestTransformer_TransformFromEventBusToResource(t *testing.T) {
+	type fields struct {
+		yamlConfig *config.Config
+		tfConfig   *hcl.Config
+	}
+
+	eventBusResource := resources.NewGenericResource("1", "orders", awsresources.EventBusType.String())
+	sqsResource := resources.NewGenericResource("2", "order-queue", awsresources.SQSType.String())
+	defaultEventBusResource := resources.NewGenericResource("1", "default", awsresources.EventBusType.String())
+	lambdaResource := resources.NewGenericResource("2", "auditor", awsresources.LambdaType.String())
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   *resources.ResourceCollection
+	}{
+		{
+			name: "from custom event bus to sqs",
+			fields: fields{
+				yamlConfig: &config.Config{},
+				tfConfig: &hcl.Config{
+					Resources: []*hcl.Resource{
+						{
+							Type:   "aws_cloudwatch_event_bus",
+							Name:   "orders_event_bus",
+							Labels: []string{"aws_cloudwatch_event_bus", "orders_event_bus"},
+							Attributes: map[string]any{
+								"name": "orders",
+							},
+						},
+						{
+							Type:   "aws_cloudwatch_event_rule",
+							Name:   "orders_order_created_rule",
+							Labels: []string{"aws_cloudwatch_event_rule", "orders_order_created_rule"},
+							Attributes: map[string]any{
+								"event_bus_name": "aws_cloudwatch_event_bus.orders_event_bus.name",
+								"event_pattern":  `{"source": ["orders"]}`,
+							},
+						},
+						{
+							Type:   "aws_cloudwatch_event_target",
+							Name:   "orders_order_created_order_queue_sqs_target",
+							Labels: []string{"aws_cloudwatch_event_target", "orders_order_created_order_queue_sqs_target"},
+							Attributes: map[string]any{
+								"rule": "aws_cloudwatch_event_rule.orders_order_created_rule.name",
+								"arn":  "aws_sqs_queue.order_queue_sqs.arn",
+							},
+						},
+						{
+							Type:   "aws_sqs_queue",
+							Name:   "order_queue_sqs",
+							Labels: []string{"aws_sqs_queue", "order_queue_sqs"},
+							Attributes: map[string]any{
+								"name": "order-queue",
+							},
+						},
+					},
+				},
+			},
+			want: &resources.ResourceCollection{
+				Resources:     []resources.Resource{eventBusResource, sqsResource},
+				Relationships: []resources.Relationship{{Source: eventBusResource, Target: sqsResource}},
+			},
+		},
+		{
+			name: "from default event bus to lambda",
+			fields: fields{
+				yamlConfig: &config.Config{},
+				tfConfig: &hcl.Config{
+					Resources: []*hcl.Resource{
+						{
+							Type:   "aws_cloudwatch_event_rule",
+							Name:   "default_instance_stopped_rule",
+							Labels: []string{"aws_cloudwatch_event_rule", "default_instance_stopped_rule"},
+							Attributes: map[string]any{
+								"event_pattern": `{"source": ["aws.ec2"]}`,
+							},
+						},
+						{
+							Type:   "aws_cloudwatch_event_target",
+							Name:   "default_instance_stopped_auditor_lambda_target",
+							Labels: []string{"aws_cloudwatch_event_target", "default_instance_stopped_auditor_lambda_target"},
+							Attributes: map[string]any{
+								"rule": "aws_cloudwatch_event_rule.default_instance_stopped_rule.name",
+								"arn":  "aws_lambda_function.auditor_lambda.arn",
+							},
+						},
+						{
+							Type:   "aws_lambda_function",
+							Name:   "auditor_lambda",
+							Labels: []string{"aws_lambda_function", "auditor_lambda"},
+							Attributes: map[string]any{
+								"function_name": "auditor",
+							},
+						},
+					},
+				},
+			},
+			want: &resources.ResourceCollection{
+				Resources:     []resources.Resource{defaultEventBusResource, lambdaResource},
+				Relationships: []resources.Relationship{{Source: defaultEventBusResource, Target: lambdaResource}},
+			},
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			tr := NewTransformer(
+				tc.fields.yamlConfig,
+				tc.fields.tfConfig,
+			)
+
+			got := tr.Transform()
+
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestTransformer_TransformFromStepFunctionToResource(t *testing.T) {
+	type fields struct {
+		yamlConfig *config.Config
+		tfConfig   *hcl.Config
+	}
+
+	stepFunctionResource := resources.NewGenericResource("1", "orderWorkflow", awsresources.StepFunctionType.String())
+	lambdaResource := resources.NewGenericResource("2", "orderValidator", awsresources.LambdaType.String())
+	sqsResource := resources.NewGenericResource("3", "order-queue", awsresources.SQSType.String())
+
+	tests := []struct {
+		name   string
+		fields fields
+		want   *resources.ResourceCollection
+	}{
+		{
+			name: "from state machine to the lambdas and queues of its tasks",
+			fields: fields{
+				yamlConfig: &config.Config{},
+				tfConfig: &hcl.Config{
+					Resources: []*hcl.Resource{
+						{
+							Type:   "aws_sfn_state_machine",
+							Name:   "order_workflow_state_machine",
+							Labels: []string{"aws_sfn_state_machine", "order_workflow_state_machine"},
+							Attributes: map[string]any{
+								"name": "orderWorkflow",
+								"definition": `{"States": {` +
+									`"Validate": {"Parameters": {"FunctionName": "aws_lambda_function.order_validator_lambda.arn"}}, ` +
+									`"Retry": {"Parameters": {"FunctionName": "aws_lambda_function.order_validator_lambda.arn"}}, ` +
+									`"Publish": {"Parameters": {"QueueUrl": "aws_sqs_queue.order_queue_sqs.url"}}}}`,
+							},
+						},
+						{
+							Type:   "aws_lambda_function",
+							Name:   "order_validator_lambda",
+							Labels: []string{"aws_lambda_function", "order_validator_lambda"},
+							Attributes: map[string]any{
+								"function_name": "orderValidator",
+							},
+						},
+						{
+							Type:   "aws_sqs_queue",
+							Name:   "order_queue_sqs",
+							Labels: []string{"aws_sqs_queue", "order_queue_sqs"},
+							Attributes: map[string]any{
+								"name": "order-queue",
+							},
+						},
+					},
+				},
+			},
+			want: &resources.ResourceCollection{
+				Resources: []resources.Resource{stepFunctionResource, lambdaResource, sqsResource},
+				Relationships: []resources.Relationship{
+					{Source: stepFunctionResource, Target: lambdaResource},
+					{Source: stepFunctionResource, Target: sqsResource},
+				},
+			},
+		},
+		{
+			name: "definition built with jsonencode",
+			fields: fields{
+				yamlConfig: &config.Config{},
+				tfConfig: &hcl.Config{
+					Resources: []*hcl.Resource{
+						{
+							Type:   "aws_sfn_state_machine",
+							Name:   "order_workflow_state_machine",
+							Labels: []string{"aws_sfn_state_machine", "order_workflow_state_machine"},
+							Attributes: map[string]any{
+								"name":       "orderWorkflow",
+								"definition": "jsonencode({StartAt:Validate})",
+							},
+						},
+					},
+				},
+			},
+			want: &resources.ResourceCollection{
+				Resources:     []resources.Resource{stepFunctionResource},
+				Relationships: []resources.Relationship{},
+			},
+		},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			tr := NewTransformer(
+				tc.fields.yamlConfig,
+				tc.fields.tfConfig,
+			)
+
+			got := tr.Transform()
+
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
 func TestTransformer_TransformEndpointAPIGatewayLambda(t *testing.T) {
 	type fields struct {
 		yamlConfig *config.Config
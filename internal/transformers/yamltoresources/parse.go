@@ -2,23 +2,14 @@ package yamltoresources
 
 import (
 	"fmt"
-	"os"
-
-	"gopkg.in/yaml.v3"
 
 	"github.com/diagram-code-generator/resources/pkg/resources"
 	"github.com/joselitofilho/aws-terraform-generator/internal/generators/config"
 )
 
-func Parse(filename string) (*resources.ResourceCollection, error) {
-	data, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, fmt.Errorf("%w", err)
-	}
-
-	var cfg *config.Config
-
-	err = yaml.Unmarshal(data, &cfg)
+// Parse parses the configuration file, with its includes and plugins, into the resources and their relationships.
+func Parse(filename string, opts ...config.YAMLOption) (*resources.ResourceCollection, error) {
+	cfg, err := config.NewYAML(filename, opts...).Parse()
 	if err != nil {
 		return nil, fmt.Errorf("%w", err)
 	}
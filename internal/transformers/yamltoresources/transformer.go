@@ -6,8 +6,6 @@ import (
 	"reflect"
 	"strings"
 
-	"github.com/ettle/strcase"
-
 	"github.com/diagram-code-generator/resources/pkg/resources"
 
 	"github.com/joselitofilho/aws-terraform-generator/internal/fmtcolor"
@@ -21,17 +19,23 @@ var ErrEmptyConfig = errors.New("config file is empty")
 type Transformer struct {
 	yamlConfig *config.Config
 
-	apigatewayByName map[string]resources.Resource
-	cronByName       map[string]resources.Resource
-	databaseByName   map[string]resources.Resource
-	endpointByName   map[string]resources.Resource
-	googleBQByName   map[string]resources.Resource
-	kinesisByName    map[string]resources.Resource
-	lambdaByName     map[string]resources.Resource
-	restfulAPIByName map[string]resources.Resource
-	s3BucketByName   map[string]resources.Resource
-	snsByName        map[string]resources.Resource
-	sqsByName        map[string]resources.Resource
+	apigatewayByName   map[string]resources.Resource
+	cronByName         map[string]resources.Resource
+	databaseByName     map[string]resources.Resource
+	endpointByName     map[string]resources.Resource
+	eventBusByName     map[string]resources.Resource
+	firehoseByName     map[string]resources.Resource
+	googleBQByName     map[string]resources.Resource
+	kinesisByName      map[string]resources.Resource
+	lambdaByName       map[string]resources.Resource
+	restfulAPIByName   map[string]resources.Resource
+	s3BucketByName     map[string]resources.Resource
+	snsByName          map[string]resources.Resource
+	sqsByName          map[string]resources.Resource
+	stepFunctionByName map[string]resources.Resource
+
+	// customByName are the resources of the registered resource types, by type.
+	customByName map[awsresources.ResourceType]map[string]resources.Resource
 
 	relationshipsMap map[awsresources.ResourceARN][]awsresources.ResourceARN
 }
@@ -40,17 +44,22 @@ func NewTransformer(yamlConfig *config.Config) *Transformer {
 	return &Transformer{
 		yamlConfig: yamlConfig,
 
-		apigatewayByName: map[string]resources.Resource{},
-		cronByName:       map[string]resources.Resource{},
-		databaseByName:   map[string]resources.Resource{},
-		endpointByName:   map[string]resources.Resource{},
-		googleBQByName:   map[string]resources.Resource{},
-		kinesisByName:    map[string]resources.Resource{},
-		lambdaByName:     map[string]resources.Resource{},
-		restfulAPIByName: map[string]resources.Resource{},
-		s3BucketByName:   map[string]resources.Resource{},
-		snsByName:        map[string]resources.Resource{},
-		sqsByName:        map[string]resources.Resource{},
+		apigatewayByName:   map[string]resources.Resource{},
+		cronByName:         map[string]resources.Resource{},
+		databaseByName:     map[string]resources.Resource{},
+		endpointByName:     map[string]resources.Resource{},
+		eventBusByName:     map[string]resources.Resource{},
+		firehoseByName:     map[string]resources.Resource{},
+		googleBQByName:     map[string]resources.Resource{},
+		kinesisByName:      map[string]resources.Resource{},
+		lambdaByName:       map[string]resources.Resource{},
+		restfulAPIByName:   map[string]resources.Resource{},
+		s3BucketByName:     map[string]resources.Resource{},
+		snsByName:          map[string]resources.Resource{},
+		sqsByName:          map[string]resources.Resource{},
+		stepFunctionByName: map[string]resources.Resource{},
+
+		customByName: map[awsresources.ResourceType]map[string]resources.Resource{},
 
 		relationshipsMap: map[awsresources.ResourceARN][]awsresources.ResourceARN{},
 	}
@@ -67,11 +76,16 @@ func (t *Transformer) Transform() (*resources.ResourceCollection, error) {
 
 	t.transformAPIGateways(&rscs, &relationships, &id)
 	t.extractKinesisResources(&rscs, &id)
+	t.extractRDSResources(&rscs, &id)
 	t.transformLambdas(&rscs, &relationships, &id)
 	t.extractRestfulAPIResources(&rscs, &id)
 	t.extractS3BucketResources(&rscs, &id)
 	t.extractSNSBucketResources(&rscs, &id)
 	t.extractSQSResources(&rscs, &id)
+	t.extractCustomResources(&rscs, &id)
+	t.transformFirehoses(&rscs, &relationships, &id)
+	t.transformStepFunctions(&rscs, &relationships, &id)
+	t.transformEventBuses(&rscs, &relationships, &id)
 
 	t.buildRelationships(&relationships)
 
@@ -105,14 +119,24 @@ func (t *Transformer) getResourceByARN(arn awsresources.ResourceARN) (resource r
 		resource = t.cronByName[key]
 	case awsresources.LabelAWSEndpoint:
 		resource = t.endpointByName[key]
+	case awsresources.LabelAWSEventBus:
+		resource = t.eventBusByName[key]
+	case awsresources.LabelAWSKinesisFirehose:
+		resource = t.firehoseByName[key]
 	case awsresources.LabelAWSKinesisStream:
 		resource = t.kinesisByName[key]
 	case awsresources.LabelAWSLambdaFunction:
 		resource = t.lambdaByName[key]
 	case awsresources.LabelAWSS3Bucket:
 		resource = t.s3BucketByName[key]
+	case awsresources.LabelAWSSFNStateMachine:
+		resource = t.stepFunctionByName[key]
 	case awsresources.LabelAWSSQSQueue:
 		resource = t.sqsByName[key]
+	default:
+		if resourceType, ok := awsresources.ResourceTypeOfLabel(arn.Type); ok {
+			resource = t.customByName[resourceType][key]
+		}
 	}
 
 	return resource
@@ -126,12 +150,14 @@ func (t *Transformer) extractResourcesByType(
 		res := resourcesList[i]
 
 		resARN := awsresources.ParseResourceARN(res.GetName(), resourceType)
+		_, isRegistered := awsresources.LookupResourceType(resourceType)
+
 		if resARN.Label == "" &&
 			(resourceType == awsresources.KinesisType ||
 				resourceType == awsresources.S3Type ||
-				resourceType == awsresources.SQSType) {
-			arnType := fmt.Sprintf("%s_%s", strcase.ToSnake(resARN.Name), awsresources.SuffixByResource[resourceType])
-			resARN.Label = arnType
+				resourceType == awsresources.SQSType ||
+				isRegistered) {
+			resARN.Label = t.yamlConfig.Naming.Label(resourceType, resARN.Name)
 		}
 
 		key := resARN.LabelOrName()
@@ -157,6 +183,21 @@ func (t *Transformer) extractKinesisResources(rscs *[]resources.Resource, id *in
 	t.extractResourcesByType(configResources, awsresources.KinesisType, t.kinesisByName, rscs, id)
 }
 
+func (t *Transformer) extractRDSResources(rscs *[]resources.Resource, id *int) {
+	for i := range t.yamlConfig.RDS {
+		name := t.yamlConfig.Naming.Case(awsresources.DatabaseType, t.yamlConfig.RDS[i].Name)
+
+		if _, ok := t.databaseByName[name]; !ok {
+			database := resources.NewGenericResource(fmt.Sprintf("%d", *id), name, awsresources.DatabaseType.String())
+			*id++
+
+			*rscs = append(*rscs, database)
+
+			t.databaseByName[name] = database
+		}
+	}
+}
+
 func (t *Transformer) extractRestfulAPIResources(rscs *[]resources.Resource, id *int) {
 	configResources := make([]config.Resource, 0, len(t.yamlConfig.RestfulAPIs))
 	for i := range t.yamlConfig.RestfulAPIs {
@@ -197,49 +238,219 @@ func (t *Transformer) extractSQSResources(rscs *[]resources.Resource, id *int) {
 	t.extractResourcesByType(configResources, awsresources.SQSType, t.sqsByName, rscs, id)
 }
 
+// extractCustomResources extracts the resources of the registered resource types, which are keyed by their label like
+// the resources the lambdas refer to.
+func (t *Transformer) extractCustomResources(rscs *[]resources.Resource, id *int) {
+	for _, def := range awsresources.RegisteredResourceTypes() {
+		customResources := t.yamlConfig.CustomResources[string(def.Type)]
+
+		configResources := make([]config.Resource, 0, len(customResources))
+		for i := range customResources {
+			configResources = append(configResources, customResources[i])
+		}
+
+		t.customByName[def.Type] = map[string]resources.Resource{}
+
+		t.extractResourcesByType(configResources, def.Type, t.customByName[def.Type], rscs, id)
+	}
+}
+
+// transformFirehoses must run after the Kinesis streams, S3 buckets and lambdas have been extracted, so the
+// delivery streams can be linked to their sources, destinations and transformation lambdas.
+func (t *Transformer) transformFirehoses(
+	rscs *[]resources.Resource, relationships *[]resources.Relationship, id *int,
+) {
+	configResources := make([]config.Resource, 0, len(t.yamlConfig.Firehoses))
+	for i := range t.yamlConfig.Firehoses {
+		configResources = append(configResources,
+			reflect.ValueOf(&t.yamlConfig.Firehoses[i]).Interface().(config.Resource))
+	}
+
+	t.extractResourcesByType(configResources, awsresources.FirehoseType, t.firehoseByName, rscs, id)
+
+	for i := range t.yamlConfig.Firehoses {
+		conf := t.yamlConfig.Firehoses[i]
+
+		firehose := t.firehoseByName[conf.Name]
+
+		if source, ok := t.kinesisByName[t.yamlConfig.Naming.Label(awsresources.KinesisType, conf.KinesisSource)]; ok {
+			*relationships = append(*relationships, resources.Relationship{Source: source, Target: firehose})
+		}
+
+		if bucket, ok := t.s3BucketByName[t.yamlConfig.Naming.Label(awsresources.S3Type, conf.Bucket)]; ok {
+			*relationships = append(*relationships, resources.Relationship{Source: firehose, Target: bucket})
+		}
+
+		if conf.TransformationLambda != "" {
+			lambdaName := t.yamlConfig.Naming.Case(awsresources.LambdaType, conf.TransformationLambda)
+
+			t.transformLambda(&config.Lambda{Name: lambdaName}, rscs, relationships, id)
+
+			*relationships = append(*relationships, resources.Relationship{
+				Source: firehose,
+				Target: t.lambdaByName[lambdaName],
+			})
+		}
+	}
+}
+
+// transformStepFunctions must run after the SQS queues and lambdas have been extracted, so the state machines can be
+// linked to the resources their tasks use.
+func (t *Transformer) transformStepFunctions(
+	rscs *[]resources.Resource, relationships *[]resources.Relationship, id *int,
+) {
+	configResources := make([]config.Resource, 0, len(t.yamlConfig.StepFunctions))
+	for i := range t.yamlConfig.StepFunctions {
+		configResources = append(configResources,
+			reflect.ValueOf(&t.yamlConfig.StepFunctions[i]).Interface().(config.Resource))
+	}
+
+	t.extractResourcesByType(configResources, awsresources.StepFunctionType, t.stepFunctionByName, rscs, id)
+
+	for i := range t.yamlConfig.StepFunctions {
+		conf := t.yamlConfig.StepFunctions[i]
+
+		stepFunction := t.stepFunctionByName[conf.Name]
+
+		// Several states can use the same task resource, but it is drawn only once.
+		linked := map[resources.Resource]struct{}{}
+		link := func(target resources.Resource) {
+			if _, ok := linked[target]; !ok {
+				linked[target] = struct{}{}
+
+				*relationships = append(*relationships, resources.Relationship{Source: stepFunction, Target: target})
+			}
+		}
+
+		for j := range conf.States {
+			state := conf.States[j]
+
+			switch {
+			case state.Lambda != "":
+				lambdaName := t.yamlConfig.Naming.Case(awsresources.LambdaType, state.Lambda)
+
+				t.transformLambda(&config.Lambda{Name: lambdaName}, rscs, relationships, id)
+
+				link(t.lambdaByName[lambdaName])
+			case state.SQS != "":
+				if sqs, ok := t.sqsByName[t.yamlConfig.Naming.Label(awsresources.SQSType, state.SQS)]; ok {
+					link(sqs)
+				}
+			}
+		}
+	}
+}
+
+// transformEventBuses must run after the Kinesis streams, SQS queues and lambdas have been extracted, so the buses
+// can be linked to the targets of their rules.
+func (t *Transformer) transformEventBuses(
+	rscs *[]resources.Resource, relationships *[]resources.Relationship, id *int,
+) {
+	configResources := make([]config.Resource, 0, len(t.yamlConfig.EventBuses))
+	for i := range t.yamlConfig.EventBuses {
+		configResources = append(configResources,
+			reflect.ValueOf(&t.yamlConfig.EventBuses[i]).Interface().(config.Resource))
+	}
+
+	t.extractResourcesByType(configResources, awsresources.EventBusType, t.eventBusByName, rscs, id)
+
+	for i := range t.yamlConfig.EventBuses {
+		conf := t.yamlConfig.EventBuses[i]
+
+		eventBus := t.eventBusByName[conf.Name]
+
+		for j := range conf.Rules {
+			rule := conf.Rules[j]
+
+			for _, target := range rule.Lambdas {
+				lambdaName := t.yamlConfig.Naming.Case(awsresources.LambdaType, target.Name)
+
+				t.transformLambda(&config.Lambda{Name: lambdaName}, rscs, relationships, id)
+
+				*relationships = append(*relationships, resources.Relationship{
+					Source: eventBus,
+					Target: t.lambdaByName[lambdaName],
+				})
+			}
+
+			for _, target := range rule.SQSs {
+				if sqs, ok := t.sqsByName[t.yamlConfig.Naming.Label(awsresources.SQSType, target.Name)]; ok {
+					*relationships = append(*relationships, resources.Relationship{Source: eventBus, Target: sqs})
+				}
+			}
+
+			for _, target := range rule.Kinesis {
+				if kinesis, ok := t.kinesisByName[t.yamlConfig.Naming.Label(awsresources.KinesisType, target.Name)]; ok {
+					*relationships = append(*relationships, resources.Relationship{Source: eventBus, Target: kinesis})
+				}
+			}
+
+			for _, target := range rule.StepFunctions {
+				if stepFunction, ok := t.stepFunctionByName[target.Name]; ok {
+					*relationships = append(*relationships, resources.Relationship{Source: eventBus, Target: stepFunction})
+				}
+			}
+		}
+	}
+}
+
 func (t *Transformer) transformAPIGateways(
 	rscs *[]resources.Resource, relationships *[]resources.Relationship, id *int,
 ) {
 	for _, res := range t.yamlConfig.APIGateways {
-		endpointValue := res.APIDomain
+		var endpointRes resources.Resource
 
-		endpointRes, ok := t.endpointByName[endpointValue]
-		if !ok {
-			endpointRes = resources.NewGenericResource(
-				fmt.Sprintf("%d", *id), endpointValue, awsresources.EndpointType.String())
-			*rscs = append(*rscs, endpointRes)
-			*id++
+		if endpointValue := res.APIDomain; endpointValue != "" || !res.IsWebSocket() {
+			var ok bool
+
+			endpointRes, ok = t.endpointByName[endpointValue]
+			if !ok {
+				endpointRes = resources.NewGenericResource(
+					fmt.Sprintf("%d", *id), endpointValue, awsresources.EndpointType.String())
+				*rscs = append(*rscs, endpointRes)
+				*id++
 
-			t.endpointByName[endpointValue] = endpointRes
+				t.endpointByName[endpointValue] = endpointRes
+			}
 		}
 
 		for i := range res.Lambdas {
 			l := res.Lambdas[i]
-			apigValue := fmt.Sprintf("%s %s", l.Verb, l.Path)
 
-			apigRes, ok := t.apigatewayByName[apigValue]
+			apigValue, apigType := fmt.Sprintf("%s %s", l.Verb, l.Path), awsresources.APIGatewayType
+			apigKey := apigValue
+
+			if res.IsWebSocket() {
+				// The WebSocket APIs of a stack may have the same route keys, such as $connect.
+				apigValue, apigType = l.RouteKey, awsresources.WebSocketType
+				apigKey = fmt.Sprintf("%s %s %s", res.StackName, res.Name, l.RouteKey)
+			}
+
+			apigRes, ok := t.apigatewayByName[apigKey]
 
 			if !ok {
-				apigRes = resources.NewGenericResource(
-					fmt.Sprintf("%d", *id), apigValue, awsresources.APIGatewayType.String())
+				apigRes = resources.NewGenericResource(fmt.Sprintf("%d", *id), apigValue, apigType.String())
 				*rscs = append(*rscs, apigRes)
 				*id++
 
-				t.apigatewayByName[apigValue] = apigRes
+				t.apigatewayByName[apigKey] = apigRes
 			}
 
-			lambdaName := awsresources.ToLambdaCase(l.Name)
+			lambdaName := t.yamlConfig.Naming.Case(awsresources.LambdaType, l.Name)
 
-			t.transformLambda(&config.Lambda{Name: lambdaName, Envars: l.Envars}, rscs, relationships, id)
+			t.transformLambda(&config.Lambda{Name: lambdaName, Envars: l.Envars, RDS: l.RDS}, rscs, relationships, id)
 
-			*relationships = append(*relationships,
-				resources.Relationship{
-					Source: apigRes,
-					Target: t.lambdaByName[lambdaName],
-				}, resources.Relationship{
+			*relationships = append(*relationships, resources.Relationship{
+				Source: apigRes,
+				Target: t.lambdaByName[lambdaName],
+			})
+
+			if endpointRes != nil {
+				*relationships = append(*relationships, resources.Relationship{
 					Source: endpointRes,
 					Target: apigRes,
 				})
+			}
 		}
 	}
 }
@@ -252,7 +463,7 @@ func (t *Transformer) transformLambda(
 	}
 
 	lambdaARN := awsresources.ParseResourceARN(res.Name, awsresources.LambdaType)
-	lambdaName := awsresources.ToLambdaCase(lambdaARN.Name)
+	lambdaName := t.yamlConfig.Naming.Case(awsresources.LambdaType, lambdaARN.Name)
 
 	lambda, ok := t.lambdaByName[lambdaName]
 	if !ok {
@@ -282,6 +493,11 @@ func (t *Transformer) transformLambda(
 
 	t.transformLambdaEnvars(res, lambda, lambdaARN, rscs, relationships, id)
 
+	for _, name := range res.RDS {
+		t.fromLambdaToResource(t.yamlConfig.Naming.Case(awsresources.DatabaseType, name), lambda, t.databaseByName, id,
+			awsresources.DatabaseType, rscs, relationships)
+	}
+
 	for _, r := range res.KinesisTriggers {
 		kinesisARN := awsresources.ParseResourceARN(r.SourceARN, awsresources.KinesisType)
 		t.relationshipsMap[kinesisARN] = append(t.relationshipsMap[kinesisARN], lambdaARN)
@@ -325,34 +541,44 @@ func (t *Transformer) transformLambdaEnvars(
 		case awsresources.RestfulAPIType:
 			t.fromLambdaToResource(value, lambda, t.restfulAPIByName, id, resType, rscs, relationships)
 		default:
-			fmtcolor.Yellow.Printf("yaml to resource: unidentified variable: %s=%s\n", k, v)
+			if _, ok := awsresources.LookupResourceType(resType); ok {
+				targetARN := awsresources.ParseResourceARN(v, resType)
+				t.relationshipsMap[lambdaARN] = append(t.relationshipsMap[lambdaARN], targetARN)
+			} else {
+				fmtcolor.Yellow.Printf("yaml to resource: unidentified variable: %s=%s\n", k, v)
+			}
 		}
 	}
 }
 
-func (*Transformer) getValueTypeFromEnvar(k string) (value string, resType awsresources.ResourceType) {
+func (t *Transformer) getValueTypeFromEnvar(k string) (value string, resType awsresources.ResourceType) {
 	switch {
 	case strings.HasSuffix(k, awsresources.EnvarSuffixDBHost):
-		value = transformers.ReplaceSuffix(k, awsresources.EnvarSuffixDBHost, awsresources.ToDatabaseCase)
+		value = transformers.ReplaceSuffix(k, awsresources.EnvarSuffixDBHost, t.toCase(awsresources.DatabaseType))
 		resType = awsresources.DatabaseType
 	case strings.HasSuffix(k, awsresources.EnvarSuffixGoogleBQ):
-		value = transformers.ReplaceSuffix(k, awsresources.EnvarSuffixGoogleBQ, awsresources.ToGoogleBQCase)
+		value = transformers.ReplaceSuffix(k, awsresources.EnvarSuffixGoogleBQ, t.toCase(awsresources.GoogleBQType))
 		resType = awsresources.GoogleBQType
 	case strings.HasSuffix(k, awsresources.EnvarSuffixKinesisStreamURL):
-		value = transformers.ReplaceSuffix(k, awsresources.EnvarSuffixKinesisStreamURL, awsresources.ToKinesisCase)
+		value = transformers.ReplaceSuffix(k, awsresources.EnvarSuffixKinesisStreamURL, t.toCase(awsresources.KinesisType))
 		resType = awsresources.KinesisType
 	case strings.HasSuffix(k, awsresources.EnvarSuffixS3BucketURL):
-		value = transformers.ReplaceSuffix(k, awsresources.EnvarSuffixS3BucketURL, awsresources.ToS3BucketCase)
+		value = transformers.ReplaceSuffix(k, awsresources.EnvarSuffixS3BucketURL, t.toCase(awsresources.S3Type))
 		resType = awsresources.S3Type
 	case strings.HasSuffix(k, awsresources.EnvarSuffixS3BucketName):
-		value = transformers.ReplaceSuffix(k, awsresources.EnvarSuffixS3BucketName, awsresources.ToS3BucketCase)
+		value = transformers.ReplaceSuffix(k, awsresources.EnvarSuffixS3BucketName, t.toCase(awsresources.S3Type))
 		resType = awsresources.S3Type
 	case strings.HasSuffix(k, awsresources.EnvarSuffixSQSQueueURL):
-		value = transformers.ReplaceSuffix(k, awsresources.EnvarSuffixSQSQueueURL, awsresources.ToSQSCase)
+		value = transformers.ReplaceSuffix(k, awsresources.EnvarSuffixSQSQueueURL, t.toCase(awsresources.SQSType))
 		resType = awsresources.SQSType
 	case strings.HasSuffix(k, awsresources.EnvarSuffixRestfulAPI):
-		value = transformers.ReplaceSuffix(k, awsresources.EnvarSuffixRestfulAPI, awsresources.ToRestfulAPICase)
+		value = transformers.ReplaceSuffix(k, awsresources.EnvarSuffixRestfulAPI, t.toCase(awsresources.RestfulAPIType))
 		resType = awsresources.RestfulAPIType
+	default:
+		if registeredType, suffix, ok := awsresources.ResourceTypeOfEnvar(k); ok {
+			value = transformers.ReplaceSuffix(k, suffix, t.toCase(registeredType))
+			resType = registeredType
+		}
 	}
 
 	return value, resType
@@ -374,3 +600,8 @@ func (t *Transformer) fromLambdaToResource(
 
 	*relationships = append(*relationships, resources.Relationship{Source: lambda, Target: r})
 }
+
+// toCase returns the casing function of the resource type naming convention.
+func (t *Transformer) toCase(resourceType awsresources.ResourceType) func(string) string {
+	return func(name string) string { return t.yamlConfig.Naming.Case(resourceType, name) }
+}
@@ -106,6 +106,46 @@ func TestTransformer_Transform(t *testing.T) {
 	err := yaml.Unmarshal(diagramData, &diagramYAML)
 	require.NoError(t, err)
 
+	webSocketRoute := resources.NewGenericResource("1", "$connect", awsresources.WebSocketType.String())
+	webSocketLambda := resources.NewGenericResource("2", "connectHandler", awsresources.LambdaType.String())
+
+	firehoseKinesis := resources.NewGenericResource("1", "myStream", awsresources.KinesisType.String())
+	firehoseBucket := resources.NewGenericResource("2", "my-bucket", awsresources.S3Type.String())
+	firehose := resources.NewGenericResource("3", "myFirehose", awsresources.FirehoseType.String())
+	firehoseLambda := resources.NewGenericResource("4", "myTransformer", awsresources.LambdaType.String())
+
+	eventBusKinesis := resources.NewGenericResource("1", "myStream", awsresources.KinesisType.String())
+	eventBusSQS := resources.NewGenericResource("2", "myQueue", awsresources.SQSType.String())
+	eventBus := resources.NewGenericResource("3", "orders", awsresources.EventBusType.String())
+	eventBusLambda := resources.NewGenericResource("4", "orderProcessor", awsresources.LambdaType.String())
+
+	stepFunctionSQS := resources.NewGenericResource("1", "myQueue", awsresources.SQSType.String())
+	stepFunction := resources.NewGenericResource("2", "orderWorkflow", awsresources.StepFunctionType.String())
+	stepFunctionLambda := resources.NewGenericResource("3", "orderValidator", awsresources.LambdaType.String())
+	stepFunctionEventBus := resources.NewGenericResource("4", "orders", awsresources.EventBusType.String())
+
+	rdsEndpoint := resources.NewGenericResource("1", "orders.example.com", awsresources.EndpointType.String())
+	rdsRoute := resources.NewGenericResource("2", "GET /orders", awsresources.APIGatewayType.String())
+	rdsAPIGatewayLambda := resources.NewGenericResource("3", "orderReader", awsresources.LambdaType.String())
+	rdsDatabase := resources.NewGenericResource("4", "orders-db", awsresources.DatabaseType.String())
+	rdsLambda := resources.NewGenericResource("5", "orderProcessor", awsresources.LambdaType.String())
+
+	namingDatabase := resources.NewGenericResource("1", "orders_db", awsresources.DatabaseType.String())
+	namingRDSLambda := resources.NewGenericResource("2", "OrderProcessor", awsresources.LambdaType.String())
+	namingSQS := resources.NewGenericResource("3", "myQueue", awsresources.SQSType.String())
+	namingStepFunction := resources.NewGenericResource("4", "orderWorkflow", awsresources.StepFunctionType.String())
+	namingLambda := resources.NewGenericResource("5", "OrderValidator", awsresources.LambdaType.String())
+
+	t.Cleanup(awsresources.ResetResourceTypes)
+
+	require.NoError(t, awsresources.RegisterResourceType(awsresources.ResourceTypeDefinition{
+		Type: "ledger", DisplayName: "Ledger", Labels: []string{"acme_ledger"}, EnvarSuffixes: []string{"LEDGER_NAME"},
+	}))
+
+	customLambda := resources.NewGenericResource("1", "worker", awsresources.LambdaType.String())
+	customPayments := resources.NewGenericResource("2", "payments", "Ledger")
+	customRefunds := resources.NewGenericResource("3", "refunds", "Ledger")
+
 	tests := []struct {
 		name      string
 		fields    fields
@@ -117,6 +157,182 @@ func TestTransformer_Transform(t *testing.T) {
 			fields: fields{yamlConfig: diagramYAML},
 			want:   wantResourceCollection,
 		},
+		{
+			name: "websocket api",
+			fields: fields{yamlConfig: &config.Config{
+				APIGateways: []config.APIGateway{
+					{
+						StackName: "mystack",
+						APIG:      true,
+						Protocol:  config.ProtocolWebSocket,
+						Lambdas:   []config.APIGatewayLambda{{Name: "connectHandler", RouteKey: "$connect"}},
+					},
+				},
+			}},
+			want: &resources.ResourceCollection{
+				Resources:     []resources.Resource{webSocketRoute, webSocketLambda},
+				Relationships: []resources.Relationship{{Source: webSocketRoute, Target: webSocketLambda}},
+			},
+		},
+		{
+			name: "firehose delivery stream",
+			fields: fields{yamlConfig: &config.Config{
+				Kinesis: []config.Kinesis{{Name: "myStream"}},
+				Buckets: []config.S3{{Name: "my-bucket"}},
+				Firehoses: []config.Firehose{
+					{
+						Name:                 "myFirehose",
+						KinesisSource:        "myStream",
+						Bucket:               "my-bucket",
+						TransformationLambda: "myTransformer",
+					},
+				},
+			}},
+			want: &resources.ResourceCollection{
+				Resources: []resources.Resource{firehoseKinesis, firehoseBucket, firehose, firehoseLambda},
+				Relationships: []resources.Relationship{
+					{Source: firehoseKinesis, Target: firehose},
+					{Source: firehose, Target: firehoseBucket},
+					{Source: firehose, Target: firehoseLambda},
+				},
+			},
+		},
+		{
+			name: "event bus rule targets",
+			fields: fields{yamlConfig: &config.Config{
+				Kinesis: []config.Kinesis{{Name: "myStream"}},
+				SQSs:    []config.SQS{{Name: "myQueue"}},
+				EventBuses: []config.EventBus{
+					{
+						Name: "orders",
+						Rules: []config.EventRule{
+							{
+								Name:    "orderCreated",
+								Lambdas: []config.EventTarget{{Name: "orderProcessor"}},
+								SQSs:    []config.EventTarget{{Name: "myQueue"}},
+								Kinesis: []config.EventTarget{{Name: "myStream"}},
+							},
+						},
+					},
+				},
+			}},
+			want: &resources.ResourceCollection{
+				Resources: []resources.Resource{eventBusKinesis, eventBusSQS, eventBus, eventBusLambda},
+				Relationships: []resources.Relationship{
+					{Source: eventBus, Target: eventBusLambda},
+					{Source: eventBus, Target: eventBusSQS},
+					{Source: eventBus, Target: eventBusKinesis},
+				},
+			},
+		},
+		{
+			name: "step function tasks",
+			fields: fields{yamlConfig: &config.Config{
+				SQSs: []config.SQS{{Name: "myQueue"}},
+				StepFunctions: []config.StepFunction{
+					{
+						Name: "orderWorkflow",
+						States: []config.StepFunctionState{
+							{Name: "Validate", Lambda: "orderValidator", Next: "Revalidate"},
+							{Name: "Revalidate", Lambda: "orderValidator", Next: "Publish"},
+							{Name: "Publish", SQS: "myQueue", Next: "Done"},
+							{Name: "Done", Type: "Succeed"},
+						},
+					},
+				},
+				EventBuses: []config.EventBus{
+					{
+						Name: "orders",
+						Rules: []config.EventRule{
+							{Name: "orderCreated", StepFunctions: []config.EventTarget{{Name: "orderWorkflow"}}},
+						},
+					},
+				},
+			}},
+			want: &resources.ResourceCollection{
+				Resources: []resources.Resource{stepFunctionSQS, stepFunction, stepFunctionLambda, stepFunctionEventBus},
+				Relationships: []resources.Relationship{
+					{Source: stepFunction, Target: stepFunctionLambda},
+					{Source: stepFunction, Target: stepFunctionSQS},
+					{Source: stepFunctionEventBus, Target: stepFunction},
+				},
+			},
+		},
+		{
+			name: "lambdas connecting to databases",
+			fields: fields{yamlConfig: &config.Config{
+				RDS:     []config.RDS{{Name: "ordersDb"}},
+				Lambdas: []config.Lambda{{Name: "orderProcessor", RDS: []string{"ordersDb"}}},
+				APIGateways: []config.APIGateway{
+					{
+						StackName: "mystack",
+						APIDomain: "orders.example.com",
+						Lambdas: []config.APIGatewayLambda{
+							{Name: "orderReader", Verb: "GET", Path: "/orders", RDS: []string{"ordersDb"}},
+						},
+					},
+				},
+			}},
+			want: &resources.ResourceCollection{
+				Resources: []resources.Resource{
+					rdsEndpoint, rdsRoute, rdsAPIGatewayLambda, rdsDatabase, rdsLambda,
+				},
+				Relationships: []resources.Relationship{
+					{Source: rdsAPIGatewayLambda, Target: rdsDatabase},
+					{Source: rdsRoute, Target: rdsAPIGatewayLambda},
+					{Source: rdsEndpoint, Target: rdsRoute},
+					{Source: rdsLambda, Target: rdsDatabase},
+				},
+			},
+		},
+		{
+			name: "custom naming conventions",
+			fields: fields{yamlConfig: &config.Config{
+				Naming: config.Naming{
+					awsresources.DatabaseType: {Case: config.CaseSnake},
+					awsresources.LambdaType:   {Case: config.CasePascal},
+					awsresources.SQSType:      {Label: "queue_{name}"},
+				},
+				SQSs:    []config.SQS{{Name: "myQueue"}},
+				RDS:     []config.RDS{{Name: "ordersDb"}},
+				Lambdas: []config.Lambda{{Name: "orderProcessor", RDS: []string{"ordersDb"}}},
+				StepFunctions: []config.StepFunction{
+					{
+						Name: "orderWorkflow",
+						States: []config.StepFunctionState{
+							{Name: "Validate", Lambda: "orderValidator", Next: "Publish"},
+							{Name: "Publish", SQS: "myQueue"},
+						},
+					},
+				},
+			}},
+			want: &resources.ResourceCollection{
+				Resources: []resources.Resource{
+					namingDatabase, namingRDSLambda, namingSQS, namingStepFunction, namingLambda,
+				},
+				Relationships: []resources.Relationship{
+					{Source: namingRDSLambda, Target: namingDatabase},
+					{Source: namingStepFunction, Target: namingLambda},
+					{Source: namingStepFunction, Target: namingSQS},
+				},
+			},
+		},
+		{
+			name: "custom resources of a plugin",
+			fields: fields{yamlConfig: &config.Config{
+				CustomResources: map[string][]config.CustomResource{
+					"ledger": {{"name": "payments"}, {"name": "refunds"}},
+				},
+				Lambdas: []config.Lambda{{
+					Name:   "worker",
+					Envars: map[string]string{"PAYMENTS_LEDGER_NAME": "acme_ledger.payments.ledger_name"},
+				}},
+			}},
+			want: &resources.ResourceCollection{
+				Resources:     []resources.Resource{customLambda, customPayments, customRefunds},
+				Relationships: []resources.Relationship{{Source: customLambda, Target: customPayments}},
+			},
+		},
 		{
 			name:      "when YAML is invalid or empty should return an error",
 			fields:    fields{yamlConfig: nil},
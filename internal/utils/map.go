@@ -1,7 +1,13 @@
 package utils
 
+// MergeStringMap returns a new map with the values of left overridden by the values of right. The given maps are not
+// modified.
 func MergeStringMap(left, right map[string]string) map[string]string {
-	result := left
+	result := make(map[string]string, len(left)+len(right))
+	for k, v := range left {
+		result[k] = v
+	}
+
 	for k, v := range right {
 		result[k] = v
 	}
@@ -0,0 +1,12 @@
+package utils
+
+// FirstNonEmpty returns the first of the given values that is not empty, or an empty string if all of them are.
+func FirstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
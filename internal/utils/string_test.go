@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFirstNonEmpty(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   string
+	}{
+		{name: "no values", values: nil, want: ""},
+		{name: "all empty", values: []string{"", ""}, want: ""},
+		{name: "first value", values: []string{"a", "b"}, want: "a"},
+		{name: "skips empty values", values: []string{"", "", "c"}, want: "c"},
+	}
+
+	for i := range tests {
+		tc := tests[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, FirstNonEmpty(tc.values...))
+		})
+	}
+}